@@ -0,0 +1,31 @@
+Feature: append a prototype branch
+
+  Background:
+    Given setting "push-new-branches" is "true"
+    And the current branch is a feature branch "existing"
+    And the commits
+      | BRANCH   | LOCATION      | MESSAGE         |
+      | existing | local, origin | existing commit |
+    When I run "git-town append --prototype new"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH   | COMMAND                             |
+      | existing | git fetch --prune --tags            |
+      |          | git checkout main                   |
+      | main     | git rebase origin/main              |
+      |          | git checkout existing               |
+      | existing | git merge --no-edit origin/existing |
+      |          | git merge --no-edit main            |
+      |          | git branch new existing             |
+      |          | git checkout new                    |
+    And the current branch is now "new"
+    And now these commits exist
+      | BRANCH   | LOCATION      | MESSAGE         |
+      | existing | local, origin | existing commit |
+      | new      | local         | existing commit |
+    And this branch hierarchy exists now
+      | BRANCH   | PARENT   |
+      | existing | main     |
+      | new      | existing |
+    And local setting "prototype-branches" is now "new"
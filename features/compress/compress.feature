@@ -0,0 +1,33 @@
+Feature: compress the commits of a feature branch
+
+  Background:
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION      | MESSAGE | FILE NAME | FILE CONTENT |
+      | feature | local, origin | commit1 | file1     | content1     |
+      |         |               | commit2 | file2     | content2     |
+    And an uncommitted file
+    When I run "git-town compress"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH  | COMMAND                                     |
+      | feature | git add -A                                  |
+      |         | git stash                                   |
+      |         | git reset --soft {{ sha 'Initial commit' }} |
+      |         | git commit -m commit1                       |
+      |         | git push --force-with-lease                 |
+      |         | git stash pop                               |
+    And the current branch is still "feature"
+    And the uncommitted file still exists
+    And now these commits exist
+      | BRANCH  | LOCATION      | MESSAGE |
+      | feature | local, origin | commit1 |
+    And file "file2" still has content "content2"
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then the current branch is still "feature"
+    And the uncommitted file still exists
+    And now the initial commits exist
+    And the initial branches and hierarchy exist
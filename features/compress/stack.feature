@@ -0,0 +1,58 @@
+Feature: compress a stack of feature branches
+
+  Background:
+    Given a feature branch "parent"
+    And a feature branch "child" as a child of "parent"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE        | FILE NAME    |
+      | parent | local, origin | parent commit1 | parent_file1 |
+      |        |               | parent commit2 | parent_file2 |
+    And the current branch is "parent"
+
+  Scenario: compress the stack with the rebase sync strategy
+    Given setting "sync-strategy" is "rebase"
+    And I ran "git-town sync --all"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE       | FILE NAME   |
+      | child  | local, origin | child commit1 | child_file1 |
+      |        |               | child commit2 | child_file2 |
+    When I run "git-town compress --stack -m compressed"
+    Then the current branch is still "parent"
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE    |
+      | child  | local, origin | compressed |
+      |        |               | compressed |
+      | parent | local, origin | compressed |
+    And the initial branch hierarchy exists
+
+  Scenario: restack the children with the rebase sync strategy
+    Given setting "sync-strategy" is "rebase"
+    And I ran "git-town sync --all"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE       | FILE NAME   |
+      | child  | local, origin | child commit1 | child_file1 |
+    When I run "git-town compress"
+    Then the current branch is still "parent"
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE        |
+      | child  | local, origin | parent commit1 |
+      |        |               | child commit1  |
+      | parent | local, origin | parent commit1 |
+
+  Scenario: undo
+    Given setting "sync-strategy" is "rebase"
+    And I ran "git-town sync --all"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE       | FILE NAME   |
+      | child  | local, origin | child commit1 | child_file1 |
+    And I ran "git-town compress --stack -m compressed"
+    When I run "git-town undo"
+    Then the current branch is still "parent"
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE        |
+      | child  | local, origin | parent commit1 |
+      |        |               | parent commit2 |
+      |        |               | child commit1  |
+      | parent | local, origin | parent commit1 |
+      |        |               | parent commit2 |
+    And the initial branch hierarchy exists
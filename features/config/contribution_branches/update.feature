@@ -0,0 +1,27 @@
+Feature: change the contribution branches
+
+  Background:
+    Given the feature branches "alpha" and "beta"
+
+  Scenario: add a contribution branch
+    When I run "git-town config contribution-branches add beta"
+    Then local setting "contribution-branches" is now "beta"
+
+  Scenario: add an observed branch as a contribution branch
+    Given setting "observed-branches" is "alpha beta"
+    When I run "git-town config contribution-branches add alpha"
+    Then local setting "contribution-branches" is now "alpha"
+    And local setting "observed-branches" is now "beta"
+
+  Scenario: add a contribution branch twice
+    Given setting "contribution-branches" is "alpha"
+    When I run "git-town config contribution-branches add alpha"
+    Then it prints the error:
+      """
+      branch "alpha" is already in the contribution branches
+      """
+
+  Scenario: remove a contribution branch
+    Given setting "contribution-branches" is "alpha"
+    When I run "git-town config contribution-branches remove alpha"
+    Then local setting "contribution-branches" is now ""
@@ -0,0 +1,36 @@
+Feature: display whether Git Town records its runs
+
+  Scenario: default value
+    When I run "git-town config log"
+    Then it prints:
+      """
+      no
+      """
+
+  Scenario Outline: valid settings
+    Given global setting "log" is "<VALUE>"
+    When I run "git-town config log"
+    Then it prints:
+      """
+      <OUTPUT>
+      """
+    Examples:
+      | VALUE | OUTPUT |
+      | yes   | yes    |
+      | on    | yes    |
+      | true  | yes    |
+      | 1     | yes    |
+      | t     | yes    |
+      | no    | no     |
+      | off   | no     |
+      | false | no     |
+      | f     | no     |
+      | 0     | no     |
+
+  Scenario: invalid value
+    Given global setting "log" is "zonk"
+    When I run "git-town config log"
+    Then it prints the error:
+      """
+      invalid value for git-town.log: "zonk". Please provide either "true" or "false"
+      """
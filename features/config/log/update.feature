@@ -0,0 +1,27 @@
+Feature: enable or disable the run log
+
+  Scenario Outline: valid settings
+    When I run "git-town config log <GIVE>"
+    Then global setting "log" is now "<WANT>"
+
+    Examples:
+      | GIVE  | WANT  |
+      | true  | true  |
+      | t     | true  |
+      | 1     | true  |
+      | on    | true  |
+      | yes   | true  |
+      | false | false |
+      | f     | false |
+      | 0     | false |
+      | off   | false |
+      | no    | false |
+
+  Scenario: invalid value
+    Given global setting "log" is "false"
+    When I run "git-town config log zonk"
+    Then it prints the error:
+      """
+      invalid argument: "zonk". Please provide either "yes" or "no"
+      """
+    And global setting "log" is still "false"
@@ -0,0 +1,17 @@
+Feature: display the observed branches
+
+  Scenario: unconfigured
+    When I run "git-town config observed-branches"
+    Then it prints:
+      """
+      (not set)
+      """
+
+  Scenario: configured
+    Given setting "observed-branches" is "alpha beta"
+    When I run "git-town config observed-branches"
+    Then it prints:
+      """
+      alpha
+      beta
+      """
@@ -0,0 +1,41 @@
+Feature: change the observed branches
+
+  Background:
+    Given the feature branches "alpha" and "beta"
+
+  Scenario: add an observed branch
+    Given setting "observed-branches" is "alpha"
+    When I run "git-town config observed-branches add beta"
+    Then local setting "observed-branches" is now "alpha beta"
+
+  Scenario: add a contribution branch as an observed branch
+    Given setting "contribution-branches" is "alpha"
+    When I run "git-town config observed-branches add alpha"
+    Then local setting "observed-branches" is now "alpha"
+    And local setting "contribution-branches" is now ""
+
+  Scenario: add the main branch
+    When I run "git-town config observed-branches add main"
+    Then it prints the error:
+      """
+      branch "main" is not a feature branch
+      """
+
+  Scenario: add a non-existing branch
+    When I run "git-town config observed-branches add zonk"
+    Then it prints the error:
+      """
+      there is no branch named "zonk"
+      """
+
+  Scenario: remove an observed branch
+    Given setting "observed-branches" is "alpha beta"
+    When I run "git-town config observed-branches remove alpha"
+    Then local setting "observed-branches" is now "beta"
+
+  Scenario: remove a branch that isn't observed
+    When I run "git-town config observed-branches remove alpha"
+    Then it prints the error:
+      """
+      branch "alpha" is not in the observed branches
+      """
@@ -26,11 +26,12 @@ Feature: display the current offline status
       | false | no     |
       | f     | no     |
       | 0     | no     |
+      | auto  | auto   |
 
   Scenario: invalid value
     Given global setting "offline" is "zonk"
     When I run "git-town config offline"
     Then it prints the error:
       """
-      invalid value for git-town.offline: "zonk". Please provide either "true" or "false"
+      invalid value for git-town.offline: "zonk". Please provide either "true", "false", or "auto"
       """
@@ -16,12 +16,13 @@ Feature: change offline mode
       | 0     | false |
       | off   | false |
       | no    | false |
+      | auto  | auto  |
 
   Scenario: invalid value
     Given global setting "offline" is "false"
     When I run "git-town config offline zonk"
     Then it prints the error:
       """
-      invalid argument: "zonk". Please provide either "yes" or "no"
+      invalid argument: "zonk". Please provide either "yes", "no", or "auto"
       """
     And global setting "offline" is still "false"
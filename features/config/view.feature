@@ -9,9 +9,12 @@ Feature: show the configuration
       Branches:
         main branch: main
         perennial branches: qa, staging
+        observed branches: (not set)
+        contribution branches: (not set)
 
       Configuration:
         offline: no
+        run log: no
         pull branch strategy: rebase
         run pre-push hook: yes
         push new branches: no
@@ -37,9 +40,12 @@ Feature: show the configuration
       Branches:
         main branch: main
         perennial branches: qa, staging
+        observed branches: (not set)
+        contribution branches: (not set)
 
       Configuration:
         offline: no
+        run log: no
         pull branch strategy: rebase
         run pre-push hook: yes
         push new branches: no
@@ -71,9 +77,12 @@ Feature: show the configuration
       Branches:
         main branch: (not set)
         perennial branches: (not set)
+        observed branches: (not set)
+        contribution branches: (not set)
 
       Configuration:
         offline: no
+        run log: no
         pull branch strategy: rebase
         run pre-push hook: yes
         push new branches: no
@@ -87,3 +96,27 @@ Feature: show the configuration
         GitLab token: (not set)
         Gitea token: (not set)
       """
+
+  Scenario: API tokens
+    Given local setting "github-token" is "ghp_1234567890abcdef"
+    And my GitHub CLI configuration:
+      """
+      github.com:
+        user: git-town
+        oauth_token: gho_ignored
+      """
+    And my GitLab CLI configuration:
+      """
+      hosts:
+        gitlab.com:
+          token: glpat-0987654321
+      """
+    When I run "git-town config"
+    Then it prints:
+      """
+      Hosting:
+        hosting service override: (not set)
+        GitHub token: ghp_************cdef (from Git config git-town.github-token)
+        GitLab token: glpa********4321 (from glab CLI configuration)
+        Gitea token: (not set)
+      """
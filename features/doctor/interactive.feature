@@ -0,0 +1,20 @@
+@skipWindows
+Feature: choose which problems to repair
+
+  Scenario: repair some problems
+    Given local setting "sync-strategy" is "zonk"
+    And local setting "pull-branch-strategy" is "zonk"
+    When I run "git-town doctor" and answer the prompts:
+      | PROMPT                                                             | ANSWER        |
+      | Fix problem 1 (remove it so that Git Town uses the default value)? | [ENTER]       |
+      | Fix problem 2 (remove it so that Git Town uses the default value)? | [DOWN][ENTER] |
+    Then it prints the error:
+      """
+      Fixed problem 1.
+      """
+    And it prints the error:
+      """
+      some problems remain, please fix them manually
+      """
+    And local setting "pull-branch-strategy" no longer exists
+    And local setting "sync-strategy" is now "zonk"
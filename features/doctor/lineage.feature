@@ -0,0 +1,30 @@
+Feature: repair the branch lineage
+
+  Background:
+    Given the feature branches "alpha" and "beta"
+    And a feature branch "child" as a child of "alpha"
+    And a perennial branch "qa"
+    And the parent branch of "alpha" is "beta"
+    And the parent branch of "beta" is "alpha"
+    And the parent branch of "qa" is "main"
+    And the parent branch of "deleted" is "main"
+    And a feature branch "orphan"
+    And the parent branch of "orphan" is "deleted"
+
+  Scenario: report the problems
+    When I run "git-town doctor --fix"
+    Then it prints:
+      """
+      1. the branches "alpha" and "beta" are their own ancestors: alpha -> beta -> alpha
+         fix: remove the parents of "alpha" and "beta" so that Git Town asks for them again
+      2. the lineage contains the branch "deleted", which doesn't exist
+         fix: remove the parent entry of "deleted"
+      3. the parent "deleted" of branch "orphan" doesn't exist
+         fix: make "main" the parent of "orphan"
+      4. the perennial branch "qa" has the parent "main", but perennial branches have no parents
+         fix: remove the parent of "qa"
+      """
+    And this branch hierarchy exists now
+      | BRANCH | PARENT |
+      | child  | alpha  |
+      | orphan | main   |
@@ -0,0 +1,10 @@
+Feature: doctor finds no problems
+
+  Scenario: healthy configuration
+    Given the feature branches "alpha" and "beta"
+    And a feature branch "child" as a child of "alpha"
+    When I run "git-town doctor"
+    Then it prints:
+      """
+      Git Town found no problems with the configuration of this repository.
+      """
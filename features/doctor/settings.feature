@@ -0,0 +1,64 @@
+Feature: repair settings
+
+  Scenario: deprecated, invalid, and unknown settings
+    Given local setting "push-verify" is "false"
+    And local setting "sync-strategy" is "zonk"
+    And global setting "offline" is "maybe"
+    And local setting "main-branch" is "main"
+    When I run "git-town doctor --fix"
+    Then it prints the error:
+      """
+      1. the local setting "git-town.push-verify" is deprecated
+         fix: rename it to "git-town.push-hook"
+      2. Git Town doesn't know the local setting "git-town.main-branch". Please check it for typos.
+      3. the global setting "git-town.offline" has the invalid value "maybe"
+         fix: remove it so that Git Town uses the default value
+      4. the local setting "git-town.sync-strategy" has the invalid value "zonk"
+         fix: remove it so that Git Town uses the default value
+      """
+    And it prints the error:
+      """
+      some problems remain, please fix them manually
+      """
+    And local setting "push-hook" is now "false"
+    And local setting "push-verify" no longer exists
+    And local setting "sync-strategy" no longer exists
+    And global setting "offline" no longer exists
+    And local setting "main-branch" is now "main"
+
+  Scenario: branch type settings with branches that don't exist
+    Given the perennial branches are "qa"
+    And local setting "observed-branches" is "upstream-feature"
+    When I run "git-town doctor --fix"
+    Then it prints:
+      """
+      1. the observed branches contain "upstream-feature", which doesn't exist
+         fix: remove "upstream-feature" from the observed branches
+      2. the perennial branches contain "qa", which doesn't exist
+         fix: remove "qa" from the perennial branches
+      """
+    And there are still no perennial branches
+    And local setting "observed-branches" is now ""
+
+  Scenario: API token for a different code hosting service
+    Given the origin is "https://github.com/git-town/git-town.git"
+    And local setting "gitlab-token" is "123456"
+    When I run "git-town doctor --fix"
+    Then it prints:
+      """
+      1. the local setting "git-town.gitlab-token" contains an API token for GitLab, but this repository is hosted on GitHub
+         fix: remove it
+      """
+    And local setting "gitlab-token" no longer exists
+
+  Scenario: missing main branch
+    Given local setting "main-branch-name" is "zonk"
+    When I run "git-town doctor"
+    Then it prints the error:
+      """
+      1. the main branch "zonk" doesn't exist. Please run "git town config main-branch <branch>" to configure an existing branch.
+      """
+    And it prints the error:
+      """
+      some problems remain, please fix them manually
+      """
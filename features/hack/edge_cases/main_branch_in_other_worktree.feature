@@ -0,0 +1,30 @@
+Feature: main branch is checked out in another worktree
+
+  Background:
+    Given the current branch is a feature branch "existing"
+    And the commits
+      | BRANCH | LOCATION | MESSAGE     |
+      | main   | origin   | main commit |
+    And the current branch is "existing"
+    And branch "main" is checked out in another worktree
+    When I run "git-town hack new"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH   | COMMAND                               |
+      | existing | git fetch --prune --tags              |
+      |          | git branch --no-track new origin/main |
+      |          | git checkout new                      |
+    And it prints:
+      """
+      Skipping branch "main" because it is checked out in the worktree at
+      """
+    And the current branch is now "new"
+    And this branch hierarchy exists now
+      | BRANCH   | PARENT |
+      | existing | main   |
+      | new      | main   |
+    And now these commits exist
+      | BRANCH | LOCATION | MESSAGE     |
+      | main   | origin   | main commit |
+      | new    | local    | main commit |
@@ -10,7 +10,7 @@ Feature: display debug statistics
     When I run "git-town hack new --debug"
     Then it prints:
       """
-      Ran 23 shell commands.
+      Ran 22 shell commands.
       """
     And the current branch is now "new"
 
@@ -19,6 +19,6 @@ Feature: display debug statistics
     When I run "git town undo --debug"
     Then it prints:
       """
-      Ran 13 shell commands.
+      Ran 12 shell commands.
       """
     And the current branch is now "main"
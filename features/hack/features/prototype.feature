@@ -0,0 +1,39 @@
+Feature: create a prototype branch
+
+  Background:
+    Given setting "push-new-branches" is "true"
+    And the commits
+      | BRANCH | LOCATION | MESSAGE       |
+      | main   | origin   | origin commit |
+    And the current branch is "main"
+    When I run "git-town hack --prototype new"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | main   | git fetch --prune --tags |
+      |        | git rebase origin/main   |
+      |        | git branch new main      |
+      |        | git checkout new         |
+    And the current branch is now "new"
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE       |
+      | main   | local, origin | origin commit |
+      | new    | local         | origin commit |
+    And this branch hierarchy exists now
+      | BRANCH | PARENT |
+      | new    | main   |
+    And local setting "prototype-branches" is now "new"
+
+  Scenario: undo
+    When I run "git town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND           |
+      | new    | git checkout main |
+      | main   | git branch -D new |
+    And the current branch is now "main"
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE       |
+      | main   | local, origin | origin commit |
+    And no branch hierarchy exists now
+    And local setting "prototype-branches" is now ""
@@ -13,7 +13,9 @@ Feature: help for commands
       | aliases                     |
       | append                      |
       | completions                 |
+      | compress                    |
       | config                      |
+      | config log                  |
       | config main-branch          |
       | config push-new-branches    |
       | config offline              |
@@ -21,18 +23,24 @@ Feature: help for commands
       | config pull-branch-strategy |
       | config sync-strategy        |
       | diff-parent                 |
+      | doctor                      |
       | hack                        |
       | help                        |
       | kill                        |
+      | log                         |
       | new-pull-request            |
+      | park                        |
       | prepend                     |
       | prune-branches              |
+      | publish                     |
       | rename-branch               |
       | repo                        |
       | set-parent                  |
       | ship                        |
       | sync                        |
+      | unpark                      |
       | version                     |
+      | walk                        |
 
   Scenario Outline: outside a Git repository
     Given I am outside a Git repo
@@ -0,0 +1,36 @@
+Feature: run hooks configured in the Git configuration
+
+  Scenario: post-hack hook
+    Given setting "hooks.post-hack" is "echo created $GIT_TOWN_BRANCH from $GIT_TOWN_PARENT"
+    And the current branch is "main"
+    When I run "git-town hack new"
+    Then it runs the commands
+      | BRANCH | COMMAND                                                     |
+      | main   | git fetch --prune --tags                                    |
+      |        | git rebase origin/main                                      |
+      |        | git branch new main                                         |
+      |        | git checkout new                                            |
+      | <none> | sh -c "echo created $GIT_TOWN_BRANCH from $GIT_TOWN_PARENT" |
+    And it prints:
+      """
+      created new from main
+      """
+    And the current branch is now "new"
+
+  Scenario: hook defined as executable and in the Git configuration
+    Given the current branch is "main"
+    And an executable "post-hack" hook:
+      """
+      #!/bin/sh
+      echo "executable hook"
+      """
+    And setting "hooks.post-hack" is "echo configured hook"
+    When I run "git-town hack new"
+    Then it prints:
+      """
+      executable hook
+      """
+    And it prints:
+      """
+      configured hook
+      """
@@ -0,0 +1,32 @@
+Feature: run executable hooks before and after syncing
+
+  Background:
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION | MESSAGE        |
+      | feature | local    | feature commit |
+    And an executable "pre-sync" hook:
+      """
+      #!/bin/sh
+      echo "running the $GIT_TOWN_HOOK hook for branch $GIT_TOWN_BRANCH with parent $GIT_TOWN_PARENT"
+      """
+    And an executable "post-sync" hook:
+      """
+      #!/bin/sh
+      echo "running the $GIT_TOWN_HOOK hook for branch $GIT_TOWN_BRANCH"
+      git branch --show-current
+      """
+    When I run "git-town sync"
+
+  Scenario: result
+    Then it prints:
+      """
+      running the pre-sync hook for branch feature with parent main
+      """
+    And it prints:
+      """
+      running the post-sync hook for branch feature
+      feature
+      """
+    And all branches are now synchronized
+    And the current branch is still "feature"
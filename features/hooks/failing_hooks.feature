@@ -0,0 +1,50 @@
+Feature: failing hooks
+
+  Background:
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION      | MESSAGE        |
+      | feature | local, origin | feature commit |
+
+  Scenario: failing pre-ship hook
+    Given an executable "pre-ship" hook:
+      """
+      #!/bin/sh
+      echo "tests are failing"
+      exit 1
+      """
+    When I run "git-town ship -m done"
+    Then it prints the error:
+      """
+      the pre-ship hook failed: exit status 1
+      """
+    And the current branch is still "feature"
+    And now the initial commits exist
+    And the initial branch hierarchy exists
+
+  Scenario: failing pre-ship hook, undo
+    Given an executable "pre-ship" hook:
+      """
+      #!/bin/sh
+      exit 1
+      """
+    And I run "git-town ship -m done"
+    When I run "git-town undo"
+    Then it runs no commands
+    And it prints the error:
+      """
+      nothing to undo
+      """
+
+  Scenario: failing post-ship hook
+    Given setting "hooks.post-ship" is "exit 1"
+    When I run "git-town ship -m done"
+    Then it prints:
+      """
+      Warning: the post-ship hook failed: exit status 1
+      """
+    And the current branch is now "main"
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE |
+      | main   | local, origin | done    |
+    And no branch hierarchy exists now
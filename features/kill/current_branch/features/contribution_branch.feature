@@ -0,0 +1,23 @@
+Feature: delete the current contribution branch
+
+  Background:
+    Given the current branch is a feature branch "contribution"
+    And setting "contribution-branches" is "contribution"
+    And the commits
+      | BRANCH       | LOCATION      | MESSAGE             |
+      | contribution | local, origin | contribution commit |
+    When I run "git-town kill"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH       | COMMAND                    |
+      | contribution | git fetch --prune --tags   |
+      |              | git checkout main          |
+      | main         | git branch -D contribution |
+    And the current branch is now "main"
+    And the branches are now
+      | REPOSITORY | BRANCHES           |
+      | local      | main               |
+      | origin     | main, contribution |
+    And no branch hierarchy exists now
+    And local setting "contribution-branches" is now ""
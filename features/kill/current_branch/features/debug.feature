@@ -12,7 +12,7 @@ Feature: display debug statistics
     When I run "git-town kill --debug"
     Then it prints:
       """
-      Ran 29 shell commands.
+      Ran 27 shell commands.
       """
     And the current branch is now "main"
 
@@ -0,0 +1,34 @@
+Feature: delete the current observed branch
+
+  Background:
+    Given the current branch is a feature branch "observed"
+    And setting "observed-branches" is "observed"
+    And the commits
+      | BRANCH   | LOCATION      | MESSAGE         |
+      | observed | local, origin | observed commit |
+    When I run "git-town kill"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH   | COMMAND                  |
+      | observed | git fetch --prune --tags |
+      |          | git checkout main        |
+      | main     | git branch -D observed   |
+    And the current branch is now "main"
+    And the branches are now
+      | REPOSITORY | BRANCHES       |
+      | local      | main           |
+      | origin     | main, observed |
+    And no branch hierarchy exists now
+    And local setting "observed-branches" is now ""
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND                                         |
+      | main   | git branch observed {{ sha 'observed commit' }} |
+      |        | git checkout observed                           |
+    And the current branch is now "observed"
+    And now the initial commits exist
+    And the initial branches and hierarchy exist
+    And local setting "observed-branches" is now "observed"
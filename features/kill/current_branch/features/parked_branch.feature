@@ -0,0 +1,30 @@
+Feature: delete the current parked branch
+
+  Background:
+    Given the current branch is a local feature branch "parked"
+    And setting "parked-branches" is "parked"
+    And the commits
+      | BRANCH | LOCATION | MESSAGE       |
+      | parked | local    | parked commit |
+    When I run "git-town kill"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | parked | git fetch --prune --tags |
+      |        | git checkout main        |
+      | main   | git branch -D parked     |
+    And the current branch is now "main"
+    And no branch hierarchy exists now
+    And local setting "parked-branches" is now ""
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND                                     |
+      | main   | git branch parked {{ sha 'parked commit' }} |
+      |        | git checkout parked                         |
+    And the current branch is now "parked"
+    And now the initial commits exist
+    And the initial branches and hierarchy exist
+    And local setting "parked-branches" is now "parked"
@@ -0,0 +1,68 @@
+Feature: display what Git Town did in earlier runs
+
+  Scenario: run log disabled
+    Given I ran "git-town hack new"
+    When I run "git-town log"
+    Then it prints:
+      """
+      The run log is empty. To enable it, run "git town config log yes".
+      """
+
+  Scenario: list the recorded runs
+    Given global setting "log" is "true"
+    And I ran "git-town hack new"
+    When I run "git-town log"
+    Then it prints something like:
+      """
+      \d{8}-\d{6}-[0-9a-f]{6}  \d{4}-\d\d-\d\d \d\d:\d\d:\d\d  git-town hack new  \(\d+ commands
+      """
+
+  Scenario: display the most recent run
+    Given global setting "log" is "true"
+    And I ran "git-town hack new"
+    When I run "git-town log last"
+    Then it prints:
+      """
+      command: git-town hack new
+      """
+    And it prints:
+      """
+      git-town.main-branch-name: main
+      """
+    And it prints something like:
+      """
+      exit 0  \S+  git checkout new
+      """
+    And it prints:
+      """
+      "Command": "hack",
+      """
+
+  Scenario: record failed commands and the runstate of an unfinished run
+    Given global setting "log" is "true"
+    And the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION | MESSAGE                    | FILE NAME        | FILE CONTENT    |
+      | main    | local    | conflicting main commit    | conflicting_file | main content    |
+      | feature | local    | conflicting feature commit | conflicting_file | feature content |
+    And I ran "git-town sync"
+    When I run "git-town log last"
+    Then it prints:
+      """
+      exit 1
+      """
+    And it prints:
+      """
+      git merge --no-edit main
+      """
+    And it prints:
+      """
+      "UnfinishedDetails": {
+      """
+
+  Scenario: unknown run
+    When I run "git-town log zonk"
+    Then it prints the error:
+      """
+      the run log contains no run with ID "zonk"
+      """
@@ -8,7 +8,7 @@ Feature: display debug statistics
     When I run "git-town new-pull-request --debug"
     Then it prints:
       """
-      Ran 31 shell commands.
+      Ran 32 shell commands.
       """
     And "open" launches a new pull request with this url in my browser:
       """
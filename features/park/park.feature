@@ -0,0 +1,51 @@
+Feature: park a branch
+
+  Background:
+    Given the feature branches "alpha" and "beta"
+
+  Scenario: park the current branch
+    Given the current branch is "alpha"
+    When I run "git-town park"
+    Then it runs no commands
+    And the current branch is still "alpha"
+    And local setting "parked-branches" is now "alpha"
+
+  Scenario: park the given branch
+    Given the current branch is "alpha"
+    And setting "parked-branches" is "alpha"
+    When I run "git-town park beta"
+    Then it runs no commands
+    And the current branch is still "alpha"
+    And local setting "parked-branches" is now "alpha beta"
+
+  Scenario: undo
+    Given the current branch is "alpha"
+    And I ran "git-town park"
+    When I run "git-town undo"
+    Then it runs no commands
+    And local setting "parked-branches" is now ""
+
+  Scenario: branch is already parked
+    Given setting "parked-branches" is "alpha"
+    When I run "git-town park alpha"
+    Then it runs no commands
+    And it prints the error:
+      """
+      branch "alpha" is already parked
+      """
+
+  Scenario: main branch
+    When I run "git-town park main"
+    Then it runs no commands
+    And it prints the error:
+      """
+      branch "main" is not a feature branch, only feature branches can be parked
+      """
+
+  Scenario: non-existing branch
+    When I run "git-town park zonk"
+    Then it runs no commands
+    And it prints the error:
+      """
+      there is no local branch named "zonk"
+      """
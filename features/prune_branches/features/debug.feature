@@ -13,7 +13,7 @@ Feature: display debug statistics
     When I run "git-town prune-branches --debug"
     Then it prints:
       """
-      Ran 23 shell commands.
+      Ran 22 shell commands.
       """
     And the current branch is now "main"
     And the branches are now
@@ -0,0 +1,54 @@
+Feature: publish a prototype branch
+
+  Background:
+    Given the current branch is a local feature branch "prototype"
+    And setting "prototype-branches" is "prototype"
+    And the commits
+      | BRANCH    | LOCATION | MESSAGE          |
+      | prototype | local    | prototype commit |
+
+  Scenario: result
+    When I run "git-town publish"
+    Then it runs the commands
+      | BRANCH    | COMMAND                      |
+      | prototype | git push -u origin prototype |
+    And the current branch is still "prototype"
+    And now these commits exist
+      | BRANCH    | LOCATION      | MESSAGE          |
+      | prototype | local, origin | prototype commit |
+    And local setting "prototype-branches" is now ""
+
+  Scenario: undo
+    Given I ran "git-town publish"
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH    | COMMAND                    |
+      | prototype | git push origin :prototype |
+    And now the initial commits exist
+    And local setting "prototype-branches" is now "prototype"
+
+  Scenario: publish the given branch
+    Given the current branch is "main"
+    When I run "git-town publish prototype"
+    Then it runs the commands
+      | BRANCH | COMMAND                      |
+      | main   | git push -u origin prototype |
+    And the current branch is still "main"
+    And local setting "prototype-branches" is now ""
+
+  Scenario: branch is not a prototype branch
+    When I run "git-town publish main"
+    Then it runs no commands
+    And it prints the error:
+      """
+      branch "main" is not a prototype branch
+      """
+
+  Scenario: offline mode
+    Given offline mode is enabled
+    When I run "git-town publish"
+    Then it runs no commands
+    And it prints the error:
+      """
+      cannot publish branch "prototype" in offline mode
+      """
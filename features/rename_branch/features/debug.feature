@@ -11,7 +11,7 @@ Feature: display debug statistics
     When I run "git-town rename-branch new --debug"
     Then it prints:
       """
-      Ran 30 shell commands.
+      Ran 29 shell commands.
       """
     And the current branch is now "new"
 
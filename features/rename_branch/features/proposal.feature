@@ -0,0 +1,51 @@
+Feature: move the proposal of the renamed branch
+
+  Background:
+    Given a feature branch "old"
+    And a feature branch "child" as a child of "old"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      |
+      | old    | local, origin | old commit   |
+      | child  | local, origin | child commit |
+    And the origin is a GitHub server with the pull requests
+      | NUMBER | HEAD  | BASE | TITLE          | STATE |
+      | 1      | old   | main | old proposal   | open  |
+      | 2      | child | old  | child proposal | open  |
+    And the current branch is "old"
+    When I run "git-town rename-branch new"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                                    |
+      | old    | git fetch --prune --tags                   |
+      |        | git branch new old                         |
+      |        | git checkout new                           |
+      | new    | git push -u origin new                     |
+      | <none> | GitHub API: moving PR #1 to branch "new"   |
+      |        | GitHub API: updating base branch for PR #2 |
+      | new    | git push origin :old                       |
+      |        | git branch -D old                          |
+    And the current branch is now "new"
+    And the pull requests are now
+      | NUMBER | HEAD  | BASE | TITLE          | STATE  |
+      | 1      | old   | main | old proposal   | closed |
+      | 2      | child | new  | child proposal | open   |
+      | 3      | new   | main | old proposal   | open   |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND                                    |
+      | new    | git branch old {{ sha 'old commit' }}      |
+      |        | git push -u origin old                     |
+      | <none> | GitHub API: updating base branch for PR #2 |
+      |        | GitHub API: moving PR #3 to branch "old"   |
+      | new    | git push origin :new                       |
+      |        | git checkout old                           |
+      | old    | git branch -D new                          |
+    And the current branch is now "old"
+    And the pull requests are now
+      | NUMBER | HEAD  | BASE | TITLE          | STATE  |
+      | 1      | old   | main | old proposal   | open   |
+      | 2      | child | old  | child proposal | open   |
+      | 3      | new   | main | old proposal   | closed |
@@ -0,0 +1,65 @@
+Feature: rename all branches in a stack that start with a prefix
+
+  Background:
+    Given a feature branch "feat/alpha"
+    And a feature branch "feat/beta" as a child of "feat/alpha"
+    And a feature branch "gamma" as a child of "feat/beta"
+    And a feature branch "feat/other"
+    And the commits
+      | BRANCH     | LOCATION      | MESSAGE      |
+      | feat/alpha | local, origin | alpha commit |
+      | feat/beta  | local, origin | beta commit  |
+      | feat/other | local, origin | other commit |
+      | gamma      | local, origin | gamma commit |
+    And the current branch is "feat/beta"
+    When I run "git-town rename-branch --stack feat/ fix/"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH    | COMMAND                         |
+      | feat/beta | git fetch --prune --tags        |
+      |           | git branch fix/alpha feat/alpha |
+      |           | git push -u origin fix/alpha    |
+      |           | git push origin :feat/alpha     |
+      |           | git branch -D feat/alpha        |
+      |           | git branch fix/beta feat/beta   |
+      |           | git checkout fix/beta           |
+      | fix/beta  | git push -u origin fix/beta     |
+      |           | git push origin :feat/beta      |
+      |           | git branch -D feat/beta         |
+    And the current branch is now "fix/beta"
+    And the branches are now
+      | REPOSITORY    | BRANCHES                                     |
+      | local, origin | main, feat/other, fix/alpha, fix/beta, gamma |
+    And this branch hierarchy exists now
+      | BRANCH     | PARENT    |
+      | feat/other | main      |
+      | fix/alpha  | main      |
+      | fix/beta   | fix/alpha |
+      | gamma      | fix/beta  |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH    | COMMAND                                        |
+      | fix/beta  | git branch feat/beta {{ sha 'beta commit' }}   |
+      |           | git push -u origin feat/beta                   |
+      |           | git push origin :fix/beta                      |
+      |           | git checkout feat/beta                         |
+      | feat/beta | git branch -D fix/beta                         |
+      |           | git branch feat/alpha {{ sha 'alpha commit' }} |
+      |           | git push -u origin feat/alpha                  |
+      |           | git push origin :fix/alpha                     |
+      |           | git branch -D fix/alpha                        |
+    And the current branch is now "feat/beta"
+    And now the initial commits exist
+    And the initial branches and hierarchy exist
+
+  Scenario: no branch in the stack starts with the prefix
+    Given the current branch is "feat/other"
+    When I run "git-town rename-branch --stack alpha/ beta/"
+    Then it runs no commands
+    And it prints the error:
+      """
+      no branch in the stack of "feat/other" starts with "alpha/"
+      """
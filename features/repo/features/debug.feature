@@ -6,7 +6,7 @@ Feature: display debug statistics
     When I run "git-town repo --debug"
     Then it prints:
       """
-      Ran 13 shell commands.
+      Ran 14 shell commands.
       """
     And "open" launches a new pull request with this url in my browser:
       """
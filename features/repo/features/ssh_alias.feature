@@ -0,0 +1,27 @@
+@skipWindows
+Feature: use an SSH host alias
+
+  Scenario: alias in the SSH configuration
+    Given tool "open" is installed
+    And my SSH configuration:
+      """
+      Host github-work
+        HostName github.com
+        IdentityFile ~/.ssh/work
+      """
+    And the origin is "github-work:git-town/git-town.git"
+    When I run "git-town repo"
+    Then "open" launches a new pull request with this url in my browser:
+      """
+      https://github.com/git-town/git-town
+      """
+
+  Scenario: alias via URL rewrite
+    Given tool "open" is installed
+    And global Git setting "url.ssh://git@gitlab.com:2222/.insteadOf" is "gl:"
+    And the origin is "gl:group/subgroup/repo.git"
+    When I run "git-town repo"
+    Then "open" launches a new pull request with this url in my browser:
+      """
+      https://gitlab.com/group/subgroup/repo
+      """
@@ -0,0 +1,70 @@
+Feature: provide the branch and its new parent as arguments
+
+  Background:
+    Given a feature branch "parent"
+    And a feature branch "child" as a child of "parent"
+    And the current branch is "main"
+
+  Scenario: result
+    When I run "git-town set-parent child main"
+    Then it runs no commands
+    And the current branch is still "main"
+    And this branch hierarchy exists now
+      | BRANCH | PARENT |
+      | child  | main   |
+      | parent | main   |
+
+  Scenario: undo
+    Given I ran "git-town set-parent child main"
+    When I run "git-town undo"
+    Then it runs no commands
+    And the current branch is still "main"
+    And the initial branch hierarchy exists
+
+  Scenario: only one argument
+    When I run "git-town set-parent child"
+    Then it runs no commands
+    And it prints the error:
+      """
+      please provide both the branch and its new parent
+      """
+    And the initial branch hierarchy exists
+
+  Scenario: non-existing parent
+    When I run "git-town set-parent child zonk"
+    Then it runs no commands
+    And it prints the error:
+      """
+      there is no branch named "zonk"
+      """
+    And the initial branch hierarchy exists
+
+  Scenario: parent is a descendant of the branch
+    When I run "git-town set-parent parent child"
+    Then it runs no commands
+    And it prints the error:
+      """
+      cannot make "child" the parent of "parent" because "child" is a descendant of "parent"
+      """
+    And the initial branch hierarchy exists
+
+  Scenario: branch is its own parent
+    When I run "git-town set-parent child child"
+    Then it runs no commands
+    And it prints the error:
+      """
+      branch "child" cannot be its own parent
+      """
+    And the initial branch hierarchy exists
+
+  Scenario: move the commits onto a branch with unknown parent
+    Given a branch "other"
+    When I run "git-town set-parent child other --rebase" and answer the prompts:
+      | PROMPT                                      | ANSWER  |
+      | Please specify the parent branch of 'other' | [ENTER] |
+    Then the current branch is still "main"
+    And this branch hierarchy exists now
+      | BRANCH | PARENT |
+      | child  | other  |
+      | other  | main   |
+      | parent | main   |
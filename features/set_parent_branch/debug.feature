@@ -9,7 +9,7 @@ Feature: display debug statistics
       | Please specify the parent branch of 'child' | [DOWN][ENTER] |
     Then it prints:
       """
-      Ran 11 shell commands.
+      Ran 10 shell commands.
       """
     And this branch hierarchy exists now
       | BRANCH | PARENT |
@@ -0,0 +1,41 @@
+@skipWindows
+Feature: move the commits of a feature branch onto its new parent
+
+  Background:
+    Given a feature branch "parent"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE       | FILE NAME   |
+      | parent | local, origin | parent commit | parent_file |
+    And a feature branch "child" as a child of "parent"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME  |
+      | child  | local, origin | child commit | child_file |
+    And the current branch is "child"
+    When I run "git-town set-parent --rebase" and answer the prompts:
+      | PROMPT                                      | ANSWER        |
+      | Please specify the parent branch of 'child' | [DOWN][ENTER] |
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                                          |
+      | child  | git rebase --onto main {{ sha 'parent commit' }} |
+      |        | git push --force-with-lease                      |
+    And the current branch is still "child"
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE       |
+      | child  | local, origin | child commit  |
+      | parent | local, origin | parent commit |
+    And this branch hierarchy exists now
+      | BRANCH | PARENT |
+      | child  | main   |
+      | parent | main   |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then the current branch is still "child"
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE       |
+      | child  | local, origin | parent commit |
+      |        |               | child commit  |
+      | parent | local, origin | parent commit |
+    And the initial branch hierarchy exists
@@ -0,0 +1,76 @@
+@skipWindows
+Feature: conflicts while moving the commits of a feature branch onto its new parent
+
+  Background:
+    Given a feature branch "parent"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE       | FILE NAME        | FILE CONTENT   |
+      | parent | local, origin | parent commit | conflicting_file | parent content |
+    And a feature branch "child" as a child of "parent"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME        | FILE CONTENT  |
+      | child  | local, origin | child commit | conflicting_file | child content |
+      | main   | local, origin | main commit  | conflicting_file | main content  |
+    And the current branch is "child"
+    When I run "git-town set-parent --rebase" and answer the prompts:
+      | PROMPT                                      | ANSWER        |
+      | Please specify the parent branch of 'child' | [DOWN][ENTER] |
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                                          |
+      | child  | git rebase --onto main {{ sha 'parent commit' }} |
+    And it prints the error:
+      """
+      To abort, run "git-town abort".
+      To continue after having resolved conflicts, run "git-town continue".
+      """
+    And a rebase is now in progress
+
+  Scenario: abort
+    When I run "git-town abort"
+    Then it runs the commands
+      | BRANCH | COMMAND            |
+      | child  | git rebase --abort |
+    And the current branch is still "child"
+    And no rebase is in progress
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE       |
+      | main   | local, origin | main commit   |
+      | child  | local, origin | parent commit |
+      |        |               | child commit  |
+      | parent | local, origin | parent commit |
+    And the initial branch hierarchy exists
+
+  Scenario: continue with unresolved conflict
+    When I run "git-town continue"
+    Then it prints the error:
+      """
+      you must resolve the conflicts before continuing
+      """
+    And a rebase is now in progress
+
+  Scenario: resolve and continue
+    When I resolve the conflict in "conflicting_file"
+    And I run "git-town continue" and close the editor
+    Then it runs the commands
+      | BRANCH | COMMAND                     |
+      | child  | git rebase --continue       |
+      |        | git push --force-with-lease |
+    And the current branch is still "child"
+    And no rebase is in progress
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE       |
+      | main   | local, origin | main commit   |
+      | child  | local, origin | main commit   |
+      |        |               | child commit  |
+      | parent | local, origin | parent commit |
+    And these committed files exist now
+      | BRANCH | NAME             | CONTENT          |
+      | main   | conflicting_file | main content     |
+      | child  | conflicting_file | resolved content |
+      | parent | conflicting_file | parent content   |
+    And this branch hierarchy exists now
+      | BRANCH | PARENT |
+      | child  | main   |
+      | parent | main   |
@@ -0,0 +1,55 @@
+@skipWindows
+Feature: move a feature branch and its descendants onto a new parent
+
+  Background:
+    Given a feature branch "parent"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE       | FILE NAME   |
+      | parent | local, origin | parent commit | parent_file |
+    And a feature branch "child" as a child of "parent"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME  |
+      | child  | local, origin | child commit | child_file |
+    And a feature branch "grandchild" as a child of "child"
+    And the commits
+      | BRANCH     | LOCATION      | MESSAGE           | FILE NAME       |
+      | grandchild | local, origin | grandchild commit | grandchild_file |
+    And the current branch is "child"
+    When I run "git-town set-parent --rebase --stack" and answer the prompts:
+      | PROMPT                                      | ANSWER        |
+      | Please specify the parent branch of 'child' | [DOWN][ENTER] |
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH     | COMMAND                                          |
+      | child      | git rebase --onto main {{ sha 'parent commit' }}            |
+      |            | git push --force-with-lease                                 |
+      |            | git checkout grandchild                                     |
+      | grandchild | git rebase --onto child {{ sha-before-run 'child commit' }} |
+      |            | git push --force-with-lease                                 |
+      |            | git checkout child                                          |
+    And the current branch is still "child"
+    And now these commits exist
+      | BRANCH     | LOCATION      | MESSAGE           |
+      | child      | local, origin | child commit      |
+      | grandchild | local, origin | child commit      |
+      |            |               | grandchild commit |
+      | parent     | local, origin | parent commit     |
+    And this branch hierarchy exists now
+      | BRANCH     | PARENT |
+      | child      | main   |
+      | grandchild | child  |
+      | parent     | main   |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then the current branch is still "child"
+    And now these commits exist
+      | BRANCH     | LOCATION      | MESSAGE           |
+      | child      | local, origin | parent commit     |
+      |            |               | child commit      |
+      | grandchild | local, origin | parent commit     |
+      |            |               | child commit      |
+      |            |               | grandchild commit |
+      | parent     | local, origin | parent commit     |
+    And the initial branch hierarchy exists
@@ -28,12 +28,16 @@ Feature: too few or many parameters
       | hack arg1 arg2                        | accepts 1 arg(s), received 2                                           |
       | kill arg1 arg2                        | accepts at most 1 arg(s), received 2                                   |
       | new-pull-request arg1                 | unknown command "arg1" for "git-town new-pull-request"                 |
+      | park arg1 arg2                        | accepts at most 1 arg(s), received 2                                   |
       | prepend                               | accepts 1 arg(s), received 0                                           |
       | prune-branches arg1                   | unknown command "arg1" for "git-town prune-branches"                   |
       | rename-branch                         | accepts between 1 and 2 arg(s), received 0                             |
       | rename-branch arg1 arg2 arg3          | accepts between 1 and 2 arg(s), received 3                             |
+      | publish arg1 arg2                     | accepts at most 1 arg(s), received 2                                   |
       | repo arg1                             | unknown command "arg1" for "git-town repo"                             |
-      | set-parent arg1                       | unknown command "arg1" for "git-town set-parent"                       |
+      | set-parent arg1                       | please provide both the branch and its new parent                      |
+      | set-parent arg1 arg2 arg3             | accepts at most 2 arg(s), received 3                                   |
       | ship arg1 arg2                        | accepts at most 1 arg(s), received 2                                   |
       | sync arg1                             | unknown command "arg1" for "git-town sync"                             |
+      | unpark arg1 arg2                      | accepts at most 1 arg(s), received 2                                   |
       | version arg1                          | unknown command "arg1" for "git-town version"                          |
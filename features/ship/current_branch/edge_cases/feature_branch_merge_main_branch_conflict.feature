@@ -18,6 +18,11 @@ Feature: handle conflicts between the shipped branch and the main branch
       |         | git checkout feature               |
       | feature | git merge --no-edit origin/feature |
       |         | git merge --no-edit main           |
+    And it prints the error:
+      """
+      Git Town predicts these merge conflicts:
+      - merging "main" into "feature" conflicts in conflicting_file
+      """
     And it prints the error:
       """
       To abort, run "git-town abort".
@@ -0,0 +1,27 @@
+Feature: does not ship observed and contribution branches
+
+  Scenario: try to ship an observed branch
+    Given the current branch is a feature branch "observed"
+    And setting "observed-branches" is "observed"
+    When I run "git-town ship -m done"
+    Then it runs the commands
+      | BRANCH   | COMMAND                  |
+      | observed | git fetch --prune --tags |
+    And it prints the error:
+      """
+      the branch "observed" is an observed branch. Only your own feature branches can be shipped
+      """
+    And the current branch is still "observed"
+
+  Scenario: try to ship a contribution branch
+    Given the current branch is a feature branch "contribution"
+    And setting "contribution-branches" is "contribution"
+    When I run "git-town ship -m done"
+    Then it runs the commands
+      | BRANCH       | COMMAND                  |
+      | contribution | git fetch --prune --tags |
+    And it prints the error:
+      """
+      the branch "contribution" is a contribution branch. Only your own feature branches can be shipped
+      """
+    And the current branch is still "contribution"
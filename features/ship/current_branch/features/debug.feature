@@ -10,7 +10,7 @@ Feature: display debug statistics
     When I run "git-town ship -m done --debug"
     Then it prints:
       """
-      Ran 46 shell commands.
+      Ran 48 shell commands.
       """
     And the current branch is now "main"
 
@@ -0,0 +1,21 @@
+Feature: main branch is checked out in another worktree
+
+  Background:
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION      | MESSAGE        |
+      | feature | local, origin | feature commit |
+    And branch "main" is checked out in another worktree
+    When I run "git-town ship -m done"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH  | COMMAND                  |
+      | feature | git fetch --prune --tags |
+    And it prints the error:
+      """
+      cannot ship "feature" because branch "main" is checked out in the worktree at
+      """
+    And the current branch is still "feature"
+    And now the initial commits exist
+    And the initial branches and hierarchy exist
@@ -0,0 +1,36 @@
+Feature: sync all branches when some branches are checked out in other worktrees
+
+  Background:
+    Given the feature branches "local" and "other"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME  |
+      | main   | origin        | main commit  | main_file  |
+      | local  | local, origin | local commit | local_file |
+      | other  | local, origin | other commit | other_file |
+    And the current branch is "local"
+    And branch "other" is checked out in another worktree
+    When I run "git-town sync --all"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                          |
+      | local  | git fetch --prune --tags         |
+      |        | git checkout main                |
+      | main   | git rebase origin/main           |
+      |        | git checkout local               |
+      | local  | git merge --no-edit origin/local |
+      |        | git merge --no-edit main         |
+      |        | git push                         |
+      |        | git push --tags                  |
+    And it prints:
+      """
+      Skipping branch "other" because it is checked out in the worktree at
+      """
+    And the current branch is still "local"
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE                        |
+      | main   | local, origin | main commit                    |
+      | local  | local, origin | local commit                   |
+      |        |               | main commit                    |
+      |        |               | Merge branch 'main' into local |
+      | other  | local, origin | other commit                   |
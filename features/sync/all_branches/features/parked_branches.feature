@@ -0,0 +1,65 @@
+Feature: parked branches
+
+  Background:
+    Given the feature branches "active" and "parked"
+    And setting "parked-branches" is "parked"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE       | FILE NAME   |
+      | main   | origin        | main commit   | main_file   |
+      | active | local, origin | active commit | active_file |
+      | parked | local, origin | parked commit | parked_file |
+
+  Scenario: sync all branches
+    Given the current branch is "active"
+    When I run "git-town sync --all"
+    Then it runs the commands
+      | BRANCH | COMMAND                           |
+      | active | git fetch --prune --tags          |
+      |        | git checkout main                 |
+      | main   | git rebase origin/main            |
+      |        | git checkout active               |
+      | active | git merge --no-edit origin/active |
+      |        | git merge --no-edit main          |
+      |        | git push                          |
+      |        | git push --tags                   |
+    And the current branch is still "active"
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE                         |
+      | main   | local, origin | main commit                     |
+      | active | local, origin | active commit                   |
+      |        |               | main commit                     |
+      |        |               | Merge branch 'main' into active |
+      | parked | local, origin | parked commit                   |
+
+  Scenario: sync all branches while on a parked branch
+    Given the current branch is "parked"
+    When I run "git-town sync --all"
+    Then it runs the commands
+      | BRANCH | COMMAND                           |
+      | parked | git fetch --prune --tags          |
+      |        | git checkout main                 |
+      | main   | git rebase origin/main            |
+      |        | git checkout active               |
+      | active | git merge --no-edit origin/active |
+      |        | git merge --no-edit main          |
+      |        | git push                          |
+      |        | git checkout parked               |
+      | parked | git merge --no-edit origin/parked |
+      |        | git merge --no-edit main          |
+      |        | git push                          |
+      |        | git push --tags                   |
+    And the current branch is still "parked"
+
+  Scenario: sync the current parked branch
+    Given the current branch is "parked"
+    When I run "git-town sync"
+    Then it runs the commands
+      | BRANCH | COMMAND                           |
+      | parked | git fetch --prune --tags          |
+      |        | git checkout main                 |
+      | main   | git rebase origin/main            |
+      |        | git checkout parked               |
+      | parked | git merge --no-edit origin/parked |
+      |        | git merge --no-edit main          |
+      |        | git push                          |
+    And the current branch is still "parked"
@@ -0,0 +1,26 @@
+Feature: sync the current contribution branch
+
+  Background:
+    Given a feature branch "contribution"
+    And setting "contribution-branches" is "contribution"
+    And the commits
+      | BRANCH       | LOCATION | MESSAGE       | FILE NAME   |
+      | main         | origin   | main commit   | main_file   |
+      | contribution | local    | local commit  | local_file  |
+      |              | origin   | origin commit | origin_file |
+    And the current branch is "contribution"
+    When I run "git-town sync"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH       | COMMAND                                 |
+      | contribution | git fetch --prune --tags                |
+      |              | git merge --no-edit origin/contribution |
+      |              | git push                                |
+    And the current branch is still "contribution"
+    And now these commits exist
+      | BRANCH       | LOCATION      | MESSAGE                                                              |
+      | main         | origin        | main commit                                                          |
+      | contribution | local, origin | local commit                                                         |
+      |              |               | origin commit                                                        |
+      |              |               | Merge remote-tracking branch 'origin/contribution' into contribution |
@@ -14,9 +14,9 @@ Feature: with upstream repo
     Then it runs the commands
       | BRANCH  | COMMAND                     |
       | feature | git fetch --prune --tags    |
+      |         | git fetch upstream main     |
       |         | git checkout main           |
       | main    | git rebase origin/main      |
-      |         | git fetch upstream main     |
       |         | git rebase upstream/main    |
       |         | git push                    |
       |         | git checkout feature        |
@@ -12,8 +12,8 @@ Feature: on the main branch with an upstream repo
     Then it runs the commands
       | BRANCH | COMMAND                  |
       | main   | git fetch --prune --tags |
-      |        | git rebase origin/main   |
       |        | git fetch upstream main  |
+      |        | git rebase origin/main   |
       |        | git rebase upstream/main |
       |        | git push                 |
       |        | git push --tags          |
@@ -0,0 +1,38 @@
+Feature: sync the current observed branch
+
+  Background:
+    Given a remote feature branch "observed"
+    And the commits
+      | BRANCH   | LOCATION | MESSAGE       | FILE NAME   |
+      | main     | origin   | main commit   | main_file   |
+      | observed | origin   | origin commit | origin_file |
+    And I ran "git fetch"
+    And I ran "git checkout observed"
+    And setting "observed-branches" is "observed"
+    And the commits
+      | BRANCH   | LOCATION | MESSAGE      | FILE NAME  |
+      | observed | local    | local commit | local_file |
+    And the current branch is "observed"
+    When I run "git-town sync"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH   | COMMAND                    |
+      | observed | git fetch --prune --tags   |
+      |          | git rebase origin/observed |
+    And I am not prompted for any parent branches
+    And the current branch is still "observed"
+    And now these commits exist
+      | BRANCH   | LOCATION      | MESSAGE       |
+      | main     | origin        | main commit   |
+      | observed | local, origin | origin commit |
+      |          | local         | local commit  |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then the current branch is still "observed"
+    And now these commits exist
+      | BRANCH   | LOCATION      | MESSAGE       |
+      | main     | origin        | main commit   |
+      | observed | local, origin | origin commit |
+      |          | local         | local commit  |
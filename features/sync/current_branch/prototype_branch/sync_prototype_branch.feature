@@ -0,0 +1,27 @@
+Feature: sync a prototype branch
+
+  Background:
+    Given the current branch is a local feature branch "prototype"
+    And setting "prototype-branches" is "prototype"
+    And the commits
+      | BRANCH    | LOCATION | MESSAGE          | FILE NAME      |
+      | main      | origin   | main commit      | main_file      |
+      | prototype | local    | prototype commit | prototype_file |
+    And the current branch is "prototype"
+    When I run "git-town sync"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH    | COMMAND                  |
+      | prototype | git fetch --prune --tags |
+      |           | git checkout main        |
+      | main      | git rebase origin/main   |
+      |           | git checkout prototype   |
+      | prototype | git merge --no-edit main |
+    And the current branch is still "prototype"
+    And now these commits exist
+      | BRANCH    | LOCATION      | MESSAGE                            |
+      | main      | local, origin | main commit                        |
+      | prototype | local         | prototype commit                   |
+      |           |               | main commit                        |
+      |           |               | Merge branch 'main' into prototype |
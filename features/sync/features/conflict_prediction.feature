@@ -0,0 +1,98 @@
+Feature: predict merge conflicts before syncing
+
+  Background:
+    Given the feature branches "alpha", "beta", and "gamma"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE            | FILE NAME        | FILE CONTENT        |
+      | main   | origin        | main commit        | main_file        | main content        |
+      | alpha  | local, origin | alpha commit       | alpha_file       | alpha content       |
+      | beta   | local         | local beta commit  | conflicting_file | local beta content  |
+      |        | origin        | origin beta commit | conflicting_file | origin beta content |
+      | gamma  | local, origin | gamma commit       | gamma_file       | gamma content       |
+    And the current branch is "main"
+
+  Scenario: sync all branches
+    When I run "git-town sync --all"
+    Then it runs the commands
+      | BRANCH | COMMAND                          |
+      | main   | git fetch --prune --tags         |
+      |        | git rebase origin/main           |
+      |        | git checkout alpha               |
+      | alpha  | git merge --no-edit origin/alpha |
+      |        | git merge --no-edit main         |
+      |        | git push                         |
+      |        | git checkout beta                |
+      | beta   | git merge --no-edit origin/beta  |
+    And it prints the error:
+      """
+      Git Town predicts these merge conflicts:
+      - merging "origin/beta" into "beta" conflicts in conflicting_file
+      Run "git town sync --skip-conflicts" to sync only the branches that don't conflict.
+      """
+    And it prints the error:
+      """
+      To continue by skipping the current branch, run "git-town skip".
+      """
+    And the current branch is now "beta"
+    And a merge is now in progress
+
+  Scenario: skip the branches that would conflict
+    When I run "git-town sync --all --skip-conflicts"
+    Then it prints:
+      """
+      Git Town predicts these merge conflicts:
+      - merging "origin/beta" into "beta" conflicts in conflicting_file
+      """
+    And it prints:
+      """
+      Skipping branch "beta" because syncing it would cause merge conflicts.
+      """
+    And it runs the commands
+      | BRANCH | COMMAND                          |
+      | main   | git fetch --prune --tags         |
+      |        | git rebase origin/main           |
+      |        | git checkout alpha               |
+      | alpha  | git merge --no-edit origin/alpha |
+      |        | git merge --no-edit main         |
+      |        | git push                         |
+      |        | git checkout gamma               |
+      | gamma  | git merge --no-edit origin/gamma |
+      |        | git merge --no-edit main         |
+      |        | git push                         |
+      |        | git checkout main                |
+      | main   | git push --tags                  |
+    And the current branch is still "main"
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE                        |
+      | main   | local, origin | main commit                    |
+      | alpha  | local, origin | alpha commit                   |
+      |        |               | main commit                    |
+      |        |               | Merge branch 'main' into alpha |
+      | beta   | local         | local beta commit              |
+      |        | origin        | origin beta commit             |
+      | gamma  | local, origin | gamma commit                   |
+      |        |               | main commit                    |
+      |        |               | Merge branch 'main' into gamma |
+
+  Scenario: dependent branches
+    Given a feature branch "delta" as a child of "beta"
+    When I run "git-town sync --all --skip-conflicts"
+    Then it prints:
+      """
+      Skipping branch "beta" because syncing it would cause merge conflicts.
+      """
+    And it does not print "Skipping branch "delta""
+    And the current branch is still "main"
+
+  Scenario: branches in other worktrees
+    Given branch "gamma" is checked out in another worktree
+    When I run "git-town sync --all --skip-conflicts"
+    Then it prints:
+      """
+      Skipping branch "beta" because syncing it would cause merge conflicts.
+      """
+    And it prints once:
+      """
+      Skipping branch "gamma" because it is checked out in the worktree at
+      """
+    And the current branch is still "main"
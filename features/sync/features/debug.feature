@@ -13,6 +13,24 @@ Feature: display debug statistics
     When I run "git-town sync --debug"
     Then it prints:
       """
-      Ran 28 shell commands.
+      Ran 36 shell commands.
+      """
+    And it prints something like:
+      """
+      TOTAL +COUNT +TYPE +NAME
+      """
+    And it prints something like:
+      """
+      s +1 +command +git fetch --prune --tags
       """
     And all branches are now synchronized
+
+  Scenario: write a trace
+    When I run "git-town sync --trace-file trace.json"
+    Then it prints:
+      """
+      Wrote a trace of this run to trace.json.
+      """
+    And file "trace.json" contains "traceEvents"
+    And file "trace.json" contains "git fetch --prune --tags"
+    And file "trace.json" contains "MergeStep"
@@ -0,0 +1,64 @@
+Feature: automatic offline mode
+
+  Background:
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION | MESSAGE       |
+      | feature | local    | local commit  |
+      | main    | origin   | origin commit |
+    And automatic offline mode is enabled
+
+  Scenario: origin is unreachable
+    Given origin is unreachable
+    When I run "git-town sync"
+    Then it runs the commands
+      | BRANCH  | COMMAND                            |
+      | feature | git checkout main                  |
+      | main    | git rebase origin/main             |
+      |         | git checkout feature               |
+      | feature | git merge --no-edit origin/feature |
+      |         | git merge --no-edit main           |
+    And it prints:
+      """
+      cannot reach origin, continuing in offline mode.
+      """
+    And the current branch is still "feature"
+    And now these commits exist
+      | BRANCH  | LOCATION | MESSAGE       |
+      | main    | origin   | origin commit |
+      | feature | local    | local commit  |
+    When I run "git-town status"
+    Then it prints:
+      """
+      These network operations are waiting for Git Town to be online:
+      - push branch "feature"
+      """
+
+  Scenario: origin is reachable
+    When I run "git-town sync"
+    Then it runs the commands
+      | BRANCH  | COMMAND                            |
+      | feature | git fetch --prune --tags           |
+      |         | git checkout main                  |
+      | main    | git rebase origin/main             |
+      |         | git checkout feature               |
+      | feature | git merge --no-edit origin/feature |
+      |         | git merge --no-edit main           |
+      |         | git push                           |
+    And it does not print "offline mode"
+    And the current branch is still "feature"
+    And now these commits exist
+      | BRANCH  | LOCATION      | MESSAGE                          |
+      | main    | local, origin | origin commit                    |
+      | feature | local, origin | local commit                     |
+      |         |               | origin commit                    |
+      |         |               | Merge branch 'main' into feature |
+
+  Scenario: commands that require an internet connection
+    Given origin is unreachable
+    When I run "git-town prune-branches"
+    Then it runs no commands
+    And it prints the error:
+      """
+      cannot reach origin. This command requires an active internet connection
+      """
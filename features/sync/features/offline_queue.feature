@@ -0,0 +1,60 @@
+Feature: perform the network operations that Git Town skipped while offline
+
+  Background:
+    Given the feature branches "alpha" and "beta"
+    And a local feature branch "gamma"
+    And the commits
+      | BRANCH | LOCATION | MESSAGE      |
+      | alpha  | local    | alpha commit |
+    And offline mode is enabled
+    And the current branch is "alpha"
+    And I ran "git-town sync"
+    And the current branch is "gamma"
+    And I ran "git-town sync"
+    And I ran "git-town config offline no"
+    And the current branch is "beta"
+
+  Scenario: status
+    When I run "git-town status"
+    Then it prints:
+      """
+      These network operations are waiting for Git Town to be online:
+      - push branch "alpha"
+      - create the tracking branch for "gamma"
+      Run "git town sync" when you are online to perform them.
+      """
+
+  Scenario: result
+    When I run "git-town sync"
+    Then it runs the commands
+      | BRANCH | COMMAND                         |
+      | beta   | git fetch --prune --tags        |
+      |        | git checkout main               |
+      | main   | git rebase origin/main          |
+      |        | git checkout beta               |
+      | beta   | git merge --no-edit origin/beta |
+      |        | git merge --no-edit main        |
+      |        | git push -u origin alpha        |
+      |        | git push -u origin gamma        |
+    And the current branch is still "beta"
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE      |
+      | alpha  | local, origin | alpha commit |
+    When I run "git-town status"
+    Then it does not print "network operations"
+
+  Scenario: undo
+    Given I ran "git-town sync"
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND                |
+      | beta   | git push origin :gamma |
+      |        | git checkout main      |
+      | main   | git checkout beta      |
+    When I run "git-town status"
+    Then it prints:
+      """
+      These network operations are waiting for Git Town to be online:
+      - create the tracking branch for "gamma"
+      Run "git town sync" when you are online to perform them.
+      """
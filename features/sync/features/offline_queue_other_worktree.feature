@@ -0,0 +1,37 @@
+Feature: keep the queued push of a branch that is checked out in another worktree
+
+  Background:
+    Given the feature branches "alpha" and "beta"
+    And the commits
+      | BRANCH | LOCATION | MESSAGE      |
+      | alpha  | local    | alpha commit |
+    And offline mode is enabled
+    And the current branch is "alpha"
+    And I ran "git-town sync"
+    And I ran "git-town config offline no"
+    And the current branch is "main"
+    And the current branch is "beta"
+    And branch "alpha" is checked out in another worktree
+    When I run "git-town sync --all"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                         |
+      | beta   | git fetch --prune --tags        |
+      |        | git checkout main               |
+      | main   | git rebase origin/main          |
+      |        | git checkout beta               |
+      | beta   | git merge --no-edit origin/beta |
+      |        | git merge --no-edit main        |
+      |        | git push --tags                 |
+    And it prints:
+      """
+      Skipping branch "alpha" because it is checked out in the worktree at
+      """
+    When I run "git-town status"
+    Then it prints:
+      """
+      These network operations are waiting for Git Town to be online:
+      - push branch "alpha"
+      Run "git town sync" when you are online to perform them.
+      """
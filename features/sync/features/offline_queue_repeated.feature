@@ -0,0 +1,28 @@
+Feature: undoing a sync keeps the network operations that earlier syncs queued while offline
+
+  Background:
+    Given a feature branch "alpha"
+    And the commits
+      | BRANCH | LOCATION | MESSAGE      |
+      | alpha  | local    | first commit |
+    And offline mode is enabled
+    And the current branch is "alpha"
+    And I ran "git-town sync"
+    And the commits
+      | BRANCH | LOCATION | MESSAGE       |
+      | alpha  | local    | second commit |
+    And I ran "git-town sync"
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND            |
+      | alpha  | git checkout main  |
+      | main   | git checkout alpha |
+    When I run "git-town status"
+    Then it prints:
+      """
+      These network operations are waiting for Git Town to be online:
+      - push branch "alpha"
+      Run "git town sync" when you are online to perform them.
+      """
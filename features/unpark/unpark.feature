@@ -0,0 +1,33 @@
+Feature: unpark a branch
+
+  Background:
+    Given the feature branches "alpha" and "beta"
+    And setting "parked-branches" is "alpha beta"
+
+  Scenario: unpark the current branch
+    Given the current branch is "alpha"
+    When I run "git-town unpark"
+    Then it runs no commands
+    And the current branch is still "alpha"
+    And local setting "parked-branches" is now "beta"
+
+  Scenario: unpark the given branch
+    Given the current branch is "alpha"
+    When I run "git-town unpark beta"
+    Then it runs no commands
+    And local setting "parked-branches" is now "alpha"
+
+  Scenario: undo
+    Given the current branch is "alpha"
+    And I ran "git-town unpark"
+    When I run "git-town undo"
+    Then it runs no commands
+    And local setting "parked-branches" is now "beta alpha"
+
+  Scenario: branch is not parked
+    When I run "git-town unpark main"
+    Then it runs no commands
+    And it prints the error:
+      """
+      branch "main" is not parked
+      """
@@ -0,0 +1,74 @@
+@skipWindows
+Feature: handle a failing command
+
+  Background:
+    Given a feature branch "alpha"
+    And a feature branch "beta" as a child of "alpha"
+    And a feature branch "gamma" as a child of "beta"
+    And the commits
+      | BRANCH | LOCATION | MESSAGE       | FILE NAME |
+      | beta   | local    | broken commit | broken    |
+    And the current branch is "alpha"
+    And an uncommitted file
+    When I run "git-town walk -- test ! -e broken"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND           |
+      | alpha  | git add -A        |
+      |        | git stash         |
+      | <none> | test ! -e broken  |
+      | alpha  | git checkout beta |
+      | <none> | test ! -e broken  |
+    And it prints the error:
+      """
+      command "test ! -e broken" failed
+      """
+    And it prints the error:
+      """
+      To continue by skipping the current branch, run "git-town skip".
+      """
+    And the current branch is now "beta"
+    And the uncommitted file is stashed
+
+  Scenario: abort
+    When I run "git-town abort"
+    Then it runs the commands
+      | BRANCH | COMMAND            |
+      | beta   | git checkout alpha |
+      | alpha  | git stash pop      |
+    And the current branch is now "alpha"
+    And the uncommitted file still exists
+
+  Scenario: skip
+    When I run "git-town skip"
+    Then it runs the commands
+      | BRANCH | COMMAND            |
+      | beta   | git checkout gamma |
+      | <none> | test ! -e broken   |
+      | gamma  | git checkout alpha |
+      | alpha  | git stash pop      |
+    And the current branch is now "alpha"
+    And the uncommitted file still exists
+
+  Scenario: continue after fixing the problem
+    When I run "git rm broken"
+    And I run "git commit -m fix"
+    And I run "git-town continue"
+    Then it runs the commands
+      | BRANCH | COMMAND            |
+      |        | test ! -e broken   |
+      | beta   | git checkout gamma |
+      | <none> | test ! -e broken   |
+      | gamma  | git checkout alpha |
+      | alpha  | git stash pop      |
+    And the current branch is now "alpha"
+    And the uncommitted file still exists
+
+  Scenario: continue without fixing the problem
+    When I run "git-town continue"
+    Then it prints the error:
+      """
+      command "test ! -e broken" failed
+      """
+    And the current branch is still "beta"
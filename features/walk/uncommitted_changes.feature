@@ -0,0 +1,62 @@
+@skipWindows
+Feature: handle a command that leaves uncommitted changes
+
+  Background:
+    Given a feature branch "alpha"
+    And a feature branch "beta" as a child of "alpha"
+    And the commits
+      | BRANCH | LOCATION | MESSAGE      | FILE NAME | FILE CONTENT |
+      | alpha  | local    | alpha commit | lockfile  | alpha        |
+      | beta   | local    | beta commit  | lockfile  | beta         |
+    And the current branch is "alpha"
+    When I run "git-town walk -- sh -c "echo regenerated > lockfile""
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                             |
+      |        | sh -c "echo regenerated > lockfile" |
+    And it prints the error:
+      """
+      command "sh -c echo regenerated > lockfile" left uncommitted changes, please commit or discard them
+      """
+    And the current branch is still "alpha"
+
+  Scenario: abort
+    When I run "git-town abort"
+    Then it runs the commands
+      | BRANCH | COMMAND          |
+      | alpha  | git reset --hard |
+    And the current branch is still "alpha"
+    And file "lockfile" still has content "alpha"
+
+  Scenario: skip
+    When I run "git-town skip"
+    Then it runs the commands
+      | BRANCH | COMMAND                             |
+      | alpha  | git reset --hard                    |
+      |        | git checkout beta                   |
+      | <none> | sh -c "echo regenerated > lockfile" |
+    And it prints the error:
+      """
+      command "sh -c echo regenerated > lockfile" left uncommitted changes, please commit or discard them
+      """
+    And the current branch is now "beta"
+
+  Scenario: continue after committing the changes
+    When I run "git commit -am regenerated"
+    And I run "git-town continue"
+    Then it runs the commands
+      | BRANCH | COMMAND                             |
+      |        | sh -c "echo regenerated > lockfile" |
+      | alpha  | git checkout beta                   |
+      | <none> | sh -c "echo regenerated > lockfile" |
+    And it prints the error:
+      """
+      command "sh -c echo regenerated > lockfile" left uncommitted changes, please commit or discard them
+      """
+    And the current branch is now "beta"
+    And now these commits exist
+      | BRANCH | LOCATION | MESSAGE      |
+      | alpha  | local    | alpha commit |
+      |        |          | regenerated  |
+      | beta   | local    | beta commit  |
@@ -0,0 +1,48 @@
+Feature: run a command on each branch of the current stack
+
+  Background:
+    Given a feature branch "alpha"
+    And a feature branch "beta" as a child of "alpha"
+    And a feature branch "other"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      |
+      | alpha  | local, origin | alpha commit |
+      | beta   | local, origin | beta commit  |
+    And the current branch is "alpha"
+    And an uncommitted file
+
+  Scenario: result
+    When I run "git-town walk -- git commit --allow-empty -m walked"
+    Then it runs the commands
+      | BRANCH | COMMAND                            |
+      | alpha  | git add -A                         |
+      |        | git stash                          |
+      |        | git commit --allow-empty -m walked |
+      |        | git checkout beta                  |
+      | beta   | git commit --allow-empty -m walked |
+      |        | git checkout alpha                 |
+      | alpha  | git stash pop                      |
+    And the current branch is still "alpha"
+    And the uncommitted file still exists
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE      |
+      | alpha  | local, origin | alpha commit |
+      |        | local         | walked       |
+      | beta   | local, origin | beta commit  |
+      |        | local         | walked       |
+
+  Scenario: undo
+    Given I ran "git-town walk -- git commit --allow-empty -m walked"
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND                                    |
+      | alpha  | git add -A                                 |
+      |        | git stash                                  |
+      |        | git checkout beta                          |
+      | beta   | git reset --hard {{ sha 'beta commit' }}   |
+      |        | git checkout alpha                         |
+      | alpha  | git reset --hard {{ sha 'alpha commit' }}  |
+      |        | git stash pop                              |
+    And the current branch is still "alpha"
+    And the uncommitted file still exists
+    And now the initial commits exist
@@ -50,6 +50,11 @@ func PrintError(err error) {
 	PrintlnColor(color.New(color.Bold).Add(color.FgRed), "\nError:", err.Error(), "\n")
 }
 
+// PrintWarning prints the given warning message to the console.
+func PrintWarning(message string) {
+	PrintlnColor(color.New(color.FgYellow), "\nWarning:", message)
+}
+
 func PrintHeader(text string) {
 	boldUnderline := color.New(color.Bold).Add(color.Underline)
 	PrintlnColor(boldUnderline, text+":")
@@ -3,12 +3,15 @@ package cli
 import (
 	"sort"
 	"strings"
+
+	"github.com/fatih/color"
 )
 
 // BranchAncestryConfig defines the configuration values needed by the `cli` package.
 type BranchAncestryConfig interface {
 	BranchAncestryRoots() []string
 	ChildBranches(string) []string
+	IsParkedBranch(string) bool
 }
 
 // PrintableBranchAncestry provides the branch ancestry in CLI printable format.
@@ -22,8 +25,12 @@ func PrintableBranchAncestry(config BranchAncestryConfig) string {
 }
 
 // PrintableBranchTree returns a user printable branch tree.
+// Parked branches are dimmed.
 func PrintableBranchTree(branch string, config BranchAncestryConfig) string {
 	result := branch
+	if config.IsParkedBranch(branch) {
+		result = color.New(color.Faint).Sprint(branch)
+	}
 	childBranches := config.ChildBranches(branch)
 	sort.Strings(childBranches)
 	for _, childBranch := range childBranches {
@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+)
+
+// Progress displays the progress of long-running work on a single terminal line that it keeps updating.
+// It displays nothing if the output is not a terminal, for example when Git Town runs in a script.
+type Progress struct {
+	enabled bool   // whether the output is a terminal
+	label   string // describes the work in progress
+	total   int    // the number of work items
+}
+
+// NewProgress provides a Progress instance for the given number of work items.
+func NewProgress(label string, total int) Progress {
+	stat, err := os.Stdout.Stat()
+	enabled := err == nil && stat.Mode()&os.ModeCharDevice != 0
+	return Progress{enabled: enabled, label: label, total: total}
+}
+
+// Done removes the progress indicator from the terminal.
+func (p Progress) Done() {
+	if p.enabled {
+		fmt.Print("\r\033[K")
+	}
+}
+
+// Update displays the given number of finished work items.
+func (p Progress) Update(finished int) {
+	if p.enabled {
+		fmt.Printf("\r\033[K%s (%d/%d) ...", p.label, finished, p.total)
+	}
+}
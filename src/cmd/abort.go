@@ -29,7 +29,7 @@ func abortCmd() *cobra.Command {
 	return &cmd
 }
 
-func abort(debug bool) error {
+func abort(debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
@@ -49,7 +49,7 @@ func abort(debug bool) error {
 		return fmt.Errorf("nothing to abort")
 	}
 	abortRunState := runState.CreateAbortRunState()
-	connector, err := hosting.NewConnector(run.Config.GitTown, &run.Backend, cli.PrintConnectorAction)
+	connector, err := hosting.NewConnector(&run.Config, &run.Backend, cli.PrintConnectorAction, run.Stats)
 	if err != nil {
 		return err
 	}
@@ -37,7 +37,7 @@ func aliasesCommand() *cobra.Command {
 	return &cmd
 }
 
-func aliases(arg string, debug bool) error {
+func aliases(arg string, debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
@@ -5,6 +5,7 @@ import (
 	"github.com/git-town/git-town/v8/src/failure"
 	"github.com/git-town/git-town/v8/src/flags"
 	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/offlinequeue"
 	"github.com/git-town/git-town/v8/src/runstate"
 	"github.com/git-town/git-town/v8/src/steps"
 	"github.com/git-town/git-town/v8/src/validate"
@@ -21,10 +22,14 @@ pushes the new feature branch to the origin repository
 (if and only if "push-new-branches" is true),
 and brings over all uncommitted changes to the new feature branch.
 
+With "--prototype", the new branch exists only locally
+until you publish it with "git town publish".
+
 See "sync" for information regarding upstream remotes.`
 
 func appendCmd() *cobra.Command {
 	addDebugFlag, readDebugFlag := flags.Debug()
+	addPrototypeFlag, readPrototypeFlag := flags.Bool("prototype", "", "Never push the new branch until it gets published")
 	cmd := cobra.Command{
 		Use:     "append <branch>",
 		GroupID: "lineage",
@@ -32,14 +37,15 @@ func appendCmd() *cobra.Command {
 		Short:   appendDesc,
 		Long:    long(appendDesc, appendHelp),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runAppend(args[0], readDebugFlag(cmd))
+			return runAppend(args[0], readPrototypeFlag(cmd), readDebugFlag(cmd))
 		},
 	}
 	addDebugFlag(&cmd)
+	addPrototypeFlag(&cmd)
 	return &cmd
 }
 
-func runAppend(arg string, debug bool) error {
+func runAppend(arg string, prototype bool, debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
@@ -51,7 +57,7 @@ func runAppend(arg string, debug bool) error {
 	if err != nil || exit {
 		return err
 	}
-	config, err := determineAppendConfig(arg, &run)
+	config, err := determineAppendConfig(arg, prototype, &run)
 	if err != nil {
 		return err
 	}
@@ -70,11 +76,12 @@ type appendConfig struct {
 	mainBranch          string
 	noPushHook          bool
 	parentBranch        string
+	prototype           bool // whether the new branch exists only locally
 	shouldNewBranchPush bool
 	targetBranch        string
 }
 
-func determineAppendConfig(targetBranch string, run *git.ProdRunner) (*appendConfig, error) {
+func determineAppendConfig(targetBranch string, prototype bool, run *git.ProdRunner) (*appendConfig, error) {
 	fc := failure.Collector{}
 	parentBranch := fc.String(run.Backend.CurrentBranch())
 	hasOrigin := fc.Bool(run.Backend.HasOrigin())
@@ -101,6 +108,7 @@ func determineAppendConfig(targetBranch string, run *git.ProdRunner) (*appendCon
 		mainBranch:          mainBranch,
 		noPushHook:          !pushHook,
 		parentBranch:        parentBranch,
+		prototype:           prototype,
 		shouldNewBranchPush: shouldNewBranchPush,
 		targetBranch:        targetBranch,
 	}, fc.Err
@@ -108,15 +116,46 @@ func determineAppendConfig(targetBranch string, run *git.ProdRunner) (*appendCon
 
 func appendStepList(config *appendConfig, run *git.ProdRunner) (runstate.StepList, error) {
 	list := runstate.StepListBuilder{}
-	for _, branch := range append(config.ancestorBranches, config.parentBranch) {
-		updateBranchSteps(&list, branch, true, run)
+	branchesInOtherWorktrees, err := run.Backend.BranchesInOtherWorktrees()
+	if err != nil {
+		return runstate.StepList{}, err
+	}
+	branchesToSync := append(config.ancestorBranches, config.parentBranch)
+	printSkippedWorktreeBranches(branchesToSync, branchesInOtherWorktrees)
+	for _, branch := range branchesToSync {
+		updateBranchSteps(&list, branch, true, false, branchesInOtherWorktrees, run)
+	}
+	startingPoint, err := branchingPoint(config.parentBranch, config.hasOrigin, config.isOffline, branchesInOtherWorktrees, run)
+	if err != nil {
+		return runstate.StepList{}, err
 	}
-	list.Add(&steps.CreateBranchStep{Branch: config.targetBranch, StartingPoint: config.parentBranch})
+	list.Add(&steps.CreateBranchStep{Branch: config.targetBranch, StartingPoint: startingPoint})
 	list.Add(&steps.SetParentStep{Branch: config.targetBranch, ParentBranch: config.parentBranch})
+	if config.prototype {
+		list.Add(&steps.AddToPrototypeBranchesStep{Branch: config.targetBranch})
+	}
 	list.Add(&steps.CheckoutStep{Branch: config.targetBranch})
-	if config.hasOrigin && config.shouldNewBranchPush && !config.isOffline {
-		list.Add(&steps.CreateTrackingBranchStep{Branch: config.targetBranch, NoPushHook: config.noPushHook})
+	if config.hasOrigin && config.shouldNewBranchPush && !config.prototype {
+		if config.isOffline {
+			list.Add(&steps.QueueOfflineOperationStep{Operation: offlinequeue.Operation{Type: offlinequeue.CreateTrackingBranch, Branch: config.targetBranch}})
+		} else {
+			list.Add(&steps.CreateTrackingBranchStep{Branch: config.targetBranch, NoPushHook: config.noPushHook})
+		}
 	}
 	list.Wrap(runstate.WrapOptions{RunInGitRoot: true, StashOpenChanges: true}, &run.Backend, config.mainBranch)
 	return list.Result()
 }
+
+// branchingPoint provides the commit that a new child branch of the given parent branch starts at.
+// Git Town cannot update parent branches that are checked out in another worktree.
+// New branches then start at the tracking branch of the parent, which contains the fetched updates.
+func branchingPoint(parent string, hasOrigin, isOffline bool, branchesInOtherWorktrees map[string]string, run *git.ProdRunner) (string, error) {
+	if _, inOtherWorktree := branchesInOtherWorktrees[parent]; !inOtherWorktree || !hasOrigin || isOffline {
+		return parent, nil
+	}
+	hasTrackingBranch, err := run.Backend.HasTrackingBranch(parent)
+	if err != nil || !hasTrackingBranch {
+		return parent, err
+	}
+	return run.Backend.TrackingBranch(parent), nil
+}
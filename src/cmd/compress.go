@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/git-town/git-town/v8/src/config"
+	"github.com/git-town/git-town/v8/src/execute"
+	"github.com/git-town/git-town/v8/src/flags"
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/offlinequeue"
+	"github.com/git-town/git-town/v8/src/runstate"
+	"github.com/git-town/git-town/v8/src/steps"
+	"github.com/git-town/git-town/v8/src/validate"
+	"github.com/spf13/cobra"
+)
+
+const compressDesc = "Squashes all commits on a feature branch into a single commit"
+
+const compressHelp = `
+Replaces the commits that the current branch added on top of its parent
+with a single commit and force-pushes the branch if it has a tracking branch.
+The new commit uses the message of the first commit in the branch
+unless you provide a different one with "-m".
+
+With "--stack", also compresses all descendants of the current branch.
+When using the "rebase" sync strategy, child branches
+get rebased onto the compressed branch.`
+
+func compressCmd() *cobra.Command {
+	addDebugFlag, readDebugFlag := flags.Debug()
+	addMessageFlag, readMessageFlag := flags.String("message", "m", "", "Specify the message for the new commit")
+	addStackFlag, readStackFlag := flags.Bool("stack", "", "Also compress the descendants of the current branch")
+	cmd := cobra.Command{
+		Use:   "compress",
+		Args:  cobra.NoArgs,
+		Short: compressDesc,
+		Long:  long(compressDesc, compressHelp),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return compress(readMessageFlag(cmd), readStackFlag(cmd), readDebugFlag(cmd))
+		},
+	}
+	addDebugFlag(&cmd)
+	addMessageFlag(&cmd)
+	addStackFlag(&cmd)
+	return &cmd
+}
+
+func compress(message string, stack bool, debug flags.DebugArgs) error {
+	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
+		Debug:                 debug,
+		DryRun:                false,
+		HandleUnfinishedState: true,
+		ValidateGitversion:    true,
+		ValidateIsRepository:  true,
+		ValidateIsConfigured:  true,
+	})
+	if err != nil || exit {
+		return err
+	}
+	config, err := determineCompressConfig(message, stack, &run)
+	if err != nil {
+		return err
+	}
+	stepList, err := compressStepList(config, &run)
+	if err != nil {
+		return err
+	}
+	runState := runstate.New("compress", stepList)
+	return runstate.Execute(runState, &run, nil)
+}
+
+type compressConfig struct {
+	branches      []compressedBranch
+	hasOrigin     bool
+	initialBranch string
+	isOffline     bool
+	mainBranch    string
+}
+
+// compressedBranch describes a branch that this command changes.
+type compressedBranch struct {
+	name              string
+	parent            string
+	base              string // the commit after which the commits of the branch start
+	compress          bool   // whether to squash the commits of the branch
+	hasTrackingBranch bool
+	message           string // the message of the squashed commit
+	restack           bool   // whether to move the commits of the branch onto its compressed parent first
+}
+
+func determineCompressConfig(message string, stack bool, run *git.ProdRunner) (*compressConfig, error) {
+	initialBranch, err := run.Backend.CurrentBranch()
+	if err != nil {
+		return nil, err
+	}
+	if !run.Config.IsFeatureBranch(initialBranch) {
+		return nil, errors.New("you can only compress feature branches")
+	}
+	mainBranch := run.Config.MainBranch()
+	err = validate.KnowsBranchAncestry(initialBranch, mainBranch, &run.Backend)
+	if err != nil {
+		return nil, err
+	}
+	run.Config.Reload()
+	hasOrigin, err := run.Backend.HasOrigin()
+	if err != nil {
+		return nil, err
+	}
+	isOffline, err := run.Config.IsOffline()
+	if err != nil {
+		return nil, err
+	}
+	syncStrategy, err := run.Config.SyncStrategy()
+	if err != nil {
+		return nil, err
+	}
+	branches := []compressedBranch{}
+	changedBranches := map[string]bool{}
+	for i, branchName := range append([]string{initialBranch}, run.Config.DescendantBranches(initialBranch)...) {
+		isInitialBranch := i == 0
+		parent := run.Config.ParentBranch(branchName)
+		restack := changedBranches[parent]
+		compress := isInitialBranch || stack
+		if !compress && (!restack || syncStrategy != config.SyncStrategyRebase) {
+			continue
+		}
+		hasLocalBranch, err := run.Backend.HasLocalBranch(branchName)
+		if err != nil {
+			return nil, err
+		}
+		if !hasLocalBranch {
+			continue
+		}
+		base, err := run.Backend.MergeBase(branchName, parent)
+		if err != nil {
+			return nil, err
+		}
+		commits, err := run.Backend.CommitsInBranch(branchName, base)
+		if err != nil {
+			return nil, err
+		}
+		if isInitialBranch && len(commits) == 0 {
+			return nil, fmt.Errorf("branch %q has no commits to compress", branchName)
+		}
+		commitMessage := message
+		if compress && len(commits) > 0 && commitMessage == "" {
+			commitMessage, err = run.Backend.CommitMessage(commits[0])
+			if err != nil {
+				return nil, err
+			}
+		}
+		compress = compress && (len(commits) > 1 || (len(commits) == 1 && message != ""))
+		if !compress && !restack {
+			continue
+		}
+		hasTrackingBranch, err := run.Backend.HasTrackingBranch(branchName)
+		if err != nil {
+			return nil, err
+		}
+		changedBranches[branchName] = true
+		branches = append(branches, compressedBranch{
+			name:              branchName,
+			parent:            parent,
+			base:              base,
+			compress:          compress,
+			hasTrackingBranch: hasTrackingBranch,
+			message:           commitMessage,
+			restack:           restack,
+		})
+	}
+	return &compressConfig{
+		branches:      branches,
+		hasOrigin:     hasOrigin,
+		initialBranch: initialBranch,
+		isOffline:     isOffline,
+		mainBranch:    mainBranch,
+	}, nil
+}
+
+func compressStepList(config *compressConfig, run *git.ProdRunner) (runstate.StepList, error) {
+	list := runstate.StepListBuilder{}
+	for _, branch := range config.branches {
+		list.Add(&steps.CheckoutStep{Branch: branch.name})
+		base := branch.base
+		if branch.restack {
+			list.Add(&steps.RebaseOntoStep{Base: branch.base, Onto: branch.parent})
+			base = branch.parent
+		}
+		if branch.compress {
+			list.Add(&steps.CompressBranchStep{Base: base, Message: branch.message})
+		}
+		if branch.hasTrackingBranch && config.hasOrigin {
+			if config.isOffline {
+				list.Add(&steps.QueueOfflineOperationStep{Operation: offlinequeue.Operation{Type: offlinequeue.PushBranch, Branch: branch.name, ForceWithLease: true}})
+			} else {
+				list.Add(&steps.PushBranchStep{Branch: branch.name, ForceWithLease: true, Undoable: true})
+			}
+		}
+	}
+	list.Add(&steps.CheckoutStep{Branch: config.initialBranch})
+	list.Wrap(runstate.WrapOptions{RunInGitRoot: true, StashOpenChanges: true}, &run.Backend, config.mainBranch)
+	return list.Result()
+}
@@ -5,9 +5,12 @@ import (
 	"strings"
 
 	"github.com/git-town/git-town/v8/src/cli"
+	"github.com/git-town/git-town/v8/src/config"
 	"github.com/git-town/git-town/v8/src/execute"
 	"github.com/git-town/git-town/v8/src/failure"
 	"github.com/git-town/git-town/v8/src/flags"
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
 	"github.com/spf13/cobra"
 )
 
@@ -26,7 +29,10 @@ func configCmd() *cobra.Command {
 		},
 	}
 	addDebugFlag(&configCmd)
+	configCmd.AddCommand(contributionBranchesCmd())
+	configCmd.AddCommand(logConfigCmd())
 	configCmd.AddCommand(mainbranchConfigCmd())
+	configCmd.AddCommand(observedBranchesCmd())
 	configCmd.AddCommand(offlineCmd())
 	configCmd.AddCommand(perennialBranchesCmd())
 	configCmd.AddCommand(pullBranchStrategyCommand())
@@ -38,7 +44,7 @@ func configCmd() *cobra.Command {
 	return &configCmd
 }
 
-func runConfig(debug bool) error {
+func runConfig(debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		OmitBranchNames:       true,
 		Debug:                 debug,
@@ -53,7 +59,8 @@ func runConfig(debug bool) error {
 	fc := failure.Collector{}
 	pushNewBranches := fc.Bool(run.Config.ShouldNewBranchPush())
 	pushHook := fc.Bool(run.Config.PushHook())
-	isOffline := fc.Bool(run.Config.IsOffline())
+	offlineMode := fc.OfflineMode(run.Config.OfflineMode())
+	isLogEnabled := fc.Bool(run.Config.IsLogEnabled())
 	deleteOrigin := fc.Bool(run.Config.ShouldShipDeleteOriginBranch())
 	pullBranchStrategy := fc.PullBranchStrategy(run.Config.PullBranchStrategy())
 	shouldSyncUpstream := fc.Bool(run.Config.ShouldSyncUpstream())
@@ -66,9 +73,12 @@ func runConfig(debug bool) error {
 	cli.PrintHeader("Branches")
 	cli.PrintEntry("main branch", cli.StringSetting(run.Config.MainBranch()))
 	cli.PrintEntry("perennial branches", cli.StringSetting(strings.Join(run.Config.PerennialBranches(), ", ")))
+	cli.PrintEntry("observed branches", cli.StringSetting(strings.Join(run.Config.ObservedBranches(), ", ")))
+	cli.PrintEntry("contribution branches", cli.StringSetting(strings.Join(run.Config.ContributionBranches(), ", ")))
 	fmt.Println()
 	cli.PrintHeader("Configuration")
-	cli.PrintEntry("offline", cli.BoolSetting(isOffline))
+	cli.PrintEntry("offline", formatOfflineMode(offlineMode))
+	cli.PrintEntry("run log", cli.BoolSetting(isLogEnabled))
 	cli.PrintEntry("pull branch strategy", string(pullBranchStrategy))
 	cli.PrintEntry("run pre-push hook", cli.BoolSetting(pushHook))
 	cli.PrintEntry("push new branches", cli.BoolSetting(pushNewBranches))
@@ -78,12 +88,29 @@ func runConfig(debug bool) error {
 	fmt.Println()
 	cli.PrintHeader("Hosting")
 	cli.PrintEntry("hosting service override", cli.StringSetting(string(hostingService)))
-	cli.PrintEntry("GitHub token", cli.StringSetting(run.Config.GitHubToken()))
-	cli.PrintEntry("GitLab token", cli.StringSetting(run.Config.GitLabToken()))
-	cli.PrintEntry("Gitea token", cli.StringSetting(run.Config.GiteaToken()))
+	cli.PrintEntry("GitHub token", apiTokenSetting(config.HostingServiceGitHub, "github.com", hostingService, &run))
+	cli.PrintEntry("GitLab token", apiTokenSetting(config.HostingServiceGitLab, "gitlab.com", hostingService, &run))
+	cli.PrintEntry("Gitea token", apiTokenSetting(config.HostingServiceGitea, "gitea.com", hostingService, &run))
 	fmt.Println()
 	if run.Config.MainBranch() != "" {
 		cli.PrintLabelAndValue("Branch Ancestry", cli.PrintableBranchAncestry(run.Config))
 	}
 	return nil
 }
+
+// apiTokenSetting describes the API token that Git Town uses for the given code hosting service,
+// whose public instance runs at the given host, in a form that is safe to display.
+// Git Town asks the Git credential helpers only for the code hosting service of the origin remote.
+func apiTokenSetting(service config.HostingService, publicHost string, hostingServiceOverride config.HostingService, run *git.ProdRunner) string {
+	var token hosting.APIToken
+	originURL := run.Config.OriginURL()
+	if originURL != nil && (originURL.Host == publicHost || hostingServiceOverride == service) {
+		token = hosting.FindAPIToken(service, originURL.Host, &run.Config, &run.Backend)
+	} else {
+		token = hosting.FindAPIToken(service, publicHost, &run.Config, nil)
+	}
+	if token.Value == "" {
+		return cli.StringSetting("")
+	}
+	return fmt.Sprintf("%s (from %s)", token.Masked(), token.Source)
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/git-town/git-town/v8/src/cli"
+	"github.com/git-town/git-town/v8/src/config"
+	"github.com/git-town/git-town/v8/src/execute"
+	"github.com/git-town/git-town/v8/src/flags"
+	"github.com/git-town/git-town/v8/src/stringslice"
+	"github.com/spf13/cobra"
+)
+
+const observedDesc = "Displays your observed branches"
+
+const observedHelp = `
+Observed branches are branches of other people that you only look at.
+Git Town pulls updates for them but never pushes them,
+syncs them with their parent, or ships them.
+Killing an observed branch only deletes the local branch.`
+
+const contributionDesc = "Displays your contribution branches"
+
+const contributionHelp = `
+Contribution branches are branches of other people that you add commits to.
+Git Town pushes them but never syncs them with their parent or ships them.
+Killing a contribution branch only deletes the local branch.`
+
+// branchType describes a type of branches that is stored as a list in the Git Town configuration.
+type branchType struct {
+	name   string // human-readable name of the branch type
+	add    func(*config.GitTown, string) error
+	list   func(*config.GitTown) []string
+	remove func(*config.GitTown, string) error
+}
+
+func observedBranchType() branchType {
+	return branchType{
+		name:   "observed",
+		add:    func(gt *config.GitTown, branch string) error { return gt.AddToObservedBranches(branch) },
+		list:   (*config.GitTown).ObservedBranches,
+		remove: (*config.GitTown).RemoveFromObservedBranches,
+	}
+}
+
+func contributionBranchType() branchType {
+	return branchType{
+		name:   "contribution",
+		add:    func(gt *config.GitTown, branch string) error { return gt.AddToContributionBranches(branch) },
+		list:   (*config.GitTown).ContributionBranches,
+		remove: (*config.GitTown).RemoveFromContributionBranches,
+	}
+}
+
+func observedBranchesCmd() *cobra.Command {
+	return branchTypeCmd("observed-branches", observedDesc, observedHelp, observedBranchType(), contributionBranchType())
+}
+
+func contributionBranchesCmd() *cobra.Command {
+	return branchTypeCmd("contribution-branches", contributionDesc, contributionHelp, contributionBranchType(), observedBranchType())
+}
+
+// branchTypeCmd provides the command to display and update the branches of the given type.
+// A branch can only have one type, so adding a branch to the given type removes it from the other type.
+func branchTypeCmd(use, desc, help string, bType, otherType branchType) *cobra.Command {
+	addDisplayDebugFlag, readDisplayDebugFlag := flags.Debug()
+	displayCmd := cobra.Command{
+		Use:   use,
+		Args:  cobra.NoArgs,
+		Short: desc,
+		Long:  long(desc, help),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return displayBranchType(bType, readDisplayDebugFlag(cmd))
+		},
+	}
+	addDisplayDebugFlag(&displayCmd)
+
+	addSummary := fmt.Sprintf("Adds the given branch to the %s branches", bType.name)
+	addAddDebugFlag, readAddDebugFlag := flags.Debug()
+	addCmd := cobra.Command{
+		Use:   "add <branch>",
+		Args:  cobra.ExactArgs(1),
+		Short: addSummary,
+		Long:  long(addSummary),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return addBranchType(args[0], bType, otherType, readAddDebugFlag(cmd))
+		},
+	}
+	addAddDebugFlag(&addCmd)
+	displayCmd.AddCommand(&addCmd)
+
+	removeSummary := fmt.Sprintf("Removes the given branch from the %s branches", bType.name)
+	addRemoveDebugFlag, readRemoveDebugFlag := flags.Debug()
+	removeCmd := cobra.Command{
+		Use:   "remove <branch>",
+		Args:  cobra.ExactArgs(1),
+		Short: removeSummary,
+		Long:  long(removeSummary),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return removeBranchType(args[0], bType, readRemoveDebugFlag(cmd))
+		},
+	}
+	addRemoveDebugFlag(&removeCmd)
+	displayCmd.AddCommand(&removeCmd)
+	return &displayCmd
+}
+
+func displayBranchType(bType branchType, debug flags.DebugArgs) error {
+	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
+		OmitBranchNames:       true,
+		Debug:                 debug,
+		DryRun:                false,
+		HandleUnfinishedState: false,
+		ValidateGitversion:    true,
+		ValidateIsRepository:  true,
+	})
+	if err != nil || exit {
+		return err
+	}
+	cli.Println(cli.StringSetting(strings.Join(bType.list(run.Config.GitTown), "\n")))
+	return nil
+}
+
+func addBranchType(branch string, bType, otherType branchType, debug flags.DebugArgs) error {
+	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
+		OmitBranchNames:       true,
+		Debug:                 debug,
+		DryRun:                false,
+		HandleUnfinishedState: false,
+		ValidateGitversion:    true,
+		ValidateIsRepository:  true,
+		ValidateIsConfigured:  true,
+	})
+	if err != nil || exit {
+		return err
+	}
+	if !run.Config.IsFeatureBranch(branch) {
+		return fmt.Errorf("branch %q is not a feature branch", branch)
+	}
+	hasBranch, err := run.Backend.HasLocalOrOriginBranch(branch, run.Config.MainBranch())
+	if err != nil {
+		return err
+	}
+	if !hasBranch {
+		return fmt.Errorf("there is no branch named %q", branch)
+	}
+	if stringslice.Contains(bType.list(run.Config.GitTown), branch) {
+		return fmt.Errorf("branch %q is already in the %s branches", branch, bType.name)
+	}
+	if stringslice.Contains(otherType.list(run.Config.GitTown), branch) {
+		err = otherType.remove(run.Config.GitTown, branch)
+		if err != nil {
+			return err
+		}
+	}
+	return bType.add(run.Config.GitTown, branch)
+}
+
+func removeBranchType(branch string, bType branchType, debug flags.DebugArgs) error {
+	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
+		OmitBranchNames:       true,
+		Debug:                 debug,
+		DryRun:                false,
+		HandleUnfinishedState: false,
+		ValidateGitversion:    true,
+		ValidateIsRepository:  true,
+	})
+	if err != nil || exit {
+		return err
+	}
+	if !stringslice.Contains(bType.list(run.Config.GitTown), branch) {
+		return fmt.Errorf("branch %q is not in the %s branches", branch, bType.name)
+	}
+	return bType.remove(run.Config.GitTown, branch)
+}
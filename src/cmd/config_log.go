@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v8/src/cli"
+	"github.com/git-town/git-town/v8/src/config"
+	"github.com/git-town/git-town/v8/src/execute"
+	"github.com/git-town/git-town/v8/src/flags"
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/spf13/cobra"
+)
+
+const logConfigDesc = "Displays or sets whether Git Town records its runs"
+
+const logConfigHelp = `
+When enabled, Git Town records each run in a log file in its configuration directory:
+the command-line arguments, the Git Town configuration,
+all executed Git commands and their exit codes, API calls to your code hosting service,
+and the final state of the run.
+The log file gets rotated when it becomes too large.
+
+To browse the log, run "git town log".`
+
+func logConfigCmd() *cobra.Command {
+	addDebugFlag, readDebugFlag := flags.Debug()
+	cmd := cobra.Command{
+		Use:   "log [(yes | no)]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: logConfigDesc,
+		Long:  long(logConfigDesc, logConfigHelp),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return logConfig(args, readDebugFlag(cmd))
+		},
+	}
+	addDebugFlag(&cmd)
+	return &cmd
+}
+
+func logConfig(args []string, debug flags.DebugArgs) error {
+	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
+		OmitBranchNames:       true,
+		Debug:                 debug,
+		DryRun:                false,
+		HandleUnfinishedState: false,
+		ValidateGitversion:    true,
+	})
+	if err != nil || exit {
+		return err
+	}
+	if len(args) > 0 {
+		return setLogEnabled(args[0], &run)
+	}
+	return displayLogEnabled(&run)
+}
+
+func displayLogEnabled(run *git.ProdRunner) error {
+	enabled, err := run.Config.IsLogEnabled()
+	if err != nil {
+		return err
+	}
+	cli.Println(cli.FormatBool(enabled))
+	return nil
+}
+
+func setLogEnabled(text string, run *git.ProdRunner) error {
+	value, err := config.ParseBool(text)
+	if err != nil {
+		return fmt.Errorf(`invalid argument: %q. Please provide either "yes" or "no".\n`, text)
+	}
+	return run.Config.SetLogEnabled(value)
+}
@@ -30,7 +30,7 @@ func mainbranchConfigCmd() *cobra.Command {
 	return &cmd
 }
 
-func configureMainBranch(args []string, debug bool) error {
+func configureMainBranch(args []string, debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		OmitBranchNames:       true,
 		Debug:                 debug,
@@ -14,12 +14,15 @@ import (
 const offlineDesc = "Displays or sets offline mode"
 
 const offlineHelp = `
-Git Town avoids network operations in offline mode.`
+Git Town avoids network operations in offline mode.
+
+In automatic offline mode ("auto"), Git Town checks whether it can reach origin
+and skips network operations if it cannot.`
 
 func offlineCmd() *cobra.Command {
 	addDebugFlag, readDebugFlag := flags.Debug()
 	cmd := cobra.Command{
-		Use:   "offline [(yes | no)]",
+		Use:   "offline [(yes | no | auto)]",
 		Args:  cobra.MaximumNArgs(1),
 		Short: offlineDesc,
 		Long:  long(offlineDesc, offlineHelp),
@@ -31,7 +34,7 @@ func offlineCmd() *cobra.Command {
 	return &cmd
 }
 
-func offline(args []string, debug bool) error {
+func offline(args []string, debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		OmitBranchNames:       true,
 		Debug:                 debug,
@@ -49,18 +52,30 @@ func offline(args []string, debug bool) error {
 }
 
 func displayOfflineStatus(run *git.ProdRunner) error {
-	isOffline, err := run.Config.IsOffline()
+	mode, err := run.Config.OfflineMode()
 	if err != nil {
 		return err
 	}
-	cli.Println(cli.FormatBool(isOffline))
+	cli.Println(formatOfflineMode(mode))
 	return nil
 }
 
+// formatOfflineMode provides a human-readable serialization of the given offline mode.
+func formatOfflineMode(mode config.OfflineMode) string {
+	switch mode {
+	case config.OfflineModeAuto:
+		return "auto"
+	case config.OfflineModeOn:
+		return cli.FormatBool(true)
+	case config.OfflineModeOff:
+	}
+	return cli.FormatBool(false)
+}
+
 func setOfflineStatus(text string, run *git.ProdRunner) error {
-	value, err := config.ParseBool(text)
-	if err != nil {
-		return fmt.Errorf(`invalid argument: %q. Please provide either "yes" or "no".\n`, text)
+	mode, err := config.NewOfflineMode(text)
+	if err != nil || text == "" {
+		return fmt.Errorf(`invalid argument: %q. Please provide either "yes", "no", or "auto".\n`, text)
 	}
-	return run.Config.SetOffline(value)
+	return run.Config.SetOfflineMode(mode)
 }
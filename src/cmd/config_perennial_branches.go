@@ -46,7 +46,7 @@ func perennialBranchesCmd() *cobra.Command {
 	return &displayCmd
 }
 
-func displayPerennialBranches(debug bool) error {
+func displayPerennialBranches(debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		OmitBranchNames:       true,
 		Debug:                 debug,
@@ -62,7 +62,7 @@ func displayPerennialBranches(debug bool) error {
 	return nil
 }
 
-func updatePerennialBranches(debug bool) error {
+func updatePerennialBranches(debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		OmitBranchNames:       true,
 		Debug:                 debug,
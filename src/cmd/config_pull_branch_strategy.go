@@ -31,7 +31,7 @@ func pullBranchStrategyCommand() *cobra.Command {
 	return &cmd
 }
 
-func pullBranchStrategy(args []string, debug bool) error {
+func pullBranchStrategy(args []string, debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		OmitBranchNames:       true,
 		Debug:                 debug,
@@ -33,7 +33,7 @@ func pushHookCommand() *cobra.Command {
 	return &cmd
 }
 
-func pushHook(args []string, global, debug bool) error {
+func pushHook(args []string, global bool, debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		OmitBranchNames:       true,
 		Debug:                 debug,
@@ -34,7 +34,7 @@ func pushNewBranchesCommand() *cobra.Command {
 	return &cmd
 }
 
-func pushNewBranches(args []string, global, debug bool) error {
+func pushNewBranches(args []string, global bool, debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		OmitBranchNames:       true,
 		Debug:                 debug,
@@ -23,7 +23,7 @@ func resetConfigCommand() *cobra.Command {
 	return &cmd
 }
 
-func resetStatus(debug bool) error {
+func resetStatus(debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		OmitBranchNames:       true,
 		Debug:                 debug,
@@ -24,7 +24,7 @@ func setupConfigCommand() *cobra.Command {
 	return &cmd
 }
 
-func setup(debug bool) error {
+func setup(debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		OmitBranchNames:       true,
 		Debug:                 debug,
@@ -32,7 +32,7 @@ func syncStrategyCommand() *cobra.Command {
 	return &cmd
 }
 
-func syncStrategy(args []string, global, debug bool) error {
+func syncStrategy(args []string, global bool, debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		OmitBranchNames:       true,
 		Debug:                 debug,
@@ -29,7 +29,7 @@ func continueCmd() *cobra.Command {
 	return &cmd
 }
 
-func runContinue(debug bool) error {
+func runContinue(debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
@@ -55,7 +55,7 @@ func runContinue(debug bool) error {
 	if hasConflicts {
 		return fmt.Errorf("you must resolve the conflicts before continuing")
 	}
-	connector, err := hosting.NewConnector(run.Config.GitTown, &run.Backend, cli.PrintConnectorAction)
+	connector, err := hosting.NewConnector(&run.Config, &run.Backend, cli.PrintConnectorAction, run.Stats)
 	if err != nil {
 		return err
 	}
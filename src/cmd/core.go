@@ -27,14 +27,19 @@ func Execute() error {
 	rootCmd.AddCommand(aliasesCommand())
 	rootCmd.AddCommand(appendCmd())
 	rootCmd.AddCommand(completionsCmd(&rootCmd))
+	rootCmd.AddCommand(compressCmd())
 	rootCmd.AddCommand(configCmd())
 	rootCmd.AddCommand(continueCmd())
 	rootCmd.AddCommand(diffParentCommand())
+	rootCmd.AddCommand(doctorCmd())
 	rootCmd.AddCommand(hackCmd())
 	rootCmd.AddCommand(killCommand())
+	rootCmd.AddCommand(logCmd())
 	rootCmd.AddCommand(newPullRequestCommand())
+	rootCmd.AddCommand(parkCmd())
 	rootCmd.AddCommand(prependCommand())
 	rootCmd.AddCommand(pruneBranchesCommand())
+	rootCmd.AddCommand(publishCmd())
 	rootCmd.AddCommand(renameBranchCommand())
 	rootCmd.AddCommand(repoCommand())
 	rootCmd.AddCommand(statusCommand())
@@ -44,7 +49,9 @@ func Execute() error {
 	rootCmd.AddCommand(switchCmd())
 	rootCmd.AddCommand(syncCmd())
 	rootCmd.AddCommand(undoCmd())
+	rootCmd.AddCommand(unparkCmd())
 	rootCmd.AddCommand(versionCmd())
+	rootCmd.AddCommand(walkCmd())
 	return rootCmd.Execute()
 }
 
@@ -33,7 +33,7 @@ func diffParentCommand() *cobra.Command {
 	return &cmd
 }
 
-func diffParent(args []string, debug bool) error {
+func diffParent(args []string, debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
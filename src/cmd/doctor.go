@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/git-town/git-town/v8/src/cli"
+	"github.com/git-town/git-town/v8/src/dialog"
+	"github.com/git-town/git-town/v8/src/doctor"
+	"github.com/git-town/git-town/v8/src/execute"
+	"github.com/git-town/git-town/v8/src/flags"
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
+	"github.com/spf13/cobra"
+)
+
+const doctorDesc = "Checks the Git Town configuration of this repository for problems"
+
+const doctorHelp = `
+Finds problems in the Git Town configuration that can cause confusing behavior:
+- deprecated, unknown, and invalid settings
+- a missing main branch
+- branch type settings that contain branches that don't exist
+- parent entries that form cycles, belong to perennial branches,
+  belong to branches that don't exist, or point to branches that don't exist
+- API tokens for code hosting services that this repository doesn't use
+
+For each problem that Git Town can repair, it asks whether to repair it.
+With the --fix flag, Git Town repairs all of them without asking.`
+
+func doctorCmd() *cobra.Command {
+	addDebugFlag, readDebugFlag := flags.Debug()
+	addFixFlag, readFixFlag := flags.Bool("fix", "", "Repair all problems that Git Town can repair without asking")
+	cmd := cobra.Command{
+		Use:     "doctor",
+		GroupID: "setup",
+		Args:    cobra.NoArgs,
+		Short:   doctorDesc,
+		Long:    long(doctorDesc, doctorHelp),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctor(readFixFlag(cmd), readDebugFlag(cmd))
+		},
+	}
+	addDebugFlag(&cmd)
+	addFixFlag(&cmd)
+	return &cmd
+}
+
+func runDoctor(fix bool, debug flags.DebugArgs) error {
+	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
+		OmitBranchNames:       true,
+		Debug:                 debug,
+		DryRun:                false,
+		HandleUnfinishedState: false,
+		ValidateGitversion:    true,
+		ValidateIsRepository:  true,
+	})
+	if err != nil || exit {
+		return err
+	}
+	problems, err := determineDoctorProblems(&run)
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		fmt.Println("Git Town found no problems with the configuration of this repository.")
+		run.Stats.PrintAnalysis()
+		return nil
+	}
+	cli.PrintHeader("Problems")
+	for p, problem := range problems {
+		fmt.Printf("%d. %s\n", p+1, problem.Description)
+		if problem.CanFix() {
+			fmt.Printf("   fix: %s\n", problem.Fix)
+		}
+	}
+	remaining := 0
+	for p, problem := range problems {
+		if !problem.CanFix() {
+			remaining++
+			continue
+		}
+		shouldFix := fix
+		if !shouldFix {
+			shouldFix, err = dialog.Confirm(fmt.Sprintf("Fix problem %d (%s)?", p+1, problem.Fix), true)
+			if err != nil {
+				return err
+			}
+		}
+		if !shouldFix {
+			remaining++
+			continue
+		}
+		err = problem.Repair()
+		if err != nil {
+			return fmt.Errorf("cannot fix problem %d: %w", p+1, err)
+		}
+		fmt.Printf("Fixed problem %d.\n", p+1)
+	}
+	run.Stats.PrintAnalysis()
+	if remaining > 0 {
+		return errors.New("some problems remain, please fix them manually")
+	}
+	return nil
+}
+
+func determineDoctorProblems(run *git.ProdRunner) ([]doctor.Problem, error) {
+	branches, err := run.Backend.LocalAndOriginBranches(run.Config.MainBranch())
+	if err != nil {
+		return []doctor.Problem{}, err
+	}
+	hostingService := ""
+	// an invalid hosting service setting is one of the problems that doctor reports,
+	// so it must not prevent doctor from running
+	connector, err := hosting.NewConnector(&run.Config, &run.Backend, cli.PrintConnectorAction, run.Stats)
+	if err == nil && connector != nil {
+		hostingService = connector.HostingServiceName()
+	}
+	return doctor.Diagnose(&run.Config, branches, hostingService), nil
+}
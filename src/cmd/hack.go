@@ -7,6 +7,7 @@ import (
 	"github.com/git-town/git-town/v8/src/failure"
 	"github.com/git-town/git-town/v8/src/flags"
 	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hooks"
 	"github.com/git-town/git-town/v8/src/runstate"
 	"github.com/git-town/git-town/v8/src/validate"
 	"github.com/spf13/cobra"
@@ -21,11 +22,15 @@ pushes the new feature branch to origin
 (if and only if "push-new-branches" is true),
 and brings over all uncommitted changes to the new feature branch.
 
+With "--prototype", the new branch exists only locally
+until you publish it with "git town publish".
+
 See "sync" for information regarding upstream remotes.`
 
 func hackCmd() *cobra.Command {
 	addDebugFlag, readDebugFlag := flags.Debug()
 	addPromptFlag, readPromptFlag := flags.Bool("prompt", "p", "Prompt for the parent branch")
+	addPrototypeFlag, readPrototypeFlag := flags.Bool("prototype", "", "Never push the new branch until it gets published")
 	cmd := cobra.Command{
 		Use:     "hack <branch>",
 		GroupID: "basic",
@@ -33,15 +38,16 @@ func hackCmd() *cobra.Command {
 		Short:   hackDesc,
 		Long:    long(hackDesc, hackHelp),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return hack(args, readPromptFlag(cmd), readDebugFlag(cmd))
+			return hack(args, readPromptFlag(cmd), readPrototypeFlag(cmd), readDebugFlag(cmd))
 		},
 	}
 	addDebugFlag(&cmd)
 	addPromptFlag(&cmd)
+	addPrototypeFlag(&cmd)
 	return &cmd
 }
 
-func hack(args []string, promptForParent, debug bool) error {
+func hack(args []string, promptForParent, prototype bool, debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
@@ -53,7 +59,7 @@ func hack(args []string, promptForParent, debug bool) error {
 	if err != nil || exit {
 		return err
 	}
-	config, err := determineHackConfig(args, promptForParent, &run)
+	config, err := determineHackConfig(args, promptForParent, prototype, &run)
 	if err != nil {
 		return err
 	}
@@ -61,11 +67,15 @@ func hack(args []string, promptForParent, debug bool) error {
 	if err != nil {
 		return err
 	}
+	err = stepList.AppendHook(hooks.PostHack, hooks.Context{Branch: config.targetBranch, Parent: config.parentBranch, Proposal: 0}, &run)
+	if err != nil {
+		return err
+	}
 	runState := runstate.New("hack", stepList)
 	return runstate.Execute(runState, &run, nil)
 }
 
-func determineHackConfig(args []string, promptForParent bool, run *git.ProdRunner) (*appendConfig, error) {
+func determineHackConfig(args []string, promptForParent, prototype bool, run *git.ProdRunner) (*appendConfig, error) {
 	fc := failure.Collector{}
 	targetBranch := args[0]
 	parentBranch := fc.String(determineParentBranch(targetBranch, promptForParent, run))
@@ -85,6 +95,7 @@ func determineHackConfig(args []string, promptForParent bool, run *git.ProdRunne
 		ancestorBranches:    []string{},
 		targetBranch:        targetBranch,
 		parentBranch:        parentBranch,
+		prototype:           prototype,
 		hasOrigin:           hasOrigin,
 		mainBranch:          mainBranch,
 		shouldNewBranchPush: shouldNewBranchPush,
@@ -33,7 +33,7 @@ func killCommand() *cobra.Command {
 	return &cmd
 }
 
-func kill(args []string, debug bool) error {
+func kill(args []string, debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
@@ -62,7 +62,11 @@ type killConfig struct {
 	hasOpenChanges      bool
 	hasTrackingBranch   bool
 	initialBranch       string
+	isContribution      bool
+	isObserved          bool
 	isOffline           bool
+	isParked            bool
+	isPrototype         bool
 	isTargetBranchLocal bool
 	mainBranch          string
 	noPushHook          bool
@@ -86,10 +90,15 @@ func determineKillConfig(args []string, run *git.ProdRunner) (*killConfig, error
 	if !run.Config.IsFeatureBranch(targetBranch) {
 		return nil, fmt.Errorf("you can only kill feature branches")
 	}
+	isObserved := run.Config.IsObservedBranch(targetBranch)
+	isContribution := run.Config.IsContributionBranch(targetBranch)
 	isTargetBranchLocal, err := run.Backend.HasLocalBranch(targetBranch)
 	if err != nil {
 		return nil, err
 	}
+	if !isTargetBranchLocal && (isObserved || isContribution) {
+		return nil, fmt.Errorf("branch %q is not yours, Git Town doesn't delete its remote branch", targetBranch)
+	}
 	if isTargetBranchLocal {
 		err = validate.KnowsBranchAncestry(targetBranch, mainBranch, &run.Backend)
 		if err != nil {
@@ -141,7 +150,11 @@ func determineKillConfig(args []string, run *git.ProdRunner) (*killConfig, error
 		hasOpenChanges:      hasOpenChanges,
 		hasTrackingBranch:   hasTrackingBranch,
 		initialBranch:       initialBranch,
+		isContribution:      isContribution,
+		isObserved:          isObserved,
 		isOffline:           isOffline,
+		isParked:            run.Config.IsParkedBranch(targetBranch),
+		isPrototype:         run.Config.IsPrototypeBranch(targetBranch),
 		isTargetBranchLocal: isTargetBranchLocal,
 		mainBranch:          mainBranch,
 		noPushHook:          !pushHook,
@@ -155,20 +168,40 @@ func killStepList(config *killConfig, run *git.ProdRunner) (runstate.StepList, e
 	result := runstate.StepList{}
 	switch {
 	case config.isTargetBranchLocal:
-		if config.hasTrackingBranch && !config.isOffline {
+		// observed and contribution branches don't need to have a parent
+		newParent := config.targetBranchParent
+		if newParent == "" {
+			newParent = config.mainBranch
+		}
+		// observed and contribution branches belong to somebody else, so only delete their local branch
+		if config.hasTrackingBranch && !config.isOffline && !config.isObserved && !config.isContribution {
 			result.Append(&steps.DeleteOriginBranchStep{Branch: config.targetBranch, IsTracking: true, NoPushHook: config.noPushHook})
 		}
 		if config.initialBranch == config.targetBranch {
 			if config.hasOpenChanges {
 				result.Append(&steps.CommitOpenChangesStep{})
 			}
-			result.Append(&steps.CheckoutStep{Branch: config.targetBranchParent})
+			result.Append(&steps.CheckoutStep{Branch: newParent})
 		}
 		result.Append(&steps.DeleteLocalBranchStep{Branch: config.targetBranch, Parent: config.mainBranch, Force: true})
 		for _, child := range config.childBranches {
-			result.Append(&steps.SetParentStep{Branch: child, ParentBranch: config.targetBranchParent})
+			result.Append(&steps.SetParentStep{Branch: child, ParentBranch: newParent})
+		}
+		if config.targetBranchParent != "" {
+			result.Append(&steps.DeleteParentBranchStep{Branch: config.targetBranch, Parent: config.targetBranchParent})
+		}
+		if config.isObserved {
+			result.Append(&steps.RemoveFromObservedBranchesStep{Branch: config.targetBranch})
+		}
+		if config.isContribution {
+			result.Append(&steps.RemoveFromContributionBranchesStep{Branch: config.targetBranch})
+		}
+		if config.isParked {
+			result.Append(&steps.RemoveFromParkedBranchesStep{Branch: config.targetBranch})
+		}
+		if config.isPrototype {
+			result.Append(&steps.RemoveFromPrototypeBranchesStep{Branch: config.targetBranch})
 		}
-		result.Append(&steps.DeleteParentBranchStep{Branch: config.targetBranch, Parent: run.Config.ParentBranch(config.targetBranch)})
 	case !config.isOffline:
 		result.Append(&steps.DeleteOriginBranchStep{Branch: config.targetBranch, IsTracking: false, NoPushHook: config.noPushHook})
 	default:
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/git-town/git-town/v8/src/cli"
+	"github.com/git-town/git-town/v8/src/runlog"
+	"github.com/spf13/cobra"
+)
+
+const logDesc = "Displays what Git Town did in earlier runs"
+
+const logHelp = `
+Without arguments, lists the runs of Git Town recorded in the run log.
+With the ID of a run, or "last" for the most recent run,
+displays what happened in that run:
+the command-line arguments, the Git Town configuration,
+all executed Git commands and their exit codes, API calls,
+and the final state of the run.
+
+Git Town only records its runs if you have enabled the run log
+by running "git town config log yes".`
+
+// timeFormat is how "git town log" displays when runs started.
+const timeFormat = "2006-01-02 15:04:05"
+
+// maxListedRuns is how many of the most recent runs "git town log" lists.
+const maxListedRuns = 20
+
+func logCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "log [(<run ID> | last)]",
+		GroupID: "errors",
+		Args:    cobra.MaximumNArgs(1),
+		Short:   logDesc,
+		Long:    long(logDesc, logHelp),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showLog(args)
+		},
+	}
+}
+
+func showLog(args []string) error {
+	dir, err := runlog.Dir()
+	if err != nil {
+		return err
+	}
+	runs, err := runlog.Read(dir)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		if args[0] == "last" && len(runs) > 0 {
+			printRun(runs[len(runs)-1])
+			return nil
+		}
+		for _, run := range runs {
+			if run.ID == args[0] {
+				printRun(run)
+				return nil
+			}
+		}
+		return fmt.Errorf("the run log contains no run with ID %q", args[0])
+	}
+	if len(runs) == 0 {
+		fmt.Println(`The run log is empty. To enable it, run "git town config log yes".`)
+		return nil
+	}
+	if len(runs) > maxListedRuns {
+		runs = runs[len(runs)-maxListedRuns:]
+	}
+	for _, run := range runs {
+		fmt.Println(runSummary(run))
+	}
+	return nil
+}
+
+// printRun prints all information in the run log about the given run.
+func printRun(run runlog.Run) {
+	cli.PrintHeader("Run " + run.ID)
+	if start := run.Start(); start != nil {
+		cli.PrintEntry("started", start.Time.Format(timeFormat))
+		cli.PrintEntry("directory", start.Dir)
+		cli.PrintEntry("command", commandLine(start.Argv))
+		fmt.Println()
+		cli.PrintHeader("Configuration")
+		keys := make([]string, 0, len(start.Config))
+		for key := range start.Config {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			cli.PrintEntry(key, start.Config[key])
+		}
+	}
+	printRunEntries(run, runlog.CommandEntry, "Commands", func(entry runlog.Entry) string {
+		exitCode := "?"
+		if entry.ExitCode != nil {
+			exitCode = strconv.Itoa(*entry.ExitCode)
+		}
+		return fmt.Sprintf("  exit %s  %s  %s", exitCode, entry.Duration.Round(time.Millisecond), entry.Name)
+	})
+	printRunEntries(run, runlog.APICallEntry, "API calls", func(entry runlog.Entry) string {
+		return fmt.Sprintf("  %s  %s", entry.Duration.Round(time.Millisecond), entry.Name)
+	})
+	printRunEntries(run, runlog.RunStateEntry, "Final runstate", func(entry runlog.Entry) string {
+		var formatted bytes.Buffer
+		err := json.Indent(&formatted, entry.RunState, "", "  ")
+		if err != nil {
+			return cli.Indent(string(entry.RunState))
+		}
+		return cli.Indent(formatted.String())
+	})
+}
+
+// printRunEntries prints the entries of the given type in the given run under the given header.
+func printRunEntries(run runlog.Run, entryType, header string, format func(runlog.Entry) string) {
+	printedHeader := false
+	for _, entry := range run.Entries {
+		if entry.Type != entryType {
+			continue
+		}
+		if !printedHeader {
+			fmt.Println()
+			cli.PrintHeader(header)
+			printedHeader = true
+		}
+		fmt.Println(format(entry))
+	}
+}
+
+// runSummary provides a one-line description of the given run.
+func runSummary(run runlog.Run) string {
+	commands := 0
+	failed := 0
+	for _, entry := range run.Entries {
+		if entry.Type != runlog.CommandEntry {
+			continue
+		}
+		commands++
+		if entry.ExitCode != nil && *entry.ExitCode != 0 {
+			failed++
+		}
+	}
+	var started, command string
+	if start := run.Start(); start != nil {
+		started = start.Time.Format(timeFormat)
+		command = commandLine(start.Argv)
+	}
+	result := fmt.Sprintf("%s  %s  %s  (%d commands", run.ID, started, command, commands)
+	if failed > 0 {
+		result += fmt.Sprintf(", %d failed", failed)
+	}
+	return result + ")"
+}
+
+// commandLine provides the given command-line arguments of Git Town in a human-readable form.
+func commandLine(argv []string) string {
+	if len(argv) == 0 {
+		return ""
+	}
+	return strings.Join(append([]string{filepath.Base(argv[0])}, argv[1:]...), " ")
+}
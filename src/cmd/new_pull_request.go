@@ -28,7 +28,8 @@ against the immediate parent branch.
 Supported only for repositories hosted on GitHub, GitLab, Gitea and Bitbucket.
 When using self-hosted versions this command needs to be configured with
 "git config %s <driver>"
-where driver is "github", "gitlab", "gitea", or "bitbucket".
+where driver is "github", "gitlab", "gitea", "bitbucket",
+or "auto" to detect the code hosting service.
 When using SSH identities, this command needs to be configured with
 "git config %s <hostname>"
 where hostname matches what is in your ssh config file.`
@@ -49,7 +50,7 @@ func newPullRequestCommand() *cobra.Command {
 	return &cmd
 }
 
-func newPullRequest(debug bool) error {
+func newPullRequest(debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
@@ -66,7 +67,7 @@ func newPullRequest(debug bool) error {
 	if err != nil {
 		return err
 	}
-	connector, err := hosting.NewConnector(run.Config.GitTown, &run.Backend, cli.PrintConnectorAction)
+	connector, err := hosting.NewConnector(&run.Config, &run.Backend, cli.PrintConnectorAction, run.Stats)
 	if err != nil {
 		return err
 	}
@@ -115,8 +116,13 @@ func determineNewPullRequestConfig(run *git.ProdRunner) (*newPullRequestConfig,
 
 func newPullRequestStepList(config *newPullRequestConfig, run *git.ProdRunner) (runstate.StepList, error) {
 	list := runstate.StepListBuilder{}
+	branchesInOtherWorktrees, err := run.Backend.BranchesInOtherWorktrees()
+	if err != nil {
+		return runstate.StepList{}, err
+	}
+	printSkippedWorktreeBranches(config.BranchesToSync, branchesInOtherWorktrees)
 	for _, branch := range config.BranchesToSync {
-		updateBranchSteps(&list, branch, true, run)
+		updateBranchSteps(&list, branch, true, false, branchesInOtherWorktrees, run)
 	}
 	list.Wrap(runstate.WrapOptions{RunInGitRoot: true, StashOpenChanges: true}, &run.Backend, config.mainBranch)
 	list.Add(&steps.CreateProposalStep{Branch: config.InitialBranch})
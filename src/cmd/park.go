@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v8/src/execute"
+	"github.com/git-town/git-town/v8/src/flags"
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/runstate"
+	"github.com/git-town/git-town/v8/src/steps"
+	"github.com/spf13/cobra"
+)
+
+const parkDesc = "Parks the given feature branch so that it doesn't get synced"
+
+const parkHelp = `
+Parked branches are feature branches that you don't work on right now,
+for example experiments that you want to come back to later.
+"git sync --all" doesn't sync parked branches,
+"git sync" on a parked branch still syncs it.
+
+Without arguments, parks the current branch.
+Use "git town unpark" to make it a normal feature branch again.`
+
+func parkCmd() *cobra.Command {
+	addDebugFlag, readDebugFlag := flags.Debug()
+	cmd := cobra.Command{
+		Use:   "park [<branch>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: parkDesc,
+		Long:  long(parkDesc, parkHelp),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return park(args, readDebugFlag(cmd))
+		},
+	}
+	addDebugFlag(&cmd)
+	return &cmd
+}
+
+func park(args []string, debug flags.DebugArgs) error {
+	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
+		Debug:                 debug,
+		DryRun:                false,
+		HandleUnfinishedState: true,
+		ValidateGitversion:    true,
+		ValidateIsRepository:  true,
+		ValidateIsConfigured:  true,
+	})
+	if err != nil || exit {
+		return err
+	}
+	branch, err := branchArgOrCurrentBranch(args, &run.Backend)
+	if err != nil {
+		return err
+	}
+	if !run.Config.IsFeatureBranch(branch) {
+		return fmt.Errorf("branch %q is not a feature branch, only feature branches can be parked", branch)
+	}
+	hasLocalBranch, err := run.Backend.HasLocalBranch(branch)
+	if err != nil {
+		return err
+	}
+	if !hasLocalBranch {
+		return fmt.Errorf("there is no local branch named %q", branch)
+	}
+	if run.Config.IsParkedBranch(branch) {
+		return fmt.Errorf("branch %q is already parked", branch)
+	}
+	stepList := runstate.StepList{}
+	stepList.Append(&steps.AddToParkedBranchesStep{Branch: branch})
+	runState := runstate.New("park", stepList)
+	return runstate.Execute(runState, &run, nil)
+}
+
+// branchArgOrCurrentBranch provides the branch given in the arguments, or the current branch if no branch was given.
+func branchArgOrCurrentBranch(args []string, backend *git.BackendCommands) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	return backend.CurrentBranch()
+}
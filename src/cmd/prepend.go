@@ -7,6 +7,7 @@ import (
 	"github.com/git-town/git-town/v8/src/failure"
 	"github.com/git-town/git-town/v8/src/flags"
 	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/offlinequeue"
 	"github.com/git-town/git-town/v8/src/runstate"
 	"github.com/git-town/git-town/v8/src/steps"
 	"github.com/git-town/git-town/v8/src/validate"
@@ -42,7 +43,7 @@ func prependCommand() *cobra.Command {
 	return &cmd
 }
 
-func prepend(args []string, debug bool) error {
+func prepend(args []string, debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
@@ -125,15 +126,28 @@ func determinePrependConfig(args []string, run *git.ProdRunner) (*prependConfig,
 
 func prependStepList(config *prependConfig, run *git.ProdRunner) (runstate.StepList, error) {
 	list := runstate.StepListBuilder{}
+	branchesInOtherWorktrees, err := run.Backend.BranchesInOtherWorktrees()
+	if err != nil {
+		return runstate.StepList{}, err
+	}
+	printSkippedWorktreeBranches(config.ancestorBranches, branchesInOtherWorktrees)
 	for _, branch := range config.ancestorBranches {
-		updateBranchSteps(&list, branch, true, run)
+		updateBranchSteps(&list, branch, true, false, branchesInOtherWorktrees, run)
 	}
-	list.Add(&steps.CreateBranchStep{Branch: config.targetBranch, StartingPoint: config.parentBranch})
+	startingPoint, err := branchingPoint(config.parentBranch, config.hasOrigin, config.isOffline, branchesInOtherWorktrees, run)
+	if err != nil {
+		return runstate.StepList{}, err
+	}
+	list.Add(&steps.CreateBranchStep{Branch: config.targetBranch, StartingPoint: startingPoint})
 	list.Add(&steps.SetParentStep{Branch: config.targetBranch, ParentBranch: config.parentBranch})
 	list.Add(&steps.SetParentStep{Branch: config.initialBranch, ParentBranch: config.targetBranch})
 	list.Add(&steps.CheckoutStep{Branch: config.targetBranch})
-	if config.hasOrigin && config.shouldNewBranchPush && !config.isOffline {
-		list.Add(&steps.CreateTrackingBranchStep{Branch: config.targetBranch, NoPushHook: config.noPushHook})
+	if config.hasOrigin && config.shouldNewBranchPush {
+		if config.isOffline {
+			list.Add(&steps.QueueOfflineOperationStep{Operation: offlinequeue.Operation{Type: offlinequeue.CreateTrackingBranch, Branch: config.targetBranch}})
+		} else {
+			list.Add(&steps.CreateTrackingBranchStep{Branch: config.targetBranch, NoPushHook: config.noPushHook})
+		}
 	}
 	list.Wrap(runstate.WrapOptions{RunInGitRoot: true, StashOpenChanges: true}, &run.Backend, config.mainBranch)
 	return list.Result()
@@ -30,7 +30,7 @@ func pruneBranchesCommand() *cobra.Command {
 	return &cmd
 }
 
-func pruneBranches(debug bool) error {
+func pruneBranches(debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
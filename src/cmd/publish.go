@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v8/src/execute"
+	"github.com/git-town/git-town/v8/src/flags"
+	"github.com/git-town/git-town/v8/src/runstate"
+	"github.com/git-town/git-town/v8/src/steps"
+	"github.com/spf13/cobra"
+)
+
+const publishDesc = "Pushes the given prototype branch to origin"
+
+const publishHelp = `
+Prototype branches, created with "git hack --prototype" or "git append --prototype",
+exist only locally. Git Town doesn't push them to origin.
+This command pushes the given prototype branch to origin
+and makes it a normal feature branch that "git sync" pushes.
+
+Without arguments, publishes the current branch.`
+
+func publishCmd() *cobra.Command {
+	addDebugFlag, readDebugFlag := flags.Debug()
+	cmd := cobra.Command{
+		Use:   "publish [<branch>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: publishDesc,
+		Long:  long(publishDesc, publishHelp),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return publish(args, readDebugFlag(cmd))
+		},
+	}
+	addDebugFlag(&cmd)
+	return &cmd
+}
+
+func publish(args []string, debug flags.DebugArgs) error {
+	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
+		Debug:                 debug,
+		DryRun:                false,
+		HandleUnfinishedState: true,
+		ValidateGitversion:    true,
+		ValidateIsRepository:  true,
+		ValidateIsConfigured:  true,
+	})
+	if err != nil || exit {
+		return err
+	}
+	branch, err := branchArgOrCurrentBranch(args, &run.Backend)
+	if err != nil {
+		return err
+	}
+	if !run.Config.IsPrototypeBranch(branch) {
+		return fmt.Errorf("branch %q is not a prototype branch", branch)
+	}
+	hasOrigin, err := run.Backend.HasOrigin()
+	if err != nil {
+		return err
+	}
+	if !hasOrigin {
+		return fmt.Errorf("cannot publish branch %q because this repository has no origin remote", branch)
+	}
+	isOffline, err := run.Config.IsOffline()
+	if err != nil {
+		return err
+	}
+	if isOffline {
+		return fmt.Errorf("cannot publish branch %q in offline mode", branch)
+	}
+	pushHook, err := run.Config.PushHook()
+	if err != nil {
+		return err
+	}
+	stepList := runstate.StepList{}
+	stepList.Append(&steps.RemoveFromPrototypeBranchesStep{Branch: branch})
+	stepList.Append(&steps.CreateTrackingBranchStep{Branch: branch, NoPushHook: !pushHook})
+	runState := runstate.New("publish", stepList)
+	return runstate.Execute(runState, &run, nil)
+}
@@ -2,12 +2,16 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/git-town/git-town/v8/src/cli"
 	"github.com/git-town/git-town/v8/src/execute"
 	"github.com/git-town/git-town/v8/src/flags"
 	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
 	"github.com/git-town/git-town/v8/src/runstate"
 	"github.com/git-town/git-town/v8/src/steps"
+	"github.com/git-town/git-town/v8/src/validate"
 	"github.com/spf13/cobra"
 )
 
@@ -25,30 +29,38 @@ When there is an origin repository
 
 When there is a tracking branch
 - pushes the new branch to the origin repository
+- moves the proposal for the old branch to the new branch
+- updates the proposals of child branches
 - deletes the old branch from the origin repository
 
 When run on a perennial branch
 - confirm with the "-f" option
-- registers the new perennial branch name in the local Git Town configuration`
+- registers the new perennial branch name in the local Git Town configuration
+
+With the --stack option, renames all branches in the stack of the current branch
+whose names start with the given old prefix
+so that they start with the given new prefix instead.`
 
 func renameBranchCommand() *cobra.Command {
 	addDebugFlag, readDebugFlag := flags.Debug()
 	addForceFlag, readForceFlag := flags.Bool("force", "f", "Force rename of perennial branch")
+	addStackFlag, readStackFlag := flags.Bool("stack", "", "Rename the branches in the current stack that start with the given prefix")
 	cmd := cobra.Command{
 		Use:   "rename-branch [<old_branch_name>] <new_branch_name>",
 		Args:  cobra.RangeArgs(1, 2),
 		Short: renameBranchDesc,
 		Long:  long(renameBranchDesc, renameBranchHelp),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return renameBranch(args, readForceFlag(cmd), readDebugFlag(cmd))
+			return renameBranch(args, readForceFlag(cmd), readStackFlag(cmd), readDebugFlag(cmd))
 		},
 	}
 	addDebugFlag(&cmd)
 	addForceFlag(&cmd)
+	addStackFlag(&cmd)
 	return &cmd
 }
 
-func renameBranch(args []string, force, debug bool) error {
+func renameBranch(args []string, force, stack bool, debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
@@ -60,7 +72,11 @@ func renameBranch(args []string, force, debug bool) error {
 	if err != nil || exit {
 		return err
 	}
-	config, err := determineRenameBranchConfig(args, force, &run)
+	connector, err := hosting.NewConnector(&run.Config, &run.Backend, cli.PrintConnectorAction, run.Stats)
+	if err != nil {
+		return err
+	}
+	config, err := determineRenameBranchConfig(args, force, stack, connector, &run)
 	if err != nil {
 		return err
 	}
@@ -69,22 +85,38 @@ func renameBranch(args []string, force, debug bool) error {
 		return err
 	}
 	runState := runstate.New("rename-branch", stepList)
-	return runstate.Execute(runState, &run, nil)
+	return runstate.Execute(runState, &run, connector)
 }
 
 type renameBranchConfig struct {
-	initialBranch              string
-	isInitialBranchPerennial   bool
-	isOffline                  bool
-	mainBranch                 string
-	newBranch                  string
-	noPushHook                 bool
-	oldBranchChildren          []string
-	oldBranchHasTrackingBranch bool
-	oldBranch                  string
+	initialBranch string
+	isOffline     bool
+	mainBranch    string
+	noPushHook    bool
+	// the branches to rename, parent branches before their children
+	renames []branchRename
+}
+
+// branchRename describes how rename-branch renames a single branch.
+type branchRename struct {
+	oldBranch           string
+	newBranch           string
+	hasTrackingBranch   bool
+	proposal            *hosting.Proposal  // the proposal for the old branch, nil if there is none
+	proposalsOfChildren []hosting.Proposal // the proposals of the child branches of the old branch
+}
+
+// newName provides the name that the given branch has after renaming.
+func (rbc *renameBranchConfig) newName(branch string) string {
+	for _, rename := range rbc.renames {
+		if rename.oldBranch == branch {
+			return rename.newBranch
+		}
+	}
+	return branch
 }
 
-func determineRenameBranchConfig(args []string, forceFlag bool, run *git.ProdRunner) (*renameBranchConfig, error) {
+func determineRenameBranchConfig(args []string, forceFlag, stackFlag bool, connector hosting.Connector, run *git.ProdRunner) (*renameBranchConfig, error) {
 	initialBranch, err := run.Backend.CurrentBranch()
 	if err != nil {
 		return nil, err
@@ -98,25 +130,34 @@ func determineRenameBranchConfig(args []string, forceFlag bool, run *git.ProdRun
 		return nil, err
 	}
 	mainBranch := run.Config.MainBranch()
-	var oldBranch string
-	var newBranch string
-	if len(args) == 1 {
-		oldBranch = initialBranch
-		newBranch = args[0]
+	var renames []branchRename
+	if stackFlag {
+		renames, err = stackRenames(args, initialBranch, run)
+		if err != nil {
+			return nil, err
+		}
 	} else {
-		oldBranch = args[0]
-		newBranch = args[1]
-	}
-	if run.Config.IsMainBranch(oldBranch) {
-		return nil, fmt.Errorf("the main branch cannot be renamed")
-	}
-	if !forceFlag {
-		if run.Config.IsPerennialBranch(oldBranch) {
-			return nil, fmt.Errorf("%q is a perennial branch. Renaming a perennial branch typically requires other updates. If you are sure you want to do this, use '--force'", oldBranch)
+		var oldBranch string
+		var newBranch string
+		if len(args) == 1 {
+			oldBranch = initialBranch
+			newBranch = args[0]
+		} else {
+			oldBranch = args[0]
+			newBranch = args[1]
 		}
-	}
-	if oldBranch == newBranch {
-		return nil, fmt.Errorf("cannot rename branch to current name")
+		if run.Config.IsMainBranch(oldBranch) {
+			return nil, fmt.Errorf("the main branch cannot be renamed")
+		}
+		if !forceFlag {
+			if run.Config.IsPerennialBranch(oldBranch) {
+				return nil, fmt.Errorf("%q is a perennial branch. Renaming a perennial branch typically requires other updates. If you are sure you want to do this, use '--force'", oldBranch)
+			}
+		}
+		if oldBranch == newBranch {
+			return nil, fmt.Errorf("cannot rename branch to current name")
+		}
+		renames = []branchRename{{oldBranch: oldBranch, newBranch: newBranch}} //nolint:exhaustruct
 	}
 	if !isOffline {
 		err := run.Frontend.Fetch()
@@ -124,65 +165,177 @@ func determineRenameBranchConfig(args []string, forceFlag bool, run *git.ProdRun
 			return nil, err
 		}
 	}
-	hasOldBranch, err := run.Backend.HasLocalBranch(oldBranch)
-	if err != nil {
-		return nil, err
+	for r := range renames {
+		rename := &renames[r]
+		hasOldBranch, err := run.Backend.HasLocalBranch(rename.oldBranch)
+		if err != nil {
+			return nil, err
+		}
+		if !hasOldBranch {
+			return nil, fmt.Errorf("there is no branch named %q", rename.oldBranch)
+		}
+		isBranchInSync, err := run.Backend.IsBranchInSync(rename.oldBranch)
+		if err != nil {
+			return nil, err
+		}
+		if !isBranchInSync {
+			return nil, fmt.Errorf("%q is not in sync with its tracking branch, please sync the branches before renaming", rename.oldBranch)
+		}
+		hasNewBranch, err := run.Backend.HasLocalOrOriginBranch(rename.newBranch, mainBranch)
+		if err != nil {
+			return nil, err
+		}
+		if hasNewBranch {
+			return nil, fmt.Errorf("a branch named %q already exists", rename.newBranch)
+		}
+		rename.hasTrackingBranch, err = run.Backend.HasTrackingBranch(rename.oldBranch)
+		if err != nil {
+			return nil, err
+		}
+		rename.proposalsOfChildren = []hosting.Proposal{}
+		if isOffline || connector == nil || !rename.hasTrackingBranch {
+			continue
+		}
+		err = findRenameProposals(rename, connector, run)
+		if err != nil {
+			// renaming branches doesn't require API access, so keep going without updating proposals
+			cli.PrintWarning(fmt.Sprintf("%s. Git Town won't update proposals.", err))
+			connector = nil
+			for p := range renames {
+				renames[p].proposal = nil
+				renames[p].proposalsOfChildren = []hosting.Proposal{}
+			}
+		}
 	}
-	if !hasOldBranch {
-		return nil, fmt.Errorf("there is no branch named %q", oldBranch)
+	return &renameBranchConfig{
+		initialBranch: initialBranch,
+		isOffline:     isOffline,
+		mainBranch:    mainBranch,
+		noPushHook:    !pushHook,
+		renames:       renames,
+	}, nil
+}
+
+// findRenameProposals looks up the proposals that the given rename affects.
+func findRenameProposals(rename *branchRename, connector hosting.Connector, run *git.ProdRunner) error {
+	if parent := run.Config.ParentBranch(rename.oldBranch); parent != "" {
+		proposal, err := connector.FindProposal(rename.oldBranch, parent)
+		if err != nil {
+			return fmt.Errorf("cannot determine proposal for branch %q: %w", rename.oldBranch, err)
+		}
+		rename.proposal = proposal
 	}
-	isBranchInSync, err := run.Backend.IsBranchInSync(oldBranch)
-	if err != nil {
-		return nil, err
+	for _, child := range run.Config.ChildBranches(rename.oldBranch) {
+		childProposal, err := connector.FindProposal(child, rename.oldBranch)
+		if err != nil {
+			return fmt.Errorf("cannot determine proposal for branch %q: %w", child, err)
+		}
+		if childProposal != nil {
+			rename.proposalsOfChildren = append(rename.proposalsOfChildren, *childProposal)
+		}
 	}
-	if !isBranchInSync {
-		return nil, fmt.Errorf("%q is not in sync with its tracking branch, please sync the branches before renaming", oldBranch)
+	return nil
+}
+
+// stackRenames provides the renames of the branches in the stack of the given branch
+// that start with the old prefix given in args.
+func stackRenames(args []string, branch string, run *git.ProdRunner) ([]branchRename, error) {
+	if len(args) != 2 {
+		return []branchRename{}, fmt.Errorf("renaming a stack requires the old and the new branch name prefix")
 	}
-	hasNewBranch, err := run.Backend.HasLocalOrOriginBranch(newBranch, mainBranch)
-	if err != nil {
-		return nil, err
+	oldPrefix := args[0]
+	newPrefix := args[1]
+	if oldPrefix == newPrefix {
+		return []branchRename{}, fmt.Errorf("cannot rename branches to their current names")
 	}
-	if hasNewBranch {
-		return nil, fmt.Errorf("a branch named %q already exists", newBranch)
+	if !run.Config.IsFeatureBranch(branch) {
+		return []branchRename{}, fmt.Errorf("the branch %q is not part of a stack of feature branches", branch)
 	}
-	oldBranchHasTrackingBranch, err := run.Backend.HasTrackingBranch(oldBranch)
+	err := validate.KnowsBranchAncestry(branch, run.Config.MainBranch(), &run.Backend)
 	if err != nil {
-		return nil, err
+		return []branchRename{}, err
 	}
-	return &renameBranchConfig{
-		initialBranch:              initialBranch,
-		isInitialBranchPerennial:   run.Config.IsPerennialBranch(initialBranch),
-		isOffline:                  isOffline,
-		mainBranch:                 mainBranch,
-		newBranch:                  newBranch,
-		noPushHook:                 !pushHook,
-		oldBranch:                  oldBranch,
-		oldBranchChildren:          run.Config.ChildBranches(oldBranch),
-		oldBranchHasTrackingBranch: oldBranchHasTrackingBranch,
-	}, err
+	renames := []branchRename{}
+	for _, stackBranch := range stackBranches(branch, &run.Config) {
+		if strings.HasPrefix(stackBranch, oldPrefix) {
+			renames = append(renames, branchRename{ //nolint:exhaustruct
+				oldBranch: stackBranch,
+				newBranch: newPrefix + strings.TrimPrefix(stackBranch, oldPrefix),
+			})
+		}
+	}
+	if len(renames) == 0 {
+		return []branchRename{}, fmt.Errorf("no branch in the stack of %q starts with %q", branch, oldPrefix)
+	}
+	return renames, nil
+}
+
+// stackBranches provides the feature branches in the stack of the given feature branch,
+// i.e. the oldest ancestor of the given branch that isn't the main or a perennial branch and all its descendants.
+// Parent branches come before their children.
+func stackBranches(branch string, config *git.RepoConfig) []string {
+	root := branch
+	ancestors := config.AncestorBranches(branch)
+	if len(ancestors) > 1 {
+		root = ancestors[1]
+	}
+	return append([]string{root}, config.DescendantBranches(root)...)
 }
 
 func renameBranchStepList(config *renameBranchConfig, run *git.ProdRunner) (runstate.StepList, error) {
 	result := runstate.StepList{}
-	result.Append(&steps.CreateBranchStep{Branch: config.newBranch, StartingPoint: config.oldBranch})
-	if config.initialBranch == config.oldBranch {
-		result.Append(&steps.CheckoutStep{Branch: config.newBranch})
+	for _, rename := range config.renames {
+		renameBranchSteps(&result, rename, config, run)
+	}
+	err := result.Wrap(runstate.WrapOptions{RunInGitRoot: false, StashOpenChanges: false}, &run.Backend, config.mainBranch)
+	return result, err
+}
+
+// renameBranchSteps adds the steps to perform the given rename to the given StepList.
+func renameBranchSteps(result *runstate.StepList, rename branchRename, config *renameBranchConfig, run *git.ProdRunner) {
+	oldParent := run.Config.ParentBranch(rename.oldBranch)
+	newParent := config.newName(oldParent)
+	result.Append(&steps.CreateBranchStep{Branch: rename.newBranch, StartingPoint: rename.oldBranch})
+	if config.initialBranch == rename.oldBranch {
+		result.Append(&steps.CheckoutStep{Branch: rename.newBranch})
 	}
-	if config.isInitialBranchPerennial {
-		result.Append(&steps.RemoveFromPerennialBranchesStep{Branch: config.oldBranch})
-		result.Append(&steps.AddToPerennialBranchesStep{Branch: config.newBranch})
+	if run.Config.IsPerennialBranch(rename.oldBranch) {
+		result.Append(&steps.RemoveFromPerennialBranchesStep{Branch: rename.oldBranch})
+		result.Append(&steps.AddToPerennialBranchesStep{Branch: rename.newBranch})
 	} else {
-		result.Append(&steps.DeleteParentBranchStep{Branch: config.oldBranch, Parent: run.Config.ParentBranch(config.oldBranch)})
-		result.Append(&steps.SetParentStep{Branch: config.newBranch, ParentBranch: run.Config.ParentBranch(config.oldBranch)})
+		result.Append(&steps.DeleteParentBranchStep{Branch: rename.oldBranch, Parent: oldParent})
+		result.Append(&steps.SetParentStep{Branch: rename.newBranch, ParentBranch: newParent})
 	}
-	for _, child := range config.oldBranchChildren {
-		result.Append(&steps.SetParentStep{Branch: child, ParentBranch: config.newBranch})
+	if run.Config.IsPrototypeBranch(rename.oldBranch) {
+		result.Append(&steps.RemoveFromPrototypeBranchesStep{Branch: rename.oldBranch})
+		result.Append(&steps.AddToPrototypeBranchesStep{Branch: rename.newBranch})
 	}
-	if config.oldBranchHasTrackingBranch && !config.isOffline {
-		result.Append(&steps.CreateTrackingBranchStep{Branch: config.newBranch, NoPushHook: config.noPushHook})
-		result.Append(&steps.DeleteOriginBranchStep{Branch: config.oldBranch, IsTracking: true})
+	for _, child := range run.Config.ChildBranches(rename.oldBranch) {
+		// children that get renamed as well get their new parent when renaming them
+		if config.newName(child) == child {
+			result.Append(&steps.SetParentStep{Branch: child, ParentBranch: rename.newBranch})
+		}
 	}
-	result.Append(&steps.DeleteLocalBranchStep{Branch: config.oldBranch, Parent: config.mainBranch})
-	err := result.Wrap(runstate.WrapOptions{RunInGitRoot: false, StashOpenChanges: false}, &run.Backend, config.mainBranch)
-	return result, err
+	if rename.hasTrackingBranch && !config.isOffline {
+		result.Append(&steps.CreateTrackingBranchStep{Branch: rename.newBranch, NoPushHook: config.noPushHook})
+		if rename.proposal != nil {
+			result.Append(&steps.MoveProposalStep{
+				Proposal:     *rename.proposal,
+				ExistingHead: rename.oldBranch,
+				NewHead:      rename.newBranch,
+				NewTarget:    newParent,
+			})
+		}
+		// update the proposals of child branches before deleting the old branch at origin,
+		// because hosting platforms close proposals whose target branch disappears
+		for _, childProposal := range rename.proposalsOfChildren {
+			result.Append(&steps.UpdateProposalTargetStep{
+				ProposalNumber: childProposal.Number,
+				NewTarget:      rename.newBranch,
+				ExistingTarget: rename.oldBranch,
+			})
+		}
+		result.Append(&steps.DeleteOriginBranchStep{Branch: rename.oldBranch, IsTracking: true})
+	}
+	result.Append(&steps.DeleteLocalBranchStep{Branch: rename.oldBranch, Parent: config.mainBranch})
 }
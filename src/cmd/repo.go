@@ -19,7 +19,8 @@ Supported for repositories hosted on GitHub, GitLab, Gitea, and Bitbucket.
 Derives the Git provider from the "origin" remote.
 You can override this detection with
 "git config %s <DRIVER>"
-where DRIVER is "github", "gitlab", "gitea", or "bitbucket".
+where DRIVER is "github", "gitlab", "gitea", or "bitbucket",
+or "auto" to detect self-hosted servers.
 
 When using SSH identities, run
 "git config %s <HOSTNAME>"
@@ -40,7 +41,7 @@ func repoCommand() *cobra.Command {
 	return &cmd
 }
 
-func repo(debug bool) error {
+func repo(debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
@@ -53,7 +54,7 @@ func repo(debug bool) error {
 	if err != nil || exit {
 		return err
 	}
-	connector, err := hosting.NewConnector(run.Config.GitTown, &run.Backend, cli.PrintConnectorAction)
+	connector, err := hosting.NewConnector(&run.Config, &run.Backend, cli.PrintConnectorAction, run.Stats)
 	if err != nil {
 		return err
 	}
@@ -2,32 +2,60 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/git-town/git-town/v8/src/execute"
 	"github.com/git-town/git-town/v8/src/flags"
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/offlinequeue"
+	"github.com/git-town/git-town/v8/src/runstate"
+	"github.com/git-town/git-town/v8/src/steps"
 	"github.com/git-town/git-town/v8/src/validate"
 	"github.com/spf13/cobra"
 )
 
 const setParentDesc = "Prompts to set the parent branch for the current branch"
 
+const setParentHelp = `
+Without arguments, prompts for the new parent of the current branch.
+When given a branch and a parent, sets the parent of that branch
+without prompting. This is useful in scripts.
+
+Without flags, only updates the parent branch in the Git Town configuration.
+The next "git sync" then merges the new parent into the branch.
+
+With "--rebase", also moves the commits that the branch added
+on top of its old parent onto the new parent
+and force-pushes the branch if it has a tracking branch.
+Add "--stack" to move all descendants of the branch along with it.`
+
 func setParentCommand() *cobra.Command {
 	addDebugFlag, readDebugFlag := flags.Debug()
+	addRebaseFlag, readRebaseFlag := flags.Bool("rebase", "", "Move the commits of the branch onto the new parent")
+	addStackFlag, readStackFlag := flags.Bool("stack", "", "Also move the descendants of the branch (requires --rebase)")
 	cmd := cobra.Command{
-		Use:     "set-parent",
+		Use:     "set-parent [<branch> <parent>]",
 		GroupID: "lineage",
-		Args:    cobra.NoArgs,
+		Args:    cobra.MaximumNArgs(2),
 		Short:   setParentDesc,
-		Long:    long(setParentDesc),
+		Long:    long(setParentDesc, setParentHelp),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return setParent(readDebugFlag(cmd))
+			return setParent(args, readRebaseFlag(cmd), readStackFlag(cmd), readDebugFlag(cmd))
 		},
 	}
 	addDebugFlag(&cmd)
+	addRebaseFlag(&cmd)
+	addStackFlag(&cmd)
 	return &cmd
 }
 
-func setParent(debug bool) error {
+func setParent(args []string, rebase, stack bool, debug flags.DebugArgs) error {
+	if len(args) == 1 {
+		return errors.New("please provide both the branch and its new parent")
+	}
+	if stack && !rebase {
+		return errors.New("the --stack flag requires the --rebase flag")
+	}
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
@@ -43,23 +71,191 @@ func setParent(debug bool) error {
 	if err != nil {
 		return err
 	}
-	if !run.Config.IsFeatureBranch(currentBranch) {
+	branch := currentBranch
+	newParent := ""
+	if len(args) == 2 {
+		branch = args[0]
+		newParent = args[1]
+	}
+	if !run.Config.IsFeatureBranch(branch) {
 		return errors.New("only feature branches can have parent branches")
 	}
-	existingParent := run.Config.ParentBranch(currentBranch)
+	if rebase {
+		config, err := determineSetParentConfig(branch, newParent, currentBranch, stack, &run)
+		if err != nil {
+			return err
+		}
+		stepList, err := setParentStepList(config, &run)
+		if err != nil {
+			return err
+		}
+		runState := runstate.New("set-parent", stepList)
+		return runstate.Execute(runState, &run, nil)
+	}
+	if newParent != "" {
+		err = validateNewParent(branch, newParent, &run)
+		if err != nil {
+			return err
+		}
+		stepList := runstate.StepList{}
+		stepList.Append(&steps.SetParentStep{Branch: branch, ParentBranch: newParent})
+		runState := runstate.New("set-parent", stepList)
+		return runstate.Execute(runState, &run, nil)
+	}
+	existingParent := run.Config.ParentBranch(branch)
 	if existingParent != "" {
 		// TODO: delete the old parent only when the user has entered a new parent
-		err = run.Config.RemoveParent(currentBranch)
+		err = run.Config.RemoveParent(branch)
 		if err != nil {
 			return err
 		}
 	} else {
 		existingParent = run.Config.MainBranch()
 	}
-	err = validate.KnowsBranchAncestry(currentBranch, existingParent, &run.Backend)
+	err = validate.KnowsBranchAncestry(branch, existingParent, &run.Backend)
 	if err != nil {
 		return err
 	}
 	run.Stats.PrintAnalysis()
 	return nil
 }
+
+// validateNewParent verifies that the given branch can have the given new parent.
+func validateNewParent(branch, newParent string, run *git.ProdRunner) error {
+	if newParent == branch {
+		return fmt.Errorf("branch %q cannot be its own parent", branch)
+	}
+	hasBranch, err := run.Backend.HasLocalOrOriginBranch(branch, run.Config.MainBranch())
+	if err != nil {
+		return err
+	}
+	if !hasBranch {
+		return fmt.Errorf("there is no branch named %q", branch)
+	}
+	hasParent, err := run.Backend.HasLocalOrOriginBranch(newParent, run.Config.MainBranch())
+	if err != nil {
+		return err
+	}
+	if !hasParent {
+		return fmt.Errorf("there is no branch named %q", newParent)
+	}
+	if run.Config.IsAncestorBranch(newParent, branch) {
+		return fmt.Errorf("cannot make %q the parent of %q because %q is a descendant of %q", newParent, branch, newParent, branch)
+	}
+	return nil
+}
+
+type setParentConfig struct {
+	branch         string
+	branchesToMove []movedBranch
+	hasOrigin      bool
+	initialBranch  string
+	isOffline      bool
+	mainBranch     string
+	newParent      string
+}
+
+// movedBranch describes a branch whose commits get moved onto a new base.
+type movedBranch struct {
+	name              string
+	base              string // the commit after which the commits of the branch start
+	hasTrackingBranch bool
+	onto              string // the branch to move the commits onto
+}
+
+func determineSetParentConfig(branch, newParent, initialBranch string, stack bool, run *git.ProdRunner) (*setParentConfig, error) {
+	mainBranch := run.Config.MainBranch()
+	oldParent := run.Config.ParentBranch(branch)
+	if oldParent == "" {
+		oldParent = mainBranch
+	}
+	var err error
+	if newParent == "" {
+		newParent, err = validate.EnterParent(branch, oldParent, &run.Backend)
+		if err != nil {
+			return nil, err
+		}
+		if newParent == validate.PerennialBranchOption {
+			return nil, fmt.Errorf("cannot move the commits of %q because perennial branches have no parent", branch)
+		}
+	}
+	err = validateNewParent(branch, newParent, run)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.KnowsBranchAncestry(newParent, mainBranch, &run.Backend)
+	if err != nil {
+		return nil, err
+	}
+	hasOrigin, err := run.Backend.HasOrigin()
+	if err != nil {
+		return nil, err
+	}
+	isOffline, err := run.Config.IsOffline()
+	if err != nil {
+		return nil, err
+	}
+	branchesToMove := []movedBranch{}
+	moved, err := determineMovedBranch(branch, oldParent, newParent, run)
+	if err != nil {
+		return nil, err
+	}
+	branchesToMove = append(branchesToMove, *moved)
+	if stack {
+		for _, descendant := range run.Config.DescendantBranches(branch) {
+			parent := run.Config.ParentBranch(descendant)
+			moved, err := determineMovedBranch(descendant, parent, parent, run)
+			if err != nil {
+				return nil, err
+			}
+			branchesToMove = append(branchesToMove, *moved)
+		}
+	}
+	return &setParentConfig{
+		branch:         branch,
+		branchesToMove: branchesToMove,
+		hasOrigin:      hasOrigin,
+		initialBranch:  initialBranch,
+		isOffline:      isOffline,
+		mainBranch:     mainBranch,
+		newParent:      newParent,
+	}, nil
+}
+
+// determineMovedBranch provides the information needed to move the commits
+// that the given branch added on top of the given old parent onto the given new parent.
+func determineMovedBranch(branch, oldParent, newParent string, run *git.ProdRunner) (*movedBranch, error) {
+	base, err := run.Backend.MergeBase(branch, oldParent)
+	if err != nil {
+		return nil, err
+	}
+	hasTrackingBranch, err := run.Backend.HasTrackingBranch(branch)
+	if err != nil {
+		return nil, err
+	}
+	return &movedBranch{
+		name:              branch,
+		base:              base,
+		hasTrackingBranch: hasTrackingBranch,
+		onto:              newParent,
+	}, nil
+}
+
+func setParentStepList(config *setParentConfig, run *git.ProdRunner) (runstate.StepList, error) {
+	list := runstate.StepListBuilder{}
+	list.Add(&steps.SetParentStep{Branch: config.branch, ParentBranch: config.newParent})
+	for _, branch := range config.branchesToMove {
+		list.Add(&steps.CheckoutStep{Branch: branch.name})
+		list.Add(&steps.RebaseOntoStep{Base: branch.base, Onto: branch.onto})
+		if branch.hasTrackingBranch && config.hasOrigin {
+			if config.isOffline {
+				list.Add(&steps.QueueOfflineOperationStep{Operation: offlinequeue.Operation{Type: offlinequeue.PushBranch, Branch: branch.name, ForceWithLease: true}})
+			} else {
+				list.Add(&steps.PushBranchStep{Branch: branch.name, ForceWithLease: true, Undoable: true})
+			}
+		}
+	}
+	list.Add(&steps.CheckoutStep{Branch: config.initialBranch})
+	list.Wrap(runstate.WrapOptions{RunInGitRoot: true, StashOpenChanges: true}, &run.Backend, config.mainBranch)
+	return list.Result()
+}
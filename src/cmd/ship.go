@@ -3,13 +3,17 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/git-town/git-town/v8/src/cli"
 	"github.com/git-town/git-town/v8/src/config"
 	"github.com/git-town/git-town/v8/src/execute"
 	"github.com/git-town/git-town/v8/src/flags"
 	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hooks"
 	"github.com/git-town/git-town/v8/src/hosting"
+	"github.com/git-town/git-town/v8/src/offlinequeue"
+	"github.com/git-town/git-town/v8/src/preflight"
 	"github.com/git-town/git-town/v8/src/runstate"
 	"github.com/git-town/git-town/v8/src/steps"
 	"github.com/git-town/git-town/v8/src/validate"
@@ -30,6 +34,9 @@ into the main branch, resulting in linear history on the main branch.
 - pushes the main branch to the origin repository
 - deletes <branch_name> from the local and origin repositories
 
+Before changing anything, warns about the merge conflicts
+that shipping would cause (requires Git 2.38 or later).
+
 Ships direct children of the main branch.
 To ship a nested child branch, ship or kill all ancestor branches first.
 
@@ -39,6 +46,11 @@ If you use GitHub, this command can squash merge pull requests via the GitHub AP
 Now anytime you ship a branch with a pull request on GitHub, it will squash merge via the GitHub API.
 It will also update the base branch for any pull requests against that branch.
 
+Before shipping via the API, Git Town verifies that the proposal
+isn't a draft, that its checks passed, and that it has the required approvals.
+The "--wait-for-checks <duration>" flag waits up to the given duration
+for running checks to finish, "--force" ships the branch anyway.
+
 If your origin server deletes shipped branches, for example
 GitHub's feature to automatically delete head branches,
 run "git config %s false"
@@ -47,6 +59,8 @@ and Git Town will leave it up to your origin server to delete the remote branch.
 func shipCmd() *cobra.Command {
 	addDebugFlag, readDebugFlag := flags.Debug()
 	addMessageFlag, readMessageFlag := flags.String("message", "m", "", "Specify the commit message for the squash commit")
+	addForceFlag, readForceFlag := flags.Bool("force", "f", "Ship even if the proposal is a draft, its checks failed, or it lacks approvals")
+	addWaitFlag, readWaitFlag := flags.Duration("wait-for-checks", "", "Wait up to the given duration for running checks of the proposal to finish")
 	cmd := cobra.Command{
 		Use:     "ship",
 		GroupID: "basic",
@@ -54,15 +68,34 @@ func shipCmd() *cobra.Command {
 		Short:   shipDesc,
 		Long:    long(shipDesc, fmt.Sprintf(shipHelp, config.GithubTokenKey, config.ShipDeleteRemoteBranchKey)),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return ship(args, readMessageFlag(cmd), readDebugFlag(cmd))
+			return ship(args, shipArgs{
+				force:         readForceFlag(cmd),
+				message:       readMessageFlag(cmd),
+				waitForChecks: readWaitFlag(cmd),
+			}, readDebugFlag(cmd))
 		},
 	}
 	addDebugFlag(&cmd)
+	addForceFlag(&cmd)
 	addMessageFlag(&cmd)
+	addWaitFlag(&cmd)
 	return &cmd
 }
 
-func ship(args []string, message string, debug bool) error {
+// checkPollInterval is how often Git Town asks the code hosting service about the status of running checks.
+const checkPollInterval = 15 * time.Second
+
+// shipArgs contains the command-line arguments of the ship command.
+type shipArgs struct {
+	// ship even if the proposal isn't ready
+	force bool
+	// the commit message for the squash commit
+	message string
+	// how long to wait for running checks, zero means don't wait
+	waitForChecks time.Duration
+}
+
+func ship(args []string, shipArgs shipArgs, debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
@@ -74,23 +107,35 @@ func ship(args []string, message string, debug bool) error {
 	if err != nil || exit {
 		return err
 	}
-	connector, err := hosting.NewConnector(run.Config.GitTown, &run.Backend, cli.PrintConnectorAction)
+	connector, err := hosting.NewConnector(&run.Config, &run.Backend, cli.PrintConnectorAction, run.Stats)
 	if err != nil {
 		return err
 	}
-	config, err := determineShipConfig(args, connector, &run)
+	config, err := determineShipConfig(args, shipArgs, connector, &run)
 	if err != nil {
 		return err
 	}
-	stepList, err := shipStepList(config, message, &run)
+	err = hooks.Run(hooks.PreShip, config.hookContext(), &run)
 	if err != nil {
 		return err
 	}
+	stepList, err := shipStepList(config, shipArgs.message, &run)
+	if err != nil {
+		return err
+	}
+	conflicts, err := preflight.PredictConflicts(stepList, config.initialBranch, &run.Backend)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		cli.PrintWarning(preflight.Report(conflicts))
+	}
 	runState := runstate.New("ship", stepList)
 	return runstate.Execute(runState, &run, connector)
 }
 
 type shipConfig struct {
+	branchesInOtherWorktrees map[string]string // the branches that are checked out in other worktrees, mapped to the path of that worktree
 	branchToShip             string
 	targetBranch             string
 	canShipViaAPI            bool
@@ -107,7 +152,16 @@ type shipConfig struct {
 	proposalsOfChildBranches []hosting.Proposal
 }
 
-func determineShipConfig(args []string, connector hosting.Connector, run *git.ProdRunner) (*shipConfig, error) {
+// hookContext provides the information that the ship hooks receive.
+func (sc *shipConfig) hookContext() hooks.Context {
+	proposalNumber := 0
+	if sc.proposal != nil {
+		proposalNumber = sc.proposal.Number
+	}
+	return hooks.Context{Branch: sc.branchToShip, Parent: sc.targetBranch, Proposal: proposalNumber}
+}
+
+func determineShipConfig(args []string, shipArgs shipArgs, connector hosting.Connector, run *git.ProdRunner) (*shipConfig, error) {
 	hasOrigin, err := run.Backend.HasOrigin()
 	if err != nil {
 		return nil, err
@@ -159,6 +213,12 @@ func determineShipConfig(args []string, connector hosting.Connector, run *git.Pr
 	if !run.Config.IsFeatureBranch(branchToShip) {
 		return nil, fmt.Errorf("the branch %q is not a feature branch. Only feature branches can be shipped", branchToShip)
 	}
+	if run.Config.IsObservedBranch(branchToShip) {
+		return nil, fmt.Errorf("the branch %q is an observed branch. Only your own feature branches can be shipped", branchToShip)
+	}
+	if run.Config.IsContributionBranch(branchToShip) {
+		return nil, fmt.Errorf("the branch %q is a contribution branch. Only your own feature branches can be shipped", branchToShip)
+	}
 	err = validate.KnowsBranchAncestry(branchToShip, mainBranch, &run.Backend)
 	if err != nil {
 		return nil, err
@@ -172,6 +232,16 @@ func determineShipConfig(args []string, connector hosting.Connector, run *git.Pr
 		return nil, err
 	}
 	targetBranch := run.Config.ParentBranch(branchToShip)
+	branchesInOtherWorktrees, err := run.Backend.BranchesInOtherWorktrees()
+	if err != nil {
+		return nil, err
+	}
+	for _, branch := range []string{targetBranch, branchToShip} {
+		if worktree, inOtherWorktree := branchesInOtherWorktrees[branch]; inOtherWorktree {
+			// shipping checks out both branches, which Git cannot do for branches checked out in another worktree
+			return nil, fmt.Errorf("cannot ship %q because branch %q is checked out in the worktree at %s.\nPlease ship from that worktree or check out another branch there", branchToShip, branch, worktree)
+		}
+	}
 	canShipViaAPI := false
 	proposalMessage := ""
 	var proposal *hosting.Proposal
@@ -184,6 +254,10 @@ func determineShipConfig(args []string, connector hosting.Connector, run *git.Pr
 				return nil, err
 			}
 			if proposal != nil {
+				err = ensureProposalIsReady(*proposal, branchToShip, shipArgs, connector)
+				if err != nil {
+					return nil, err
+				}
 				canShipViaAPI = true
 				proposalMessage = connector.DefaultProposalMessage(*proposal)
 			}
@@ -199,6 +273,7 @@ func determineShipConfig(args []string, connector hosting.Connector, run *git.Pr
 		}
 	}
 	return &shipConfig{
+		branchesInOtherWorktrees: branchesInOtherWorktrees,
 		targetBranch:             targetBranch,
 		branchToShip:             branchToShip,
 		canShipViaAPI:            canShipViaAPI,
@@ -216,6 +291,42 @@ func determineShipConfig(args []string, connector hosting.Connector, run *git.Pr
 	}, nil
 }
 
+// ensureProposalIsReady verifies that the given proposal for the given branch is ready to ship.
+// It waits for running checks if the user asked for it.
+func ensureProposalIsReady(proposal hosting.Proposal, branch string, shipArgs shipArgs, connector hosting.Connector) error {
+	status, err := connector.ProposalStatus(proposal)
+	deadline := time.Now().Add(shipArgs.waitForChecks)
+	for err == nil && status.Checks == hosting.CheckStatusPending && time.Now().Before(deadline) {
+		cli.Printf("waiting for the checks of proposal #%d to finish ...\n", proposal.Number)
+		time.Sleep(checkPollInterval)
+		status, err = connector.ProposalStatus(proposal)
+	}
+	if err != nil {
+		if shipArgs.force {
+			cli.PrintWarning(fmt.Sprintf("cannot determine whether proposal #%d is ready to ship: %v", proposal.Number, err))
+			return nil
+		}
+		return fmt.Errorf("cannot determine whether proposal #%d is ready to ship: %w\n\nrun \"git town ship --force\" to ship anyway", proposal.Number, err)
+	}
+	blockers := hosting.ShipBlockers(proposal, status)
+	if len(blockers) == 0 {
+		return nil
+	}
+	if shipArgs.force {
+		cli.PrintWarning(fmt.Sprintf("shipping proposal #%d although %s", proposal.Number, strings.Join(blockers, ", ")))
+		return nil
+	}
+	reasons := ""
+	for _, blocker := range blockers {
+		reasons += "\n- " + blocker
+	}
+	hint := `run "git town ship --force" to ship anyway`
+	if status.Checks == hosting.CheckStatusPending {
+		hint = `run "git town ship --wait-for-checks 10m" to wait up to 10 minutes for the checks, or "git town ship --force" to ship anyway`
+	}
+	return fmt.Errorf("cannot ship branch %q because its proposal #%d isn't ready:%s\n\n%s", branch, proposal.Number, reasons, hint)
+}
+
 func ensureParentBranchIsMainOrPerennialBranch(branch string, run *git.ProdRunner) error {
 	parentBranch := run.Config.ParentBranch(branch)
 	if !run.Config.IsMainBranch(parentBranch) && !run.Config.IsPerennialBranch(parentBranch) {
@@ -230,8 +341,8 @@ please ship %q first`, strings.Join(ancestorsWithoutMainOrPerennial, ", "), olde
 
 func shipStepList(config *shipConfig, commitMessage string, run *git.ProdRunner) (runstate.StepList, error) {
 	list := runstate.StepListBuilder{}
-	updateBranchSteps(&list, config.targetBranch, true, run)  // sync the parent branch
-	updateBranchSteps(&list, config.branchToShip, false, run) // sync the branch to ship locally only
+	updateBranchSteps(&list, config.targetBranch, true, false, config.branchesInOtherWorktrees, run)  // sync the parent branch
+	updateBranchSteps(&list, config.branchToShip, false, false, config.branchesInOtherWorktrees, run) // sync the branch to ship locally only
 	list.Add(&steps.EnsureHasShippableChangesStep{Branch: config.branchToShip, Parent: config.mainBranch})
 	list.Add(&steps.CheckoutStep{Branch: config.targetBranch})
 	if config.canShipViaAPI {
@@ -258,6 +369,9 @@ func shipStepList(config *shipConfig, commitMessage string, run *git.ProdRunner)
 	if config.hasOrigin && !config.isOffline {
 		list.Add(&steps.PushBranchStep{Branch: config.targetBranch, Undoable: true})
 	}
+	if config.hasOrigin && config.isOffline {
+		queueOfflineShipSteps(&list, config, run)
+	}
 	// NOTE: when shipping via API, we can always delete the remote branch because:
 	// - we know we have a tracking branch (otherwise there would be no PR to ship via API)
 	// - we have updated the PRs of all child branches (because we have API access)
@@ -276,5 +390,23 @@ func shipStepList(config *shipConfig, commitMessage string, run *git.ProdRunner)
 		list.Add(&steps.CheckoutStep{Branch: config.initialBranch})
 	}
 	list.Wrap(runstate.WrapOptions{RunInGitRoot: true, StashOpenChanges: !config.isShippingInitialBranch}, &run.Backend, config.mainBranch)
+	list.AddHook(hooks.PostShip, config.hookContext(), run)
 	return list.Result()
 }
+
+// queueOfflineShipSteps provides the steps to record the network operations
+// that shipping the given branch while offline skips.
+func queueOfflineShipSteps(list *runstate.StepListBuilder, config *shipConfig, run *git.ProdRunner) {
+	list.Add(&steps.QueueOfflineOperationStep{Operation: offlinequeue.Operation{Type: offlinequeue.PushBranch, Branch: config.targetBranch}})
+	// the proposals of child branches need to target the branch that received the shipped branch
+	for _, child := range config.childBranches {
+		if list.Bool(run.Backend.HasTrackingBranch(child)) {
+			list.Add(&steps.QueueOfflineOperationStep{Operation: offlinequeue.Operation{
+				Type:      offlinequeue.UpdateProposalTarget,
+				Branch:    child,
+				OldTarget: config.branchToShip,
+				NewTarget: config.targetBranch,
+			}})
+		}
+	}
+}
@@ -27,7 +27,7 @@ func skipCmd() *cobra.Command {
 	return &cmd
 }
 
-func skip(debug bool) error {
+func skip(debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
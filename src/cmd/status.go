@@ -9,6 +9,7 @@ import (
 	"github.com/git-town/git-town/v8/src/execute"
 	"github.com/git-town/git-town/v8/src/flags"
 	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/offlinequeue"
 	"github.com/git-town/git-town/v8/src/runstate"
 	"github.com/spf13/cobra"
 )
@@ -32,7 +33,7 @@ func statusCommand() *cobra.Command {
 	return &cmd
 }
 
-func status(debug bool) error {
+func status(debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
@@ -53,8 +54,9 @@ func status(debug bool) error {
 }
 
 type displayStatusConfig struct {
-	filepath string             // filepath of the runstate file
-	state    *runstate.RunState // content of the runstate file
+	filepath     string             // filepath of the runstate file
+	offlineQueue offlinequeue.Queue // the network operations that Git Town skipped while offline
+	state        *runstate.RunState // content of the runstate file
 }
 
 func loadDisplayStatusConfig(run *git.ProdRunner) (*displayStatusConfig, error) {
@@ -68,22 +70,42 @@ func loadDisplayStatusConfig(run *git.ProdRunner) (*displayStatusConfig, error)
 			return nil, fmt.Errorf("the runstate file contains invalid content: %w", err)
 		}
 	}
+	offlineQueuePath, err := offlinequeue.FilePath(&run.Backend)
+	if err != nil {
+		return nil, fmt.Errorf("cannot determine the offline queue file path: %w", err)
+	}
+	offlineQueue, err := offlinequeue.Load(offlineQueuePath)
+	if err != nil {
+		return nil, err
+	}
 	return &displayStatusConfig{
-		filepath: filepath,
-		state:    state,
+		filepath:     filepath,
+		offlineQueue: offlineQueue,
+		state:        state,
 	}, nil
 }
 
 func displayStatus(config displayStatusConfig) {
-	if config.state == nil {
+	switch {
+	case config.state == nil:
 		fmt.Println("No status file found for this repository.")
-		return
-	}
-	if config.state.IsUnfinished() {
+	case config.state.IsUnfinished():
 		displayUnfinishedStatus(config)
-	} else {
+	default:
 		displayFinishedStatus(config)
 	}
+	if !config.offlineQueue.IsEmpty() {
+		displayOfflineQueue(config.offlineQueue)
+	}
+}
+
+func displayOfflineQueue(queue offlinequeue.Queue) {
+	fmt.Println()
+	fmt.Println("These network operations are waiting for Git Town to be online:")
+	for _, operation := range queue.Operations {
+		fmt.Printf("- %s\n", operation)
+	}
+	fmt.Println("Run \"git town sync\" when you are online to perform them.")
 }
 
 func displayUnfinishedStatus(config displayStatusConfig) {
@@ -26,7 +26,7 @@ func resetRunstateCommand() *cobra.Command {
 	return &cmd
 }
 
-func statusReset(debug bool) error {
+func statusReset(debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
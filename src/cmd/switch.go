@@ -2,33 +2,64 @@ package cmd
 
 import (
 	"strings"
+	"time"
 
 	"github.com/git-town/git-town/v8/src/dialog"
 	"github.com/git-town/git-town/v8/src/execute"
 	"github.com/git-town/git-town/v8/src/flags"
 	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
+	"github.com/git-town/git-town/v8/src/stringslice"
 	"github.com/spf13/cobra"
 )
 
 const switchDesc = "Displays the local branches visually and allows switching between them"
 
+const switchHelp = `
+Type "/" followed by parts of a branch name to filter the displayed branches.
+
+With "--all", also displays branches that exist only at origin.
+Selecting such a branch creates a local branch that tracks it.
+
+With "--merge", carries uncommitted changes over to the new branch
+by performing a three-way merge like "git checkout --merge".`
+
 func switchCmd() *cobra.Command {
+	addAllFlag, readAllFlag := flags.Bool("all", "a", "Also display branches that exist only at origin")
 	addDebugFlag, readDebugFlag := flags.Debug()
+	addHideUnknownFlag, readHideUnknownFlag := flags.Bool("hide-unknown", "", "Don't display feature branches without a known parent")
+	addMergeFlag, readMergeFlag := flags.Bool("merge", "m", "Carry uncommitted changes over to the new branch")
 	cmd := cobra.Command{
 		Use:     "switch",
 		GroupID: "basic",
 		Args:    cobra.NoArgs,
 		Short:   switchDesc,
-		Long:    long(switchDesc),
+		Long:    long(switchDesc, switchHelp),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runSwitch(readDebugFlag(cmd))
+			return runSwitch(switchArgs{
+				all:         readAllFlag(cmd),
+				hideUnknown: readHideUnknownFlag(cmd),
+				merge:       readMergeFlag(cmd),
+			}, readDebugFlag(cmd))
 		},
 	}
+	addAllFlag(&cmd)
 	addDebugFlag(&cmd)
+	addHideUnknownFlag(&cmd)
+	addMergeFlag(&cmd)
 	return &cmd
 }
 
-func runSwitch(debug bool) error {
+type switchArgs struct {
+	// also display branches that exist only at origin
+	all bool
+	// don't display feature branches without a known parent
+	hideUnknown bool
+	// carry uncommitted changes over to the new branch
+	merge bool
+}
+
+func runSwitch(args switchArgs, debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
@@ -44,47 +75,206 @@ func runSwitch(debug bool) error {
 	if err != nil {
 		return err
 	}
-	newBranch, err := queryBranch(currentBranch, &run)
+	newBranch, err := queryBranch(currentBranch, args, &run)
 	if err != nil {
 		return err
 	}
-	if newBranch != nil && *newBranch != currentBranch {
-		err = run.Backend.CheckoutBranch(*newBranch)
-		if err != nil {
-			return err
-		}
+	if newBranch == nil || *newBranch == currentBranch {
+		return nil
 	}
-	return nil
+	hasLocalBranch, err := run.Backend.HasLocalBranch(*newBranch)
+	if err != nil {
+		return err
+	}
+	return run.Backend.SwitchBranch(git.SwitchArgs{
+		Branch: *newBranch,
+		Merge:  args.merge,
+		Track:  !hasLocalBranch,
+	})
 }
 
 // queryBranch lets the user select a new branch via a visual dialog.
 // Returns the selected branch or nil if the user aborted.
-func queryBranch(currentBranch string, run *git.ProdRunner) (selection *string, err error) { //nolint:nonamedreturns
-	entries, err := createEntries(run)
+func queryBranch(currentBranch string, args switchArgs, run *git.ProdRunner) (selection *string, err error) { //nolint:nonamedreturns
+	entries, err := createEntries(args, run)
 	if err != nil {
 		return nil, err
 	}
+	entries = annotateProposals(entries, run)
 	return dialog.ModalSelect(entries, currentBranch)
 }
 
+// proposalLookupTimeout is how long the branch dialog waits for proposals that aren't cached.
+const proposalLookupTimeout = 2 * time.Second
+
+// annotateProposals adds the proposals for the branches in the given entries to them.
+// It does nothing when offline or when the repository isn't hosted on a supported code hosting service.
+// If the proposal cache doesn't know all branches, it looks up all open proposals with a single API call.
+// If that takes too long, it shows the cached proposals and updates the cache in the background.
+func annotateProposals(entries dialog.ModalEntries, run *git.ProdRunner) dialog.ModalEntries {
+	isOffline, err := run.Config.IsOffline()
+	if err != nil || isOffline {
+		return entries
+	}
+	originURL := run.Config.OriginURLString()
+	if originURL == "" {
+		return entries
+	}
+	cachePath, err := hosting.ProposalCachePath(originURL)
+	if err != nil {
+		return entries
+	}
+	cache := hosting.LoadProposalCache(cachePath, hosting.ProposalCacheMaxAge)
+	parents := map[string]string{}
+	hasUncachedBranches := false
+	for _, entry := range entries {
+		hasTrackingBranch, err := run.Backend.HasTrackingBranch(entry.Value)
+		if err != nil || !hasTrackingBranch {
+			continue
+		}
+		parent := run.Config.ParentBranch(entry.Value)
+		if parent == "" {
+			continue
+		}
+		parents[entry.Value] = parent
+		if _, isCached := cache.Lookup(entry.Value, parent); !isCached {
+			hasUncachedBranches = true
+		}
+	}
+	if hasUncachedBranches {
+		updated := make(chan struct{})
+		go func() {
+			defer close(updated)
+			if updateProposalCache(cache, parents, run) == nil {
+				_ = cache.Save()
+			}
+		}()
+		select {
+		case <-updated:
+		case <-time.After(proposalLookupTimeout):
+		}
+	}
+	return entries.Annotate(func(branch string) string {
+		parent := parents[branch]
+		if parent == "" {
+			return ""
+		}
+		proposal, _ := cache.Lookup(branch, parent)
+		if proposal == nil {
+			return ""
+		}
+		return "(" + hosting.ProposalSummary(*proposal) + ")"
+	})
+}
+
+// updateProposalCache stores the open proposals of the given branches into the given parent branches in the given cache.
+func updateProposalCache(cache *hosting.ProposalCache, parents map[string]string, run *git.ProdRunner) error {
+	connector, err := hosting.NewConnector(&run.Config, &run.Backend, nil, run.Stats)
+	if err != nil {
+		return err
+	}
+	if connector == nil {
+		return hosting.UnsupportedServiceError()
+	}
+	proposals, err := connector.OpenProposals()
+	if err != nil {
+		return err
+	}
+	for branch, parent := range parents {
+		proposal, hasProposal := proposals[branch]
+		if hasProposal && proposal.Target == parent {
+			cache.Store(branch, parent, &proposal)
+		} else {
+			cache.Store(branch, parent, nil)
+		}
+	}
+	return nil
+}
+
 // createEntries provides all the entries for the branch dialog.
-func createEntries(run *git.ProdRunner) (dialog.ModalEntries, error) {
+func createEntries(args switchArgs, run *git.ProdRunner) (dialog.ModalEntries, error) {
 	entries := dialog.ModalEntries{}
 	var err error
 	for _, root := range run.Config.BranchAncestryRoots() {
+		if args.hideUnknown && hasUnknownParent(root, run) {
+			continue
+		}
 		entries, err = addEntryAndChildren(entries, root, 0, run)
 		if err != nil {
 			return nil, err
 		}
 	}
+	// local branches outside of the branch ancestry, for example the main branch without children,
+	// observed and contribution branches, or branches created without Git Town
+	localBranches, err := run.Backend.LocalBranchesMainFirst(run.Config.MainBranch())
+	if err != nil {
+		return nil, err
+	}
+	for _, branch := range localBranches {
+		if entries.IndexOfValue(branch) != nil || (args.hideUnknown && hasUnknownParent(branch, run)) {
+			continue
+		}
+		entries, err = addEntryAndChildren(entries, branch, 0, run)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if args.all {
+		entries, err = addRemoteEntries(entries, localBranches, run)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// addRemoteEntries adds the branches that exist only at origin to the given entries collection.
+func addRemoteEntries(entries dialog.ModalEntries, localBranches []string, run *git.ProdRunner) (dialog.ModalEntries, error) {
+	allBranches, err := run.Backend.LocalAndOriginBranches(run.Config.MainBranch())
+	if err != nil {
+		return nil, err
+	}
+	for _, branch := range allBranches {
+		if branch == "HEAD" || stringslice.Contains(localBranches, branch) || entries.IndexOfValue(branch) != nil {
+			continue
+		}
+		entries = append(entries, dialog.ModalEntry{
+			Dimmed: false,
+			Text:   branch + "  (remote)",
+			Value:  branch,
+		})
+	}
 	return entries, nil
 }
 
+// hasUnknownParent indicates whether the given branch is a feature branch for which Git Town doesn't know the parent.
+// Observed and contribution branches don't need a parent.
+func hasUnknownParent(branch string, run *git.ProdRunner) bool {
+	return run.Config.IsFeatureBranch(branch) &&
+		!run.Config.IsObservedBranch(branch) &&
+		!run.Config.IsContributionBranch(branch) &&
+		!run.Config.HasParentBranch(branch)
+}
+
 // addEntryAndChildren adds the given branch and all its child branches to the given entries collection.
 func addEntryAndChildren(entries dialog.ModalEntries, branch string, indent int, run *git.ProdRunner) (dialog.ModalEntries, error) {
+	text := strings.Repeat("  ", indent) + branch
+	switch {
+	case run.Config.IsObservedBranch(branch):
+		text += "  (observed)"
+	case run.Config.IsContributionBranch(branch):
+		text += "  (contribution)"
+	case run.Config.IsPrototypeBranch(branch):
+		text += "  (prototype)"
+	}
+	isParked := run.Config.IsParkedBranch(branch)
+	if isParked {
+		text += "  (parked)"
+	}
 	entries = append(entries, dialog.ModalEntry{
-		Text:  strings.Repeat("  ", indent) + branch,
-		Value: branch,
+		Dimmed: isParked,
+		Text:   text,
+		Value:  branch,
 	})
 	var err error
 	for _, child := range run.Config.ChildBranches(branch) {
@@ -3,12 +3,19 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/git-town/git-town/v8/src/cli"
 	"github.com/git-town/git-town/v8/src/config"
 	"github.com/git-town/git-town/v8/src/execute"
 	"github.com/git-town/git-town/v8/src/flags"
 	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hooks"
+	"github.com/git-town/git-town/v8/src/hosting"
+	"github.com/git-town/git-town/v8/src/offlinequeue"
+	"github.com/git-town/git-town/v8/src/parallel"
+	"github.com/git-town/git-town/v8/src/preflight"
 	"github.com/git-town/git-town/v8/src/runstate"
 	"github.com/git-town/git-town/v8/src/steps"
+	"github.com/git-town/git-town/v8/src/stringslice"
 	"github.com/git-town/git-town/v8/src/validate"
 	"github.com/spf13/cobra"
 )
@@ -28,6 +35,17 @@ When run on the main branch or a perennial branch
 - pulls and pushes updates for the current branch
 - pushes tags
 
+With "--all", syncs all local branches except parked branches.
+
+Before changing anything, predicts the merge conflicts
+that syncing would cause (requires Git 2.38 or later).
+With "--skip-conflicts", doesn't sync the branches that would conflict.
+
+In offline mode, remembers the branches that need pushing.
+The next sync while online pushes them
+and performs the other network operations
+that Git Town skipped while offline.
+
 If the repository contains an "upstream" remote,
 syncs the main branch with its upstream counterpart.
 You can disable this by running "git config %s false".`
@@ -36,6 +54,7 @@ func syncCmd() *cobra.Command {
 	addDebugFlag, readDebugFlag := flags.Debug()
 	addDryRunFlag, readDryRunFlag := flags.DryRun()
 	addAllFlag, readAllFlag := flags.Bool("all", "a", "Sync all local branches")
+	addSkipConflictsFlag, readSkipConflictsFlag := flags.Bool("skip-conflicts", "", "Don't sync branches that would have merge conflicts")
 	cmd := cobra.Command{
 		Use:     "sync",
 		GroupID: "basic",
@@ -43,16 +62,17 @@ func syncCmd() *cobra.Command {
 		Short:   syncDesc,
 		Long:    long(syncDesc, fmt.Sprintf(syncHelp, config.SyncUpstreamKey)),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return sync(readAllFlag(cmd), readDryRunFlag(cmd), readDebugFlag(cmd))
+			return sync(readAllFlag(cmd), readSkipConflictsFlag(cmd), readDryRunFlag(cmd), readDebugFlag(cmd))
 		},
 	}
 	addAllFlag(&cmd)
 	addDebugFlag(&cmd)
 	addDryRunFlag(&cmd)
+	addSkipConflictsFlag(&cmd)
 	return &cmd
 }
 
-func sync(all, dryRun, debug bool) error {
+func sync(all, skipConflicts, dryRun bool, debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                dryRun,
@@ -68,21 +88,41 @@ func sync(all, dryRun, debug bool) error {
 	if err != nil {
 		return err
 	}
-	stepList, err := syncBranchesSteps(config, &run)
+	err = hooks.Run(hooks.PreSync, config.hookContext, &run)
+	if err != nil {
+		return err
+	}
+	printSkippedWorktreeBranches(config.branchesToSync, config.branchesInOtherWorktrees)
+	stepList, err := syncStepsWithoutConflicts(config, skipConflicts, &run)
 	if err != nil {
 		return err
 	}
 	runState := runstate.New("sync", stepList)
-	return runstate.Execute(runState, &run, nil)
+	return runstate.Execute(runState, &run, config.connector)
 }
 
 type syncConfig struct {
-	branchesToSync []string
-	hasOrigin      bool
-	initialBranch  string
-	isOffline      bool
-	mainBranch     string
-	shouldPushTags bool
+	branchesInOtherWorktrees map[string]string // the branches that are checked out in other worktrees, mapped to the path of that worktree
+	branchesToSync           []string
+	connector                hosting.Connector // only set when updating proposals that Git Town couldn't update while offline
+	hasFetchedUpstream       bool              // whether determineSyncConfig has already fetched the main branch from the upstream remote
+	hasOrigin                bool
+	hookContext              hooks.Context
+	initialBranch            string
+	isOffline                bool
+	mainBranch               string
+	offlineOperations        []queuedOperation // the network operations that Git Town skipped while offline and performs now
+	shouldPushTags           bool
+	skippedBranches          []string // the branches that don't get synced because they would have merge conflicts
+}
+
+// maxParallelAPICalls is the maximum number of concurrent requests to the API of the code hosting service.
+const maxParallelAPICalls = 4
+
+// queuedOperation is a network operation that Git Town skipped while offline.
+type queuedOperation struct {
+	operation offlinequeue.Operation
+	proposal  *hosting.Proposal // for proposal updates: the proposal to update, nil if it doesn't exist anymore
 }
 
 func determineSyncConfig(allFlag bool, run *git.ProdRunner) (*syncConfig, error) {
@@ -94,12 +134,6 @@ func determineSyncConfig(allFlag bool, run *git.ProdRunner) (*syncConfig, error)
 	if err != nil {
 		return nil, err
 	}
-	if hasOrigin && !isOffline {
-		err := run.Frontend.Fetch()
-		if err != nil {
-			return nil, err
-		}
-	}
 	initialBranch, err := run.Backend.CurrentBranch()
 	if err != nil {
 		return nil, err
@@ -116,8 +150,18 @@ func determineSyncConfig(allFlag bool, run *git.ProdRunner) (*syncConfig, error)
 		if err != nil {
 			return nil, err
 		}
-		branchesToSync = branches
+		for _, branch := range branches {
+			// parked branches only get synced when the user explicitly syncs them
+			if run.Config.IsParkedBranch(branch) && branch != initialBranch {
+				continue
+			}
+			branchesToSync = append(branchesToSync, branch)
+		}
 		shouldPushTags = true
+	} else if run.Config.IsObservedBranch(initialBranch) || run.Config.IsContributionBranch(initialBranch) {
+		// these branches don't get synced with their ancestors
+		branchesToSync = []string{initialBranch}
+		shouldPushTags = false
 	} else {
 		err = validate.KnowsBranchAncestry(initialBranch, run.Config.MainBranch(), &run.Backend)
 		if err != nil {
@@ -126,32 +170,228 @@ func determineSyncConfig(allFlag bool, run *git.ProdRunner) (*syncConfig, error)
 		branchesToSync = append(run.Config.AncestorBranches(initialBranch), initialBranch)
 		shouldPushTags = !run.Config.IsFeatureBranch(initialBranch)
 	}
+	hasFetchedUpstream := false
+	offlineOperations := []queuedOperation{}
+	var connector hosting.Connector
+	if hasOrigin && !isOffline {
+		hasFetchedUpstream, err = shouldSyncUpstream(branchesToSync, mainBranch, run)
+		if err != nil {
+			return nil, err
+		}
+		if hasFetchedUpstream {
+			err = run.Frontend.FetchWithUpstream(mainBranch)
+		} else {
+			err = run.Frontend.Fetch()
+		}
+		if err != nil {
+			// in automatic offline mode, syncing continues offline if fetching fails
+			err = run.Config.SwitchToOffline("fetching updates", err)
+			if err != nil {
+				return nil, err
+			}
+			isOffline = true
+			hasFetchedUpstream = false
+		}
+	}
+	if hasOrigin && !isOffline {
+		offlineOperations, connector, err = determineQueuedOperations(run)
+		if err != nil {
+			return nil, err
+		}
+	}
+	branchesInOtherWorktrees, err := run.Backend.BranchesInOtherWorktrees()
+	if err != nil {
+		return nil, err
+	}
 	return &syncConfig{
-		branchesToSync: branchesToSync,
-		hasOrigin:      hasOrigin,
-		initialBranch:  initialBranch,
-		isOffline:      isOffline,
-		mainBranch:     mainBranch,
-		shouldPushTags: shouldPushTags,
+		branchesInOtherWorktrees: branchesInOtherWorktrees,
+		branchesToSync:           branchesToSync,
+		connector:                connector,
+		hasFetchedUpstream:       hasFetchedUpstream,
+		hasOrigin:                hasOrigin,
+		hookContext:              hooks.Context{Branch: initialBranch, Parent: run.Config.ParentBranch(initialBranch), Proposal: 0},
+		initialBranch:            initialBranch,
+		isOffline:                isOffline,
+		mainBranch:               mainBranch,
+		offlineOperations:        offlineOperations,
+		shouldPushTags:           shouldPushTags,
+		skippedBranches:          []string{},
 	}, nil
 }
 
+// determineQueuedOperations provides the network operations that Git Town skipped while offline
+// together with the connector needed to update proposals.
+// It looks up the proposals to update concurrently
+// and leaves proposal updates in the queue if it cannot look up the proposal right now.
+func determineQueuedOperations(run *git.ProdRunner) ([]queuedOperation, hosting.Connector, error) {
+	path, err := offlinequeue.FilePath(&run.Backend)
+	if err != nil {
+		return nil, nil, err
+	}
+	queue, err := offlinequeue.Load(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	var connector hosting.Connector
+	for _, operation := range queue.Operations {
+		if operation.Type == offlinequeue.UpdateProposalTarget {
+			connector, err = hosting.NewConnector(&run.Config, &run.Backend, cli.PrintConnectorAction, run.Stats)
+			if err != nil {
+				return nil, nil, err
+			}
+			break
+		}
+	}
+	// look up the proposals to update concurrently
+	proposals := make([]*hosting.Proposal, len(queue.Operations))
+	jobs := []func() error{}
+	jobOfOperation := map[int]int{}
+	for o, operation := range queue.Operations {
+		if operation.Type != offlinequeue.UpdateProposalTarget || connector == nil {
+			continue
+		}
+		o, operation := o, operation
+		jobOfOperation[o] = len(jobs)
+		jobs = append(jobs, func() error {
+			var err error
+			proposals[o], err = connector.FindProposal(operation.Branch, operation.OldTarget)
+			return err
+		})
+	}
+	progress := cli.NewProgress("Looking up proposals", len(jobs))
+	errs := parallel.Run(maxParallelAPICalls, jobs, progress.Update)
+	progress.Done()
+	result := []queuedOperation{}
+	for o, operation := range queue.Operations {
+		if operation.Type != offlinequeue.UpdateProposalTarget {
+			result = append(result, queuedOperation{operation: operation, proposal: nil})
+			continue
+		}
+		if connector == nil {
+			cli.PrintWarning(fmt.Sprintf("cannot %s because Git Town has no API access to your code hosting service, please do it manually", operation))
+			result = append(result, queuedOperation{operation: operation, proposal: nil})
+			continue
+		}
+		if err := errs[jobOfOperation[o]]; err != nil {
+			cli.PrintWarning(fmt.Sprintf("cannot %s: %v\nGit Town will try again the next time you sync", operation, err))
+			continue
+		}
+		result = append(result, queuedOperation{operation: operation, proposal: proposals[o]})
+	}
+	return result, connector, nil
+}
+
+// shouldSyncUpstream indicates whether syncing the given branches syncs the main branch with the upstream remote.
+func shouldSyncUpstream(branchesToSync []string, mainBranch string, run *git.ProdRunner) (bool, error) {
+	if !stringslice.Contains(branchesToSync, mainBranch) {
+		return false, nil
+	}
+	hasUpstream, err := run.Backend.HasRemote("upstream")
+	if err != nil || !hasUpstream {
+		return false, err
+	}
+	return run.Config.ShouldSyncUpstream()
+}
+
+// syncStepsWithoutConflicts provides the step list for the "git sync" command
+// after warning about the merge conflicts that it would cause.
+// If skipConflicts is set, the step list doesn't sync the branches that would conflict.
+func syncStepsWithoutConflicts(config *syncConfig, skipConflicts bool, run *git.ProdRunner) (runstate.StepList, error) {
+	for {
+		stepList, err := syncBranchesSteps(config, run)
+		if err != nil {
+			return stepList, err
+		}
+		conflicts, err := preflight.PredictConflicts(stepList, config.initialBranch, &run.Backend)
+		if err != nil || len(conflicts) == 0 {
+			return stepList, err
+		}
+		if !skipConflicts {
+			cli.PrintWarning(preflight.Report(conflicts) + "\nRun \"git town sync --skip-conflicts\" to sync only the branches that don't conflict.")
+			return stepList, nil
+		}
+		cli.PrintWarning(preflight.Report(conflicts))
+		branchCount := len(config.branchesToSync)
+		for _, branch := range preflight.Branches(conflicts) {
+			if stringslice.Contains(config.branchesToSync, branch) {
+				cli.Printf("Skipping branch %q because syncing it would cause merge conflicts.\n", branch)
+				config.branchesToSync = stringslice.Remove(config.branchesToSync, branch)
+				config.skippedBranches = append(config.skippedBranches, branch)
+			}
+		}
+		// skipping branches changes how their descendants get synced, so predict again unless nothing changed
+		if len(config.branchesToSync) == branchCount {
+			return stepList, nil
+		}
+	}
+}
+
 // syncBranchesSteps provides the step list for the "git sync" command.
 func syncBranchesSteps(config *syncConfig, run *git.ProdRunner) (runstate.StepList, error) {
 	list := runstate.StepListBuilder{}
 	for _, branch := range config.branchesToSync {
-		updateBranchSteps(&list, branch, true, run)
+		updateBranchSteps(&list, branch, true, config.hasFetchedUpstream, config.branchesInOtherWorktrees, run)
 	}
+	flushOfflineQueueSteps(&list, config, run)
 	list.Add(&steps.CheckoutStep{Branch: config.initialBranch})
 	if config.hasOrigin && config.shouldPushTags && !config.isOffline {
 		list.Add(&steps.PushTagsStep{})
 	}
 	list.Wrap(runstate.WrapOptions{RunInGitRoot: true, StashOpenChanges: true}, &run.Backend, config.mainBranch)
+	list.AddHook(hooks.PostSync, config.hookContext, run)
 	return list.Result()
 }
 
+// flushOfflineQueueSteps provides the steps to perform the network operations that Git Town skipped while offline.
+func flushOfflineQueueSteps(list *runstate.StepListBuilder, config *syncConfig, run *git.ProdRunner) {
+	for _, queued := range config.offlineOperations {
+		operation := queued.operation
+		switch operation.Type {
+		case offlinequeue.CreateTrackingBranch, offlinequeue.PushBranch:
+			// pushing a branch that wasn't synced because of merge conflicts waits until the next sync
+			if stringslice.Contains(config.skippedBranches, operation.Branch) {
+				continue
+			}
+			// branches checked out in other worktrees don't get synced here and therefore not pushed either
+			if _, inOtherWorktree := config.branchesInOtherWorktrees[operation.Branch]; inOtherWorktree && stringslice.Contains(config.branchesToSync, operation.Branch) {
+				continue
+			}
+			// syncing a branch pushes it, and branches that don't exist anymore don't need to get pushed
+			if stringslice.Contains(config.branchesToSync, operation.Branch) || !list.Bool(run.Backend.HasLocalBranch(operation.Branch)) {
+				break
+			}
+			if list.Bool(run.Backend.HasTrackingBranch(operation.Branch)) {
+				list.Add(&steps.PushBranchStep{Branch: operation.Branch, ForceWithLease: operation.ForceWithLease})
+			} else {
+				list.Add(&steps.CreateTrackingBranchStep{Branch: operation.Branch})
+			}
+		case offlinequeue.UpdateProposalTarget:
+			if queued.proposal != nil && queued.proposal.Target != operation.NewTarget {
+				list.Add(&steps.UpdateProposalTargetStep{
+					ProposalNumber: queued.proposal.Number,
+					NewTarget:      operation.NewTarget,
+					ExistingTarget: queued.proposal.Target,
+				})
+			}
+		}
+		list.Add(&steps.DequeueOfflineOperationStep{Operation: operation})
+	}
+}
+
+// printSkippedWorktreeBranches tells the user which of the given branches don't get synced
+// because they are checked out in other worktrees.
+func printSkippedWorktreeBranches(branches []string, branchesInOtherWorktrees map[string]string) {
+	for _, branch := range branches {
+		if worktree, inOtherWorktree := branchesInOtherWorktrees[branch]; inOtherWorktree {
+			cli.Printf("Skipping branch %q because it is checked out in the worktree at %s.\n", branch, worktree)
+		}
+	}
+}
+
 // updateBranchSteps provides the steps to sync a particular branch.
-func updateBranchSteps(list *runstate.StepListBuilder, branch string, pushBranch bool, run *git.ProdRunner) {
+// If hasFetchedUpstream is set, the main branch gets synced with the already fetched upstream branch.
+// Branches that are checked out in other worktrees don't get synced, see printSkippedWorktreeBranches.
+func updateBranchSteps(list *runstate.StepListBuilder, branch string, pushBranch, hasFetchedUpstream bool, branchesInOtherWorktrees map[string]string, run *git.ProdRunner) {
 	isFeatureBranch := run.Config.IsFeatureBranch(branch)
 	syncStrategy := list.SyncStrategy(run.Config.SyncStrategy())
 	hasOrigin := list.Bool(run.Backend.HasOrigin())
@@ -159,27 +399,50 @@ func updateBranchSteps(list *runstate.StepListBuilder, branch string, pushBranch
 	if !hasOrigin && !isFeatureBranch {
 		return
 	}
+	if _, inOtherWorktree := branchesInOtherWorktrees[branch]; inOtherWorktree {
+		// Git cannot check out a branch that is checked out in another worktree
+		return
+	}
+	if run.Config.IsObservedBranch(branch) {
+		updateObservedBranchSteps(list, branch, hasOrigin, run)
+		return
+	}
 	list.Add(&steps.CheckoutStep{Branch: branch})
-	if isFeatureBranch {
+	switch {
+	case run.Config.IsContributionBranch(branch):
+		updateContributionBranchSteps(list, branch, run)
+	case isFeatureBranch:
 		updateFeatureBranchSteps(list, branch, run)
-	} else {
-		updatePerennialBranchSteps(list, branch, run)
+	default:
+		updatePerennialBranchSteps(list, branch, hasFetchedUpstream, run)
+	}
+	// prototype branches exist only locally until they get published
+	if !pushBranch || !hasOrigin || run.Config.IsPrototypeBranch(branch) {
+		return
 	}
 	isOffline := list.Bool(run.Config.IsOffline())
-	if pushBranch && hasOrigin && !isOffline {
-		hasTrackingBranch := list.Bool(run.Backend.HasTrackingBranch(branch))
-		if !hasTrackingBranch {
-			list.Add(&steps.CreateTrackingBranchStep{Branch: branch})
-			return
-		}
-		if !isFeatureBranch {
-			list.Add(&steps.PushBranchStep{Branch: branch})
-			return
-		}
+	hasTrackingBranch := list.Bool(run.Backend.HasTrackingBranch(branch))
+	switch {
+	case isOffline:
+		queueOfflinePushSteps(list, branch, hasTrackingBranch, isFeatureBranch && syncStrategy == config.SyncStrategyRebase)
+	case !hasTrackingBranch:
+		list.Add(&steps.CreateTrackingBranchStep{Branch: branch})
+	case !isFeatureBranch:
+		list.Add(&steps.PushBranchStep{Branch: branch})
+	default:
 		pushFeatureBranchSteps(list, branch, syncStrategy, pushHook)
 	}
 }
 
+// queueOfflinePushSteps provides the steps to record that the given branch needs to get pushed once Git Town is online again.
+func queueOfflinePushSteps(list *runstate.StepListBuilder, branch string, hasTrackingBranch, forceWithLease bool) {
+	if !hasTrackingBranch {
+		list.Add(&steps.QueueOfflineOperationStep{Operation: offlinequeue.Operation{Type: offlinequeue.CreateTrackingBranch, Branch: branch}})
+		return
+	}
+	list.Add(&steps.QueueOfflineOperationStep{Operation: offlinequeue.Operation{Type: offlinequeue.PushBranch, Branch: branch, ForceWithLease: forceWithLease}})
+}
+
 func updateFeatureBranchSteps(list *runstate.StepListBuilder, branch string, run *git.ProdRunner) {
 	syncStrategy := list.SyncStrategy(run.Config.SyncStrategy())
 	hasTrackingBranch := list.Bool(run.Backend.HasTrackingBranch(branch))
@@ -189,7 +452,29 @@ func updateFeatureBranchSteps(list *runstate.StepListBuilder, branch string, run
 	syncBranchSteps(list, run.Config.ParentBranch(branch), string(syncStrategy))
 }
 
-func updatePerennialBranchSteps(list *runstate.StepListBuilder, branch string, run *git.ProdRunner) {
+// updateContributionBranchSteps provides the steps to sync a contribution branch.
+// Contribution branches don't get synced with their parent.
+func updateContributionBranchSteps(list *runstate.StepListBuilder, branch string, run *git.ProdRunner) {
+	hasTrackingBranch := list.Bool(run.Backend.HasTrackingBranch(branch))
+	if hasTrackingBranch {
+		syncStrategy := list.SyncStrategy(run.Config.SyncStrategy())
+		syncBranchSteps(list, run.Backend.TrackingBranch(branch), string(syncStrategy))
+	}
+}
+
+// updateObservedBranchSteps provides the steps to sync an observed branch.
+// Observed branches only receive the updates from their tracking branch.
+func updateObservedBranchSteps(list *runstate.StepListBuilder, branch string, hasOrigin bool, run *git.ProdRunner) {
+	hasTrackingBranch := list.Bool(run.Backend.HasTrackingBranch(branch))
+	if !hasOrigin || !hasTrackingBranch {
+		return
+	}
+	list.Add(&steps.CheckoutStep{Branch: branch})
+	pullBranchStrategy := list.PullBranchStrategy(run.Config.PullBranchStrategy())
+	syncBranchSteps(list, run.Backend.TrackingBranch(branch), string(pullBranchStrategy))
+}
+
+func updatePerennialBranchSteps(list *runstate.StepListBuilder, branch string, hasFetchedUpstream bool, run *git.ProdRunner) {
 	hasTrackingBranch := list.Bool(run.Backend.HasTrackingBranch(branch))
 	if hasTrackingBranch {
 		pullBranchStrategy := list.PullBranchStrategy(run.Config.PullBranchStrategy())
@@ -199,7 +484,11 @@ func updatePerennialBranchSteps(list *runstate.StepListBuilder, branch string, r
 	hasUpstream := list.Bool(run.Backend.HasRemote("upstream"))
 	shouldSyncUpstream := list.Bool(run.Config.ShouldSyncUpstream())
 	if mainBranch == branch && hasUpstream && shouldSyncUpstream {
-		list.Add(&steps.FetchUpstreamStep{Branch: mainBranch})
+		// offline, the main branch gets synced with the last fetched state of the upstream remote
+		isOffline := list.Bool(run.Config.IsOffline())
+		if !hasFetchedUpstream && !isOffline {
+			list.Add(&steps.FetchUpstreamStep{Branch: mainBranch})
+		}
 		list.Add(&steps.RebaseBranchStep{Branch: fmt.Sprintf("upstream/%s", mainBranch)})
 	}
 }
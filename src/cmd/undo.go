@@ -3,8 +3,10 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/git-town/git-town/v8/src/cli"
 	"github.com/git-town/git-town/v8/src/execute"
 	"github.com/git-town/git-town/v8/src/flags"
+	"github.com/git-town/git-town/v8/src/hosting"
 	"github.com/git-town/git-town/v8/src/runstate"
 	"github.com/spf13/cobra"
 )
@@ -27,7 +29,7 @@ func undoCmd() *cobra.Command {
 	return &cmd
 }
 
-func undo(debug bool) error {
+func undo(debug flags.DebugArgs) error {
 	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
 		Debug:                 debug,
 		DryRun:                false,
@@ -47,5 +49,13 @@ func undo(debug bool) error {
 		return fmt.Errorf("nothing to undo")
 	}
 	undoRunState := runState.CreateUndoRunState()
-	return runstate.Execute(&undoRunState, &run, nil)
+	// only undoing changes to proposals requires the connector
+	var connector hosting.Connector
+	if undoRunState.RunStepList.HasProposalSteps() {
+		connector, err = hosting.NewConnector(&run.Config, &run.Backend, cli.PrintConnectorAction, run.Stats)
+		if err != nil {
+			return err
+		}
+	}
+	return runstate.Execute(&undoRunState, &run, connector)
 }
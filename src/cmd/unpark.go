@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v8/src/execute"
+	"github.com/git-town/git-town/v8/src/flags"
+	"github.com/git-town/git-town/v8/src/runstate"
+	"github.com/git-town/git-town/v8/src/steps"
+	"github.com/spf13/cobra"
+)
+
+const unparkDesc = "Makes the given parked branch a normal feature branch again"
+
+const unparkHelp = `
+Without arguments, unparks the current branch.`
+
+func unparkCmd() *cobra.Command {
+	addDebugFlag, readDebugFlag := flags.Debug()
+	cmd := cobra.Command{
+		Use:   "unpark [<branch>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: unparkDesc,
+		Long:  long(unparkDesc, unparkHelp),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return unpark(args, readDebugFlag(cmd))
+		},
+	}
+	addDebugFlag(&cmd)
+	return &cmd
+}
+
+func unpark(args []string, debug flags.DebugArgs) error {
+	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
+		Debug:                 debug,
+		DryRun:                false,
+		HandleUnfinishedState: true,
+		ValidateGitversion:    true,
+		ValidateIsRepository:  true,
+		ValidateIsConfigured:  true,
+	})
+	if err != nil || exit {
+		return err
+	}
+	branch, err := branchArgOrCurrentBranch(args, &run.Backend)
+	if err != nil {
+		return err
+	}
+	if !run.Config.IsParkedBranch(branch) {
+		return fmt.Errorf("branch %q is not parked", branch)
+	}
+	stepList := runstate.StepList{}
+	stepList.Append(&steps.RemoveFromParkedBranchesStep{Branch: branch})
+	runState := runstate.New("unpark", stepList)
+	return runstate.Execute(runState, &run, nil)
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v8/src/execute"
+	"github.com/git-town/git-town/v8/src/flags"
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/runstate"
+	"github.com/git-town/git-town/v8/src/steps"
+	"github.com/git-town/git-town/v8/src/validate"
+	"github.com/spf13/cobra"
+)
+
+const walkDesc = "Runs the given command on each branch of the current stack"
+
+const walkHelp = `
+Checks out each feature branch in the stack of the current branch,
+from the oldest ancestor to the youngest descendant,
+and runs the given command in it.
+
+Separate the command from the flags of this command with "--",
+for example "git town walk -- make lint".
+The command runs without a shell.
+To use shell features, run "git town walk -- sh -c '...'".
+
+If the command fails, Git Town stops and lets you
+fix the problem and run "git town continue" to run the command again,
+run "git town skip" to go to the next branch,
+or run "git town abort" to go back to where you started.
+Git Town also stops if the command leaves uncommitted changes.
+Commit them and run "git town continue",
+or discard them with "git town skip" or "git town abort".`
+
+func walkCmd() *cobra.Command {
+	addAllFlag, readAllFlag := flags.Bool("all", "a", "Run the command on all local feature branches")
+	addDebugFlag, readDebugFlag := flags.Debug()
+	cmd := cobra.Command{
+		Use:   "walk [flags] -- <command> [<args>...]",
+		Args:  cobra.MinimumNArgs(1),
+		Short: walkDesc,
+		Long:  long(walkDesc, walkHelp),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return walk(args, readAllFlag(cmd), readDebugFlag(cmd))
+		},
+	}
+	addAllFlag(&cmd)
+	addDebugFlag(&cmd)
+	return &cmd
+}
+
+func walk(args []string, all bool, debug flags.DebugArgs) error {
+	run, exit, err := execute.LoadProdRunner(execute.LoadArgs{
+		Debug:                 debug,
+		DryRun:                false,
+		HandleUnfinishedState: true,
+		ValidateGitversion:    true,
+		ValidateIsRepository:  true,
+		ValidateIsConfigured:  true,
+	})
+	if err != nil || exit {
+		return err
+	}
+	config, err := determineWalkConfig(args, all, &run)
+	if err != nil {
+		return err
+	}
+	stepList, err := walkStepList(config, &run)
+	if err != nil {
+		return err
+	}
+	runState := runstate.New("walk", stepList)
+	return runstate.Execute(runState, &run, nil)
+}
+
+type walkConfig struct {
+	branches      []string
+	command       []string
+	initialBranch string
+	mainBranch    string
+}
+
+func determineWalkConfig(args []string, all bool, run *git.ProdRunner) (*walkConfig, error) {
+	initialBranch, err := run.Backend.CurrentBranch()
+	if err != nil {
+		return nil, err
+	}
+	mainBranch := run.Config.MainBranch()
+	var candidates []string
+	if all {
+		localBranches, err := run.Backend.LocalBranchesMainFirst(mainBranch)
+		if err != nil {
+			return nil, err
+		}
+		err = validate.KnowsBranchesAncestry(localBranches, &run.Backend)
+		if err != nil {
+			return nil, err
+		}
+		run.Config.Reload()
+		for _, root := range run.Config.BranchAncestryRoots() {
+			candidates = append(candidates, run.Config.DescendantBranches(root)...)
+		}
+	} else {
+		if !run.Config.IsFeatureBranch(initialBranch) {
+			return nil, fmt.Errorf("the current branch %q is not a feature branch, please use --all", initialBranch)
+		}
+		err = validate.KnowsBranchAncestry(initialBranch, mainBranch, &run.Backend)
+		if err != nil {
+			return nil, err
+		}
+		run.Config.Reload()
+		candidates = append(run.Config.AncestorBranches(initialBranch), initialBranch)
+		candidates = append(candidates, run.Config.DescendantBranches(initialBranch)...)
+	}
+	branches := []string{}
+	for _, branch := range candidates {
+		if !run.Config.IsFeatureBranch(branch) {
+			continue
+		}
+		hasLocalBranch, err := run.Backend.HasLocalBranch(branch)
+		if err != nil {
+			return nil, err
+		}
+		if hasLocalBranch {
+			branches = append(branches, branch)
+		}
+	}
+	return &walkConfig{
+		branches:      branches,
+		command:       args,
+		initialBranch: initialBranch,
+		mainBranch:    mainBranch,
+	}, nil
+}
+
+func walkStepList(config *walkConfig, run *git.ProdRunner) (runstate.StepList, error) {
+	list := runstate.StepListBuilder{}
+	for _, branch := range config.branches {
+		list.Add(&steps.CheckoutStep{Branch: branch})
+		list.Add(&steps.RunCommandStep{Command: config.command})
+	}
+	list.Add(&steps.CheckoutStep{Branch: config.initialBranch})
+	list.Wrap(runstate.WrapOptions{RunInGitRoot: true, StashOpenChanges: true}, &run.Backend, config.mainBranch)
+	return list.Result()
+}
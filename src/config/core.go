@@ -5,16 +5,24 @@
 package config
 
 const (
+	APITimeoutKey                  = "git-town.api-timeout"
 	CodeHostingDriverKey           = "git-town.code-hosting-driver"
 	CodeHostingOriginHostnameKey   = "git-town.code-hosting-origin-hostname"
+	ContributionBranchesKey        = "git-town.contribution-branches"
 	DeprecatedNewBranchPushFlagKey = "git-town.new-branch-push-flag"
 	DeprecatedPushVerifyKey        = "git-town.push-verify"
+	DetectedHostingServiceKey      = "git-town.detected-hosting-service"
 	GiteaTokenKey                  = "git-town.gitea-token"  //nolint:gosec
 	GithubTokenKey                 = "git-town.github-token" //nolint:gosec
 	GitlabTokenKey                 = "git-town.gitlab-token" //nolint:gosec
+	HooksKeyPrefix                 = "git-town.hooks."
+	LogKey                         = "git-town.log"
 	MainBranchKey                  = "git-town.main-branch-name"
+	ObservedBranchesKey            = "git-town.observed-branches"
 	OfflineKey                     = "git-town.offline"
+	ParkedBranchesKey              = "git-town.parked-branches"
 	PerennialBranchesKey           = "git-town.perennial-branch-names"
+	PrototypeBranchesKey           = "git-town.prototype-branches"
 	PullBranchStrategyKey          = "git-town.pull-branch-strategy"
 	PushHookKey                    = "git-town.push-hook"
 	PushNewBranchesKey             = "git-town.push-new-branches"
@@ -23,3 +31,46 @@ const (
 	SyncStrategyKey                = "git-town.sync-strategy"
 	TestingRemoteURLKey            = "git-town.testing.remote-url"
 )
+
+// DeprecatedKeys provides the deprecated configuration keys and the keys that replace them.
+func DeprecatedKeys() map[string]string {
+	return map[string]string{
+		DeprecatedNewBranchPushFlagKey: PushNewBranchesKey,
+		DeprecatedPushVerifyKey:        PushHookKey,
+	}
+}
+
+// HookKey provides the configuration key for the command of the hook with the given name.
+func HookKey(hook string) string {
+	return HooksKeyPrefix + hook
+}
+
+// KnownKeys provides all configuration keys that Git Town uses, except the branch-specific ones.
+func KnownKeys() []string {
+	return []string{
+		APITimeoutKey,
+		CodeHostingDriverKey,
+		CodeHostingOriginHostnameKey,
+		ContributionBranchesKey,
+		DeprecatedNewBranchPushFlagKey,
+		DeprecatedPushVerifyKey,
+		DetectedHostingServiceKey,
+		GiteaTokenKey,
+		GithubTokenKey,
+		GitlabTokenKey,
+		LogKey,
+		MainBranchKey,
+		ObservedBranchesKey,
+		OfflineKey,
+		ParkedBranchesKey,
+		PerennialBranchesKey,
+		PrototypeBranchesKey,
+		PullBranchStrategyKey,
+		PushHookKey,
+		PushNewBranchesKey,
+		ShipDeleteRemoteBranchKey,
+		SyncUpstreamKey,
+		SyncStrategyKey,
+		TestingRemoteURLKey,
+	}
+}
@@ -63,6 +63,18 @@ func (g *Git) GlobalConfigValue(key string) string {
 	return g.globalConfigCache[key]
 }
 
+// GlobalConfigKeysMatching provides the names of the global Git configuration keys matching the given RegExp string.
+func (g *Git) GlobalConfigKeysMatching(toMatch string) []string {
+	result := []string{}
+	re := regexp.MustCompile(toMatch)
+	for key := range g.globalConfigCache {
+		if re.MatchString(key) {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
 // LocalConfigKeysMatching provides the names of the Git Town configuration keys matching the given RegExp string.
 func (g *Git) LocalConfigKeysMatching(toMatch string) []string {
 	result := []string{}
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/git-town/git-town/v8/src/giturl"
 	"github.com/git-town/git-town/v8/src/stringslice"
@@ -27,12 +28,56 @@ func NewGitTown(runner runner) *GitTown {
 	}
 }
 
+// DefaultAPITimeout is how long API calls to code hosting services can take if the user hasn't configured otherwise.
+const DefaultAPITimeout = 30 * time.Second
+
+// APITimeout provides how long API calls to code hosting services can take before Git Town aborts them.
+// The setting accepts durations like "45s" or "2m" as well as a plain number of seconds.
+func (gt *GitTown) APITimeout() (time.Duration, error) {
+	setting := gt.LocalOrGlobalConfigValue(APITimeoutKey)
+	if setting == "" {
+		return DefaultAPITimeout, nil
+	}
+	result, err := time.ParseDuration(setting)
+	if err != nil {
+		seconds, atoiErr := strconv.Atoi(setting)
+		if atoiErr != nil {
+			return 0, fmt.Errorf("invalid value for %s: %q. Please provide a duration like \"45s\" or \"2m\"", APITimeoutKey, setting)
+		}
+		result = time.Duration(seconds) * time.Second
+	}
+	if result <= 0 {
+		return 0, fmt.Errorf("invalid value for %s: %q. Please provide a positive duration", APITimeoutKey, setting)
+	}
+	return result, nil
+}
+
+// AddToContributionBranches registers the given branch names as contribution branches.
+func (gt *GitTown) AddToContributionBranches(branches ...string) error {
+	return gt.SetContributionBranches(append(gt.ContributionBranches(), branches...))
+}
+
+// AddToObservedBranches registers the given branch names as observed branches.
+func (gt *GitTown) AddToObservedBranches(branches ...string) error {
+	return gt.SetObservedBranches(append(gt.ObservedBranches(), branches...))
+}
+
+// AddToParkedBranches registers the given branch names as parked branches.
+func (gt *GitTown) AddToParkedBranches(branches ...string) error {
+	return gt.SetParkedBranches(append(gt.ParkedBranches(), branches...))
+}
+
 // AddToPerennialBranches registers the given branch names as perennial branches.
 // The branches must exist.
 func (gt *GitTown) AddToPerennialBranches(branches ...string) error {
 	return gt.SetPerennialBranches(append(gt.PerennialBranches(), branches...))
 }
 
+// AddToPrototypeBranches registers the given branch names as prototype branches.
+func (gt *GitTown) AddToPrototypeBranches(branches ...string) error {
+	return gt.SetPrototypeBranches(append(gt.PrototypeBranches(), branches...))
+}
+
 // AncestorBranches provides the names of all parent branches for the given branch,
 // This information is read from the cache in the Git config,
 // so might be out of date when the branch hierarchy has been modified.
@@ -81,6 +126,28 @@ func (gt *GitTown) ChildBranches(branch string) []string {
 	return result
 }
 
+// ContributionBranches provides the branches that the user contributes to
+// but doesn't own. Git Town pushes these branches
+// but doesn't sync them with their parent or ship them.
+func (gt *GitTown) ContributionBranches() []string {
+	result := gt.LocalConfigValue(ContributionBranchesKey)
+	if result == "" {
+		return []string{}
+	}
+	return strings.Split(result, " ")
+}
+
+// DescendantBranches provides the names of all branches that descend from the given branch,
+// ordered so that each branch comes before its children.
+func (gt *GitTown) DescendantBranches(branch string) []string {
+	result := []string{}
+	for _, child := range gt.ChildBranches(branch) {
+		result = append(result, child)
+		result = append(result, gt.DescendantBranches(child)...)
+	}
+	return result
+}
+
 func (gt *GitTown) DeprecatedNewBranchPushFlagGlobal() string {
 	return gt.globalConfigCache[DeprecatedNewBranchPushFlagKey]
 }
@@ -127,6 +194,20 @@ func (gt *GitTown) HasBranchInformation() bool {
 	return false
 }
 
+// DetectedHostingService provides the code hosting service that Git Town detected earlier at the given host.
+// The boolean return value indicates whether Git Town has probed this host before.
+func (gt *GitTown) DetectedHostingService(hostname string) (HostingService, bool) {
+	host, service, found := strings.Cut(gt.LocalConfigValue(DetectedHostingServiceKey), "=")
+	if !found || host != hostname {
+		return HostingServiceNone, false
+	}
+	hostingService, err := NewHostingService(service)
+	if err != nil {
+		return HostingServiceNone, false
+	}
+	return hostingService, true
+}
+
 // HasParentBranch returns whether or not the given branch has a parent.
 func (gt *GitTown) HasParentBranch(branch string) bool {
 	return gt.ParentBranch(branch) != ""
@@ -137,6 +218,11 @@ func (gt *GitTown) HostingServiceName() string {
 	return gt.LocalOrGlobalConfigValue(CodeHostingDriverKey)
 }
 
+// HookCommand provides the shell command configured for the hook with the given name.
+func (gt *GitTown) HookCommand(hook string) string {
+	return gt.LocalOrGlobalConfigValue(HookKey(hook))
+}
+
 // HostingService provides the type-safe name of the code hosting connector to use.
 // This function caches its result and can be queried repeatedly.
 func (gt *GitTown) HostingService() (HostingService, error) {
@@ -149,6 +235,12 @@ func (gt *GitTown) IsAncestorBranch(branch, ancestorBranch string) bool {
 	return stringslice.Contains(ancestorBranches, ancestorBranch)
 }
 
+// IsContributionBranch indicates whether the branch with the given name is
+// a contribution branch.
+func (gt *GitTown) IsContributionBranch(branch string) bool {
+	return stringslice.Contains(gt.ContributionBranches(), branch)
+}
+
 // IsFeatureBranch indicates whether the branch with the given name is
 // a feature branch.
 func (gt *GitTown) IsFeatureBranch(branch string) bool {
@@ -161,19 +253,38 @@ func (gt *GitTown) IsMainBranch(branch string) bool {
 	return branch == gt.MainBranch()
 }
 
-// IsOffline indicates whether Git Town is currently in offline mode.
-func (gt *GitTown) IsOffline() (bool, error) {
-	config := gt.GlobalConfigValue(OfflineKey)
+// IsOfflineModeOn indicates whether the user has put Git Town into offline mode.
+// In automatic offline mode it provides false, use git.RepoConfig.IsOffline to check whether origin is reachable.
+func (gt *GitTown) IsOfflineModeOn() (bool, error) {
+	mode, err := gt.OfflineMode()
+	return mode == OfflineModeOn, err
+}
+
+// IsLogEnabled indicates whether Git Town records its runs in the run log.
+func (gt *GitTown) IsLogEnabled() (bool, error) {
+	config := gt.GlobalConfigValue(LogKey)
 	if config == "" {
 		return false, nil
 	}
 	result, err := ParseBool(config)
 	if err != nil {
-		return false, fmt.Errorf("invalid value for %s: %q. Please provide either \"true\" or \"false\"", OfflineKey, config)
+		return false, fmt.Errorf("invalid value for %s: %q. Please provide either \"true\" or \"false\"", LogKey, config)
 	}
 	return result, nil
 }
 
+// IsObservedBranch indicates whether the branch with the given name is
+// an observed branch.
+func (gt *GitTown) IsObservedBranch(branch string) bool {
+	return stringslice.Contains(gt.ObservedBranches(), branch)
+}
+
+// IsParkedBranch indicates whether the branch with the given name is
+// a parked branch.
+func (gt *GitTown) IsParkedBranch(branch string) bool {
+	return stringslice.Contains(gt.ParkedBranches(), branch)
+}
+
 // IsPerennialBranch indicates whether the branch with the given name is
 // a perennial branch.
 func (gt *GitTown) IsPerennialBranch(branch string) bool {
@@ -181,6 +292,31 @@ func (gt *GitTown) IsPerennialBranch(branch string) bool {
 	return stringslice.Contains(perennialBranches, branch)
 }
 
+// IsPrototypeBranch indicates whether the branch with the given name is
+// a prototype branch.
+func (gt *GitTown) IsPrototypeBranch(branch string) bool {
+	return stringslice.Contains(gt.PrototypeBranches(), branch)
+}
+
+// LoggableValues provides the Git Town entries of the local and global Git configuration
+// in a form that is safe to record in the run log: API tokens are masked.
+// Local entries take precedence over global ones.
+func (gt *GitTown) LoggableValues() map[string]string {
+	result := map[string]string{}
+	for _, values := range []map[string]string{gt.globalConfigCache, gt.localConfigCache} {
+		for key, value := range values {
+			if !strings.HasPrefix(key, "git-town.") && !strings.HasPrefix(key, "git-town-branch.") {
+				continue
+			}
+			if key == GiteaTokenKey || key == GithubTokenKey || key == GitlabTokenKey {
+				value = "(masked)"
+			}
+			result[key] = value
+		}
+	}
+	return result
+}
+
 // MainBranch provides the name of the main branch.
 func (gt *GitTown) MainBranch() string {
 	return gt.LocalOrGlobalConfigValue(MainBranchKey)
@@ -195,6 +331,27 @@ func (gt *GitTown) MainBranchOr(defaultValue string) string {
 	return defaultValue
 }
 
+// ObservedBranches provides the branches that the user only looks at.
+// Git Town pulls updates for these branches but never pushes them
+// or syncs them with their parent.
+func (gt *GitTown) ObservedBranches() []string {
+	result := gt.LocalConfigValue(ObservedBranchesKey)
+	if result == "" {
+		return []string{}
+	}
+	return strings.Split(result, " ")
+}
+
+// OfflineMode provides the offline mode that the user has configured.
+func (gt *GitTown) OfflineMode() (OfflineMode, error) {
+	setting := gt.GlobalConfigValue(OfflineKey)
+	result, err := NewOfflineMode(setting)
+	if err != nil {
+		return OfflineModeOff, fmt.Errorf("invalid value for %s: %q. Please provide either \"true\", \"false\", or \"auto\"", OfflineKey, setting)
+	}
+	return result, nil
+}
+
 // OriginOverride provides the override for the origin hostname from the Git Town configuration.
 func (gt *GitTown) OriginOverride() string {
 	return gt.LocalConfigValue(CodeHostingOriginHostnameKey)
@@ -202,16 +359,24 @@ func (gt *GitTown) OriginOverride() string {
 
 // OriginURLString provides the URL for the "origin" remote.
 // Tests can stub this through the GIT_TOWN_REMOTE environment variable.
+// Git applies the "url.<base>.insteadOf" settings to the URLs it provides,
+// so this method applies them only to stubbed URLs.
 func (gt *GitTown) OriginURLString() string {
 	remote := os.Getenv("GIT_TOWN_REMOTE")
 	if remote != "" {
-		return remote
+		return giturl.Rewrite(remote, gt.URLRewrites())
+	}
+	output, err := gt.Query("git", "remote", "get-url", OriginRemote)
+	if err != nil {
+		// the repo has no origin remote
+		return ""
 	}
-	output, _ := gt.Query("git", "remote", "get-url", OriginRemote)
 	return output
 }
 
 // OriginURL provides the URL for the "origin" remote.
+// It resolves the SSH host aliases of the user
+// so that the URL points to the actual server.
 // Tests can stub this through the GIT_TOWN_REMOTE environment variable.
 // Caches its result so can be called repeatedly.
 func (gt *GitTown) OriginURL() *giturl.Parts {
@@ -224,9 +389,13 @@ func (gt *GitTown) OriginURL() *giturl.Parts {
 		return cached
 	}
 	url := giturl.Parse(text)
-	originOverride := gt.OriginOverride()
-	if originOverride != "" {
-		url.Host = originOverride
+	if url != nil {
+		resolved := giturl.LoadSSHConfig().Resolve(*url)
+		url = &resolved
+		originOverride := gt.OriginOverride()
+		if originOverride != "" {
+			url.Host = originOverride
+		}
 	}
 	gt.originURLCache[text] = url
 	return url
@@ -248,6 +417,16 @@ func (gt *GitTown) ParentBranch(branch string) string {
 	return gt.LocalConfigValue("git-town-branch." + branch + ".parent")
 }
 
+// ParkedBranches provides the branches that the user doesn't work on right now.
+// "git sync --all" doesn't sync these branches.
+func (gt *GitTown) ParkedBranches() []string {
+	result := gt.LocalConfigValue(ParkedBranchesKey)
+	if result == "" {
+		return []string{}
+	}
+	return strings.Split(result, " ")
+}
+
 // PerennialBranches returns all branches that are marked as perennial.
 func (gt *GitTown) PerennialBranches() []string {
 	result := gt.LocalOrGlobalConfigValue(PerennialBranchesKey)
@@ -257,6 +436,16 @@ func (gt *GitTown) PerennialBranches() []string {
 	return strings.Split(result, " ")
 }
 
+// PrototypeBranches provides the branches that exist only locally.
+// Git Town doesn't push these branches until they get published.
+func (gt *GitTown) PrototypeBranches() []string {
+	result := gt.LocalConfigValue(PrototypeBranchesKey)
+	if result == "" {
+		return []string{}
+	}
+	return strings.Split(result, " ")
+}
+
 // PullBranchStrategy provides the currently configured pull branch strategy.
 func (gt *GitTown) PullBranchStrategy() (PullBranchStrategy, error) {
 	text := gt.LocalOrGlobalConfigValue(PullBranchStrategyKey)
@@ -282,7 +471,7 @@ func (gt *GitTown) PushHook() (bool, error) {
 
 // PushHook provides the currently configured push-hook setting.
 func (gt *GitTown) PushHookGlobal() (bool, error) {
-	err := gt.updateDeprecatedGlobalSetting(DeprecatedPushVerifyKey, PushHookKey)
+	err := gt.UpdateDeprecatedGlobalSetting(DeprecatedPushVerifyKey, PushHookKey)
 	if err != nil {
 		return false, err
 	}
@@ -297,11 +486,31 @@ func (gt *GitTown) PushHookGlobal() (bool, error) {
 	return result, nil
 }
 
+// RemoveFromContributionBranches removes the given branch as a contribution branch.
+func (gt *GitTown) RemoveFromContributionBranches(branch string) error {
+	return gt.SetContributionBranches(stringslice.Remove(gt.ContributionBranches(), branch))
+}
+
+// RemoveFromObservedBranches removes the given branch as an observed branch.
+func (gt *GitTown) RemoveFromObservedBranches(branch string) error {
+	return gt.SetObservedBranches(stringslice.Remove(gt.ObservedBranches(), branch))
+}
+
+// RemoveFromParkedBranches removes the given branch as a parked branch.
+func (gt *GitTown) RemoveFromParkedBranches(branch string) error {
+	return gt.SetParkedBranches(stringslice.Remove(gt.ParkedBranches(), branch))
+}
+
 // RemoveFromPerennialBranches removes the given branch as a perennial branch.
 func (gt *GitTown) RemoveFromPerennialBranches(branch string) error {
 	return gt.SetPerennialBranches(stringslice.Remove(gt.PerennialBranches(), branch))
 }
 
+// RemoveFromPrototypeBranches removes the given branch as a prototype branch.
+func (gt *GitTown) RemoveFromPrototypeBranches(branch string) error {
+	return gt.SetPrototypeBranches(stringslice.Remove(gt.PrototypeBranches(), branch))
+}
+
 // RemoveLocalGitConfiguration removes all Git Town configuration.
 func (gt *GitTown) RemoveLocalGitConfiguration() error {
 	err := gt.Run("git", "config", "--remove-section", "git-town")
@@ -349,12 +558,22 @@ func (gt *GitTown) SetCodeHostingOriginHostname(value string) error {
 	return err
 }
 
+// SetDetectedHostingService remembers that the given code hosting service runs at the given host.
+func (gt *GitTown) SetDetectedHostingService(hostname string, service HostingService) error {
+	return gt.SetLocalConfigValue(DetectedHostingServiceKey, hostname+"="+string(service))
+}
+
 // SetColorUI configures whether Git output contains color codes.
 func (gt *GitTown) SetColorUI(value string) error {
 	err := gt.Run("git", "config", "color.ui", value)
 	return err
 }
 
+// SetContributionBranches marks the given branches as contribution branches.
+func (gt *GitTown) SetContributionBranches(branches []string) error {
+	return gt.SetLocalConfigValue(ContributionBranchesKey, strings.Join(branches, " "))
+}
+
 // SetMainBranch marks the given branch as the main branch
 // in the Git Town configuration.
 func (gt *GitTown) SetMainBranch(branch string) error {
@@ -374,12 +593,29 @@ func (gt *GitTown) SetNewBranchPush(value bool, global bool) error {
 	return err
 }
 
+// SetObservedBranches marks the given branches as observed branches.
+func (gt *GitTown) SetObservedBranches(branches []string) error {
+	return gt.SetLocalConfigValue(ObservedBranchesKey, strings.Join(branches, " "))
+}
+
+// SetLogEnabled updates whether Git Town records its runs in the run log.
+func (gt *GitTown) SetLogEnabled(value bool) error {
+	_, err := gt.SetGlobalConfigValue(LogKey, strconv.FormatBool(value))
+	return err
+}
+
 // SetOffline updates whether Git Town is in offline mode.
 func (gt *GitTown) SetOffline(value bool) error {
 	_, err := gt.SetGlobalConfigValue(OfflineKey, strconv.FormatBool(value))
 	return err
 }
 
+// SetOfflineMode updates the offline mode setting.
+func (gt *GitTown) SetOfflineMode(mode OfflineMode) error {
+	_, err := gt.SetGlobalConfigValue(OfflineKey, mode.String())
+	return err
+}
+
 // SetParent marks the given branch as the direct parent of the other given branch
 // in the Git Town configuration.
 func (gt *GitTown) SetParent(branch, parentBranch string) error {
@@ -387,12 +623,22 @@ func (gt *GitTown) SetParent(branch, parentBranch string) error {
 	return err
 }
 
+// SetParkedBranches marks the given branches as parked branches.
+func (gt *GitTown) SetParkedBranches(branches []string) error {
+	return gt.SetLocalConfigValue(ParkedBranchesKey, strings.Join(branches, " "))
+}
+
 // SetPerennialBranches marks the given branches as perennial branches.
 func (gt *GitTown) SetPerennialBranches(branch []string) error {
 	err := gt.SetLocalConfigValue(PerennialBranchesKey, strings.Join(branch, " "))
 	return err
 }
 
+// SetPrototypeBranches marks the given branches as prototype branches.
+func (gt *GitTown) SetPrototypeBranches(branches []string) error {
+	return gt.SetLocalConfigValue(PrototypeBranchesKey, strings.Join(branches, " "))
+}
+
 // SetPullBranchStrategy updates the configured pull branch strategy.
 func (gt *GitTown) SetPullBranchStrategy(strategy PullBranchStrategy) error {
 	err := gt.SetLocalConfigValue(PullBranchStrategyKey, string(strategy))
@@ -460,7 +706,7 @@ func (gt *GitTown) ShouldNewBranchPush() (bool, error) {
 // ShouldNewBranchPushGlobal indictes whether the global configuration requires to push
 // freshly created branches to origin.
 func (gt *GitTown) ShouldNewBranchPushGlobal() (bool, error) {
-	err := gt.updateDeprecatedGlobalSetting(DeprecatedNewBranchPushFlagKey, PushNewBranchesKey)
+	err := gt.UpdateDeprecatedGlobalSetting(DeprecatedNewBranchPushFlagKey, PushNewBranchesKey)
 	if err != nil {
 		return false, err
 	}
@@ -503,15 +749,30 @@ func (gt *GitTown) SyncStrategyGlobal() (SyncStrategy, error) {
 	return ToSyncStrategy(setting)
 }
 
-func (gt *GitTown) updateDeprecatedSetting(deprecatedKey, newKey string) error {
-	err := gt.updateDeprecatedLocalSetting(deprecatedKey, newKey)
-	if err != nil {
-		return err
+// URLRewrites provides the "url.<base>.insteadOf" settings in the local and global Git configuration.
+// The result maps the URL prefixes to replace to the base URLs to replace them with.
+// Local settings take precedence over global ones.
+func (gt *GitTown) URLRewrites() map[string]string {
+	result := map[string]string{}
+	for _, key := range gt.GlobalConfigKeysMatching(urlRewriteKeyRegex) {
+		result[gt.GlobalConfigValue(key)] = urlRewriteBase(key)
+	}
+	for _, key := range gt.LocalConfigKeysMatching(urlRewriteKeyRegex) {
+		result[gt.LocalConfigValue(key)] = urlRewriteBase(key)
 	}
-	return gt.updateDeprecatedGlobalSetting(deprecatedKey, newKey)
+	return result
+}
+
+// urlRewriteKeyRegex matches the keys of the "url.<base>.insteadOf" settings.
+const urlRewriteKeyRegex = `^url\..*\.(?i:insteadof)$`
+
+// urlRewriteBase provides the base URL of the given "url.<base>.insteadof" key.
+func urlRewriteBase(key string) string {
+	return strings.TrimPrefix(key, "url.")[:len(key)-len("url.")-len(".insteadof")]
 }
 
-func (gt *GitTown) updateDeprecatedGlobalSetting(deprecatedKey, newKey string) error {
+// UpdateDeprecatedGlobalSetting moves the value of the given deprecated global setting to the given new setting.
+func (gt *GitTown) UpdateDeprecatedGlobalSetting(deprecatedKey, newKey string) error {
 	deprecatedSetting := gt.GlobalConfigValue(deprecatedKey)
 	if deprecatedSetting != "" {
 		fmt.Printf("I found the deprecated global setting %q.\n", deprecatedKey)
@@ -526,7 +787,8 @@ func (gt *GitTown) updateDeprecatedGlobalSetting(deprecatedKey, newKey string) e
 	return nil
 }
 
-func (gt *GitTown) updateDeprecatedLocalSetting(deprecatedKey, newKey string) error {
+// UpdateDeprecatedLocalSetting moves the value of the given deprecated local setting to the given new setting.
+func (gt *GitTown) UpdateDeprecatedLocalSetting(deprecatedKey, newKey string) error {
 	deprecatedSetting := gt.LocalConfigValue(deprecatedKey)
 	if deprecatedSetting != "" {
 		fmt.Printf("I found the deprecated local setting %q.\n", deprecatedKey)
@@ -540,3 +802,11 @@ func (gt *GitTown) updateDeprecatedLocalSetting(deprecatedKey, newKey string) er
 	}
 	return nil
 }
+
+func (gt *GitTown) updateDeprecatedSetting(deprecatedKey, newKey string) error {
+	err := gt.UpdateDeprecatedLocalSetting(deprecatedKey, newKey)
+	if err != nil {
+		return err
+	}
+	return gt.UpdateDeprecatedGlobalSetting(deprecatedKey, newKey)
+}
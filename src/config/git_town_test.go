@@ -3,7 +3,9 @@ package config_test
 import (
 	"os"
 	"testing"
+	"time"
 
+	"github.com/git-town/git-town/v8/src/config"
 	"github.com/git-town/git-town/v8/src/giturl"
 	"github.com/git-town/git-town/v8/test/testruntime"
 	"github.com/stretchr/testify/assert"
@@ -11,15 +13,99 @@ import (
 
 func TestGitTown(t *testing.T) {
 	t.Parallel()
+	t.Run(".APITimeout()", func(t *testing.T) {
+		t.Parallel()
+		repo := testruntime.CreateGitTown(t)
+		have, err := repo.Config.APITimeout()
+		assert.NoError(t, err)
+		assert.Equal(t, config.DefaultAPITimeout, have)
+		tests := map[string]time.Duration{
+			"45s": 45 * time.Second,
+			"2m":  2 * time.Minute,
+			"10":  10 * time.Second,
+		}
+		for give, want := range tests {
+			assert.NoError(t, repo.Config.SetLocalConfigValue(config.APITimeoutKey, give))
+			have, err := repo.Config.APITimeout()
+			assert.NoError(t, err)
+			assert.Equal(t, want, have, give)
+		}
+		for _, give := range []string{"zero", "0", "-5s"} {
+			assert.NoError(t, repo.Config.SetLocalConfigValue(config.APITimeoutKey, give))
+			_, err := repo.Config.APITimeout()
+			assert.Error(t, err, give)
+		}
+	})
+
+	t.Run(".DescendantBranches()", func(t *testing.T) {
+		t.Parallel()
+		repo := testruntime.CreateGitTown(t)
+		assert.NoError(t, repo.Config.SetParent("parent", "main"))
+		assert.NoError(t, repo.Config.SetParent("child1", "parent"))
+		assert.NoError(t, repo.Config.SetParent("child2", "parent"))
+		assert.NoError(t, repo.Config.SetParent("grandchild", "child1"))
+		assert.NoError(t, repo.Config.SetParent("other", "main"))
+		have := repo.Config.DescendantBranches("parent")
+		assert.Equal(t, []string{"child1", "grandchild", "child2"}, have)
+	})
+
+	t.Run(".IsObservedBranch()", func(t *testing.T) {
+		t.Parallel()
+		repo := testruntime.CreateGitTown(t)
+		assert.False(t, repo.Config.IsObservedBranch("observed"))
+		assert.NoError(t, repo.Config.AddToObservedBranches("observed"))
+		assert.True(t, repo.Config.IsObservedBranch("observed"))
+		assert.False(t, repo.Config.IsContributionBranch("observed"))
+		assert.NoError(t, repo.Config.RemoveFromObservedBranches("observed"))
+		assert.False(t, repo.Config.IsObservedBranch("observed"))
+	})
+
+	t.Run(".IsParkedBranch()", func(t *testing.T) {
+		t.Parallel()
+		repo := testruntime.CreateGitTown(t)
+		assert.False(t, repo.Config.IsParkedBranch("parked"))
+		assert.NoError(t, repo.Config.AddToParkedBranches("parked"))
+		assert.True(t, repo.Config.IsParkedBranch("parked"))
+		assert.NoError(t, repo.Config.RemoveFromParkedBranches("parked"))
+		assert.False(t, repo.Config.IsParkedBranch("parked"))
+	})
+
+	t.Run(".IsPrototypeBranch()", func(t *testing.T) {
+		t.Parallel()
+		repo := testruntime.CreateGitTown(t)
+		assert.False(t, repo.Config.IsPrototypeBranch("prototype"))
+		assert.NoError(t, repo.Config.AddToPrototypeBranches("prototype"))
+		assert.True(t, repo.Config.IsPrototypeBranch("prototype"))
+		assert.NoError(t, repo.Config.RemoveFromPrototypeBranches("prototype"))
+		assert.False(t, repo.Config.IsPrototypeBranch("prototype"))
+	})
+
+	t.Run(".DetectedHostingService()", func(t *testing.T) {
+		t.Parallel()
+		repo := testruntime.CreateGitTown(t)
+		_, found := repo.Config.DetectedHostingService("git.example.com")
+		assert.False(t, found)
+		assert.NoError(t, repo.Config.SetDetectedHostingService("git.example.com", config.HostingServiceGitLab))
+		service, found := repo.Config.DetectedHostingService("git.example.com")
+		assert.True(t, found)
+		assert.Equal(t, config.HostingServiceGitLab, service)
+		_, found = repo.Config.DetectedHostingService("other.example.com")
+		assert.False(t, found)
+		assert.NoError(t, repo.Config.SetDetectedHostingService("other.example.com", config.HostingServiceNone))
+		service, found = repo.Config.DetectedHostingService("other.example.com")
+		assert.True(t, found)
+		assert.Equal(t, config.HostingServiceNone, service)
+	})
+
 	t.Run("OriginURL()", func(t *testing.T) {
 		t.Parallel()
 		tests := map[string]giturl.Parts{
-			"http://github.com/organization/repository":                     {Host: "github.com", Org: "organization", Repo: "repository"},
-			"http://github.com/organization/repository.git":                 {Host: "github.com", Org: "organization", Repo: "repository"},
-			"https://github.com/organization/repository":                    {Host: "github.com", Org: "organization", Repo: "repository"},
-			"https://github.com/organization/repository.git":                {Host: "github.com", Org: "organization", Repo: "repository"},
-			"https://sub.domain.customhost.com/organization/repository":     {Host: "sub.domain.customhost.com", Org: "organization", Repo: "repository"},
-			"https://sub.domain.customhost.com/organization/repository.git": {Host: "sub.domain.customhost.com", Org: "organization", Repo: "repository"},
+			"http://github.com/organization/repository":                     {Scheme: "http", Host: "github.com", Org: "organization", Repo: "repository"},
+			"http://github.com/organization/repository.git":                 {Scheme: "http", Host: "github.com", Org: "organization", Repo: "repository"},
+			"https://github.com/organization/repository":                    {Scheme: "https", Host: "github.com", Org: "organization", Repo: "repository"},
+			"https://github.com/organization/repository.git":                {Scheme: "https", Host: "github.com", Org: "organization", Repo: "repository"},
+			"https://sub.domain.customhost.com/organization/repository":     {Scheme: "https", Host: "sub.domain.customhost.com", Org: "organization", Repo: "repository"},
+			"https://sub.domain.customhost.com/organization/repository.git": {Scheme: "https", Host: "sub.domain.customhost.com", Org: "organization", Repo: "repository"},
 		}
 		for give, want := range tests {
 			repo := testruntime.CreateGitTown(t)
@@ -30,17 +116,34 @@ func TestGitTown(t *testing.T) {
 		}
 	})
 
+	t.Run("OriginURL() with URL rewrites", func(t *testing.T) {
+		t.Parallel()
+		repo := testruntime.CreateGitTown(t)
+		assert.NoError(t, repo.Config.SetLocalConfigValue("url.git@github.com:.insteadOf", "gh:"))
+		os.Setenv("GIT_TOWN_REMOTE", "gh:organization/repository")
+		defer os.Unsetenv("GIT_TOWN_REMOTE")
+		want := giturl.Parts{Scheme: "ssh", User: "git", Host: "github.com", Org: "organization", Repo: "repository"}
+		have := repo.Config.OriginURL()
+		assert.Equal(t, want, *have)
+	})
+
+	t.Run("OriginURL() without origin remote", func(t *testing.T) {
+		t.Parallel()
+		repo := testruntime.CreateGitTown(t)
+		assert.Nil(t, repo.Config.OriginURL())
+	})
+
 	t.Run(".SetOffline()", func(t *testing.T) {
 		t.Parallel()
 		repo := testruntime.CreateGitTown(t)
 		err := repo.Config.SetOffline(true)
 		assert.NoError(t, err)
-		offline, err := repo.Config.IsOffline()
+		offline, err := repo.Config.IsOfflineModeOn()
 		assert.Nil(t, err)
 		assert.True(t, offline)
 		err = repo.Config.SetOffline(false)
 		assert.NoError(t, err)
-		offline, err = repo.Config.IsOffline()
+		offline, err = repo.Config.IsOfflineModeOn()
 		assert.Nil(t, err)
 		assert.False(t, offline)
 	})
@@ -9,6 +9,8 @@ import (
 type HostingService string
 
 const (
+	// HostingServiceAuto makes Git Town detect the code hosting service of a self-hosted origin server.
+	HostingServiceAuto      HostingService = "auto"
 	HostingServiceBitbucket HostingService = "bitbucket"
 	HostingServiceGitHub    HostingService = "github"
 	HostingServiceGitLab    HostingService = "gitlab"
@@ -31,6 +33,7 @@ func NewHostingService(text string) (HostingService, error) {
 func hostingServices() []HostingService {
 	return []HostingService{
 		HostingServiceNone,
+		HostingServiceAuto,
 		HostingServiceBitbucket,
 		HostingServiceGitHub,
 		HostingServiceGitLab,
@@ -12,6 +12,7 @@ func TestNewHostingService(t *testing.T) {
 	t.Run("valid content", func(t *testing.T) {
 		t.Parallel()
 		tests := map[string]config.HostingService{
+			"auto":      config.HostingServiceAuto,
 			"bitbucket": config.HostingServiceBitbucket,
 			"github":    config.HostingServiceGitHub,
 			"gitlab":    config.HostingServiceGitLab,
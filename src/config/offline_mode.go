@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OfflineMode defines legal values for the "offline" configuration setting.
+type OfflineMode string
+
+const (
+	// OfflineModeOff makes Git Town perform network operations.
+	OfflineModeOff OfflineMode = "false"
+	// OfflineModeOn makes Git Town skip all network operations.
+	OfflineModeOn OfflineMode = "true"
+	// OfflineModeAuto makes Git Town skip network operations when it cannot reach origin.
+	OfflineModeAuto OfflineMode = "auto"
+)
+
+// NewOfflineMode provides the OfflineMode that the given text describes.
+// It accepts "auto" and all boolean values that ParseBool accepts.
+func NewOfflineMode(text string) (OfflineMode, error) {
+	switch strings.ToLower(text) {
+	case "":
+		return OfflineModeOff, nil
+	case "auto":
+		return OfflineModeAuto, nil
+	}
+	value, err := ParseBool(text)
+	if err != nil {
+		return OfflineModeOff, fmt.Errorf("unknown offline mode: %q", text)
+	}
+	if value {
+		return OfflineModeOn, nil
+	}
+	return OfflineModeOff, nil
+}
+
+func (om OfflineMode) String() string {
+	return string(om)
+}
@@ -0,0 +1,36 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v8/src/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOfflineMode(t *testing.T) {
+	t.Parallel()
+	t.Run("valid content", func(t *testing.T) {
+		t.Parallel()
+		tests := map[string]config.OfflineMode{
+			"":      config.OfflineModeOff,
+			"false": config.OfflineModeOff,
+			"no":    config.OfflineModeOff,
+			"true":  config.OfflineModeOn,
+			"yes":   config.OfflineModeOn,
+			"1":     config.OfflineModeOn,
+			"auto":  config.OfflineModeAuto,
+			"Auto":  config.OfflineModeAuto,
+		}
+		for give, want := range tests {
+			have, err := config.NewOfflineMode(give)
+			assert.Nil(t, err)
+			assert.Equal(t, want, have, give)
+		}
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		t.Parallel()
+		_, err := config.NewOfflineMode("zonk")
+		assert.Error(t, err)
+	})
+}
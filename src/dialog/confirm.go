@@ -0,0 +1,32 @@
+package dialog
+
+import (
+	"fmt"
+
+	survey "gopkg.in/AlecAivazis/survey.v1"
+)
+
+// Confirm asks the user the given yes/no question.
+// It uses a selection dialog because, unlike text prompts, selection dialogs don't query the terminal for the cursor position.
+func Confirm(message string, defaultValue bool) (bool, error) {
+	defaultAnswer := confirmNo
+	if defaultValue {
+		defaultAnswer = confirmYes
+	}
+	result := ""
+	prompt := &survey.Select{
+		Message: message,
+		Options: []string{confirmYes, confirmNo},
+		Default: defaultAnswer,
+	}
+	err := survey.AskOne(prompt, &result, nil)
+	if err != nil {
+		return false, fmt.Errorf("cannot read answer from CLI: %w", err)
+	}
+	return result == confirmYes, nil
+}
+
+const (
+	confirmYes = "yes"
+	confirmNo  = "no"
+)
@@ -22,24 +22,35 @@ func ModalSelect(entries ModalEntries, initialValue string) (*string, error) {
 		activeCursor:  "> ",
 		activeColor:   color.New(color.FgCyan, color.Bold),
 		activePos:     *initialPos,
+		dimColor:      color.New(color.Faint),
+		filter:        "",
+		filtering:     false,
 		initialCursor: "* ",
 		initialColor:  color.New(color.FgGreen),
 		initialPos:    *initialPos,
+		printedLines:  0,
 		status:        modalSelectStatusNew,
+		visible:       entries.matching(""),
 	}
 	return input.Display()
 }
 
 // modalSelect allows selecting a value from a list using VIM keybindings.
+// Pressing "/" filters the entries by the typed text.
 type modalSelect struct {
 	activeColor   *color.Color      // color with which to print the currently selected line
 	activeCursor  string            // text that gets prepended to the currently selected row
-	activePos     int               // index of the currently selected row
+	activePos     int               // index of the currently selected entry
+	dimColor      *color.Color      // color with which to print dimmed entries
 	entries       ModalEntries      // the entries to display
+	filter        string            // the text that the user typed to filter the entries
+	filtering     bool              // whether the user is typing a filter
 	initialColor  *color.Color      // color with which to print the initially selected value
 	initialCursor string            // cursor at the initial entry
-	initialPos    int               // index of the initially selected value
+	initialPos    int               // index of the initially selected entry
+	printedLines  int               // how many lines the last print call printed
 	status        modalSelectStatus // the current status of this ModalInput instance
+	visible       []int             // indexes of the entries that match the filter
 }
 
 // Display shows the dialog and lets the user select an entry.
@@ -72,19 +83,39 @@ func (mi *modalSelect) print() {
 	if mi.status == modalSelectStatusNew {
 		mi.status = modalSelectStatusSelecting
 	} else {
-		cursor.Up(len(mi.entries))
+		cursor.Up(mi.printedLines)
 	}
-	for e, entry := range mi.entries {
+	lines := 0
+	for _, e := range mi.visible {
+		entry := mi.entries[e]
+		cursor.ClearLine()
 		if e == mi.initialPos && e == mi.activePos { //nolint:gocritic
 			mi.activeColor.Println(mi.initialCursor + entry.Text)
 		} else if e == mi.initialPos {
 			mi.initialColor.Println(mi.initialCursor + entry.Text)
 		} else if e == mi.activePos {
 			mi.activeColor.Println(mi.activeCursor + entry.Text)
+		} else if entry.Dimmed {
+			mi.dimColor.Println(strings.Repeat(" ", len(mi.activeCursor)) + entry.Text)
 		} else {
 			fmt.Println(strings.Repeat(" ", len(mi.activeCursor)) + entry.Text)
 		}
+		lines++
 	}
+	if mi.filtering {
+		cursor.ClearLine()
+		fmt.Printf("filter: %s\n", mi.filter)
+		lines++
+	}
+	// clear the lines that the previous print call printed additionally
+	for l := lines; l < mi.printedLines; l++ {
+		cursor.ClearLine()
+		fmt.Println()
+	}
+	if mi.printedLines > lines {
+		cursor.Up(mi.printedLines - lines)
+	}
+	mi.printedLines = lines
 }
 
 // handleInput waits for keyboard input and updates the dialog state.
@@ -93,25 +124,67 @@ func (mi *modalSelect) handleInput() error {
 	if err != nil {
 		return err
 	}
+	mi.handleKey(char, key)
+	return nil
+}
+
+// handleKey updates the dialog state for the given keypress.
+func (mi *modalSelect) handleKey(char rune, key keyboard.Key) {
 	switch {
-	case char == 'j', key == keyboard.KeyArrowDown, key == keyboard.KeyTab:
-		if mi.activePos < len(mi.entries)-1 {
-			mi.activePos++
-		} else {
-			mi.activePos = 0
-		}
-	case char == 'k', key == keyboard.KeyArrowUp:
-		if mi.activePos > 0 {
-			mi.activePos--
-		} else {
-			mi.activePos = len(mi.entries) - 1
+	case key == keyboard.KeyArrowDown, key == keyboard.KeyTab, char == 'j' && !mi.filtering:
+		mi.moveSelection(1)
+	case key == keyboard.KeyArrowUp, char == 'k' && !mi.filtering:
+		mi.moveSelection(-1)
+	case key == keyboard.KeyEnter, char == 's' && !mi.filtering:
+		if len(mi.visible) > 0 {
+			mi.status = modalSelectStatusSelected
 		}
-	case key == keyboard.KeyEnter, char == 's':
-		mi.status = modalSelectStatusSelected
+	case key == keyboard.KeyEsc && mi.filtering:
+		mi.filtering = false
+		mi.setFilter("")
 	case key == keyboard.KeyEsc:
 		mi.status = modalSelectStatusAborted
+	case char == '/' && !mi.filtering:
+		mi.filtering = true
+	case key == keyboard.KeyBackspace || key == keyboard.KeyBackspace2:
+		if mi.filtering && mi.filter != "" {
+			mi.setFilter(mi.filter[:len(mi.filter)-1])
+		}
+	case key == keyboard.KeySpace && mi.filtering:
+		mi.setFilter(mi.filter + " ")
+	case char != 0 && mi.filtering:
+		mi.setFilter(mi.filter + string(char))
+	}
+}
+
+// moveSelection moves the selection by the given number of visible entries, wrapping around at the ends.
+func (mi *modalSelect) moveSelection(delta int) {
+	if len(mi.visible) == 0 {
+		return
+	}
+	pos := 0
+	for v, e := range mi.visible {
+		if e == mi.activePos {
+			pos = v
+		}
+	}
+	pos = (pos + delta + len(mi.visible)) % len(mi.visible)
+	mi.activePos = mi.visible[pos]
+}
+
+// setFilter filters the displayed entries by the given text.
+// It keeps the current selection if it still matches, otherwise selects the first match.
+func (mi *modalSelect) setFilter(filter string) {
+	mi.filter = filter
+	mi.visible = mi.entries.matching(filter)
+	for _, e := range mi.visible {
+		if e == mi.activePos {
+			return
+		}
+	}
+	if len(mi.visible) > 0 {
+		mi.activePos = mi.visible[0]
 	}
-	return nil
 }
 
 // selectedValue provides the value selected by the user.
@@ -119,10 +192,34 @@ func (mi *modalSelect) selectedValue() string {
 	return mi.entries[mi.activePos].Value
 }
 
+// FuzzyMatch indicates whether the given text contains the characters of the given pattern in the same order,
+// ignoring case. Spaces in the pattern match anything.
+func FuzzyMatch(pattern, text string) bool {
+	remaining := []rune(strings.ToLower(text))
+	for _, char := range strings.ToLower(pattern) {
+		if char == ' ' {
+			continue
+		}
+		found := false
+		for r, textChar := range remaining {
+			if textChar == char {
+				remaining = remaining[r+1:]
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 // ModalEntry contains one of the many entries that the user can choose from.
 type ModalEntry struct {
-	Text  string // the text to display
-	Value string // the return value
+	Dimmed bool   // whether to display this entry in a less prominent color
+	Text   string // the text to display
+	Value  string // the return value
 }
 
 // ModalEntries is a collection of ModalEntry.
@@ -139,6 +236,32 @@ func (mes ModalEntries) IndexOfValue(value string) *int {
 	return nil
 }
 
+// matching provides the indexes of the entries whose value matches the given filter.
+func (mes ModalEntries) matching(filter string) []int {
+	result := []int{}
+	for e, entry := range mes {
+		if FuzzyMatch(filter, entry.Value) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Annotate provides a copy of these entries with the annotations that the given function provides for their values
+// appended to their text.
+// The annotate function returns an empty string for entries that have no annotation.
+func (mes ModalEntries) Annotate(annotate func(value string) string) ModalEntries {
+	result := make(ModalEntries, len(mes))
+	copy(result, mes)
+	for e := range result {
+		annotation := annotate(result[e].Value)
+		if annotation != "" {
+			result[e].Text += "  " + annotation
+		}
+	}
+	return result
+}
+
 // modalSelectStatus represents the different states that a modalSelect instance can be in.
 type modalSelectStatus int
 
@@ -0,0 +1,31 @@
+package dialog_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v8/src/dialog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModalEntries(t *testing.T) {
+	t.Parallel()
+	t.Run(".Annotate()", func(t *testing.T) {
+		t.Parallel()
+		entries := dialog.ModalEntries{
+			{Dimmed: false, Text: "main", Value: "main"},
+			{Dimmed: false, Text: "  feature", Value: "feature"},
+			{Dimmed: true, Text: "  parked", Value: "parked"},
+		}
+		annotations := map[string]string{"feature": "#12"}
+		have := entries.Annotate(func(value string) string {
+			return annotations[value]
+		})
+		want := dialog.ModalEntries{
+			{Dimmed: false, Text: "main", Value: "main"},
+			{Dimmed: false, Text: "  feature  #12", Value: "feature"},
+			{Dimmed: true, Text: "  parked", Value: "parked"},
+		}
+		assert.Equal(t, want, have)
+		assert.Equal(t, "  feature", entries[1].Text)
+	})
+}
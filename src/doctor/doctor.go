@@ -0,0 +1,400 @@
+// Package doctor finds and repairs problems with the Git Town configuration of a repository.
+package doctor
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/git-town/git-town/v8/src/config"
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hooks"
+	"github.com/git-town/git-town/v8/src/stringslice"
+)
+
+// Problem describes something that is wrong with the Git Town configuration of a repository.
+type Problem struct {
+	// Description describes what is wrong.
+	Description string
+	// Fix describes how Git Town repairs this problem.
+	// Empty if Git Town cannot repair this problem automatically.
+	Fix string
+	// repair repairs this problem, nil if Git Town cannot repair it automatically.
+	repair func() error
+}
+
+// CanFix indicates whether Git Town can repair this problem automatically.
+func (p Problem) CanFix() bool {
+	return p.repair != nil
+}
+
+// Repair repairs this problem.
+func (p Problem) Repair() error {
+	if p.repair == nil {
+		return fmt.Errorf("cannot repair automatically: %s", p.Description)
+	}
+	return p.repair()
+}
+
+// Diagnose provides the problems with the given Git Town configuration.
+// Branches contains the names of all local branches and branches at origin.
+// HostingService is the name of the code hosting service of the repository, empty if unknown.
+func Diagnose(repoConfig *git.RepoConfig, branches []string, hostingService string) []Problem {
+	result := []Problem{}
+	result = append(result, deprecatedKeys(repoConfig)...)
+	result = append(result, unknownKeys(repoConfig)...)
+	result = append(result, invalidValues(repoConfig)...)
+	result = append(result, mainBranch(repoConfig, branches)...)
+	result = append(result, missingBranchesInLists(repoConfig, branches)...)
+	result = append(result, lineage(repoConfig, branches)...)
+	result = append(result, unreachableTokens(repoConfig, hostingService)...)
+	return result
+}
+
+// deprecatedKeys finds settings that use outdated keys.
+func deprecatedKeys(repoConfig *git.RepoConfig) []Problem {
+	result := []Problem{}
+	deprecated := config.DeprecatedKeys()
+	keys := make([]string, 0, len(deprecated))
+	for key := range deprecated {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		key, newKey := key, deprecated[key]
+		if repoConfig.LocalConfigValue(key) != "" {
+			result = append(result, Problem{
+				Description: fmt.Sprintf("the local setting %q is deprecated", key),
+				Fix:         fmt.Sprintf("rename it to %q", newKey),
+				repair:      func() error { return repoConfig.UpdateDeprecatedLocalSetting(key, newKey) },
+			})
+		}
+		if repoConfig.GlobalConfigValue(key) != "" {
+			result = append(result, Problem{
+				Description: fmt.Sprintf("the global setting %q is deprecated", key),
+				Fix:         fmt.Sprintf("rename it to %q", newKey),
+				repair:      func() error { return repoConfig.UpdateDeprecatedGlobalSetting(key, newKey) },
+			})
+		}
+	}
+	return result
+}
+
+// unknownKeys finds Git Town settings that Git Town doesn't use, for example because of typos.
+// Git Town doesn't remove them automatically because they might belong to a newer version of Git Town.
+func unknownKeys(repoConfig *git.RepoConfig) []Problem {
+	result := []Problem{}
+	knownKeys := config.KnownKeys()
+	parentKeyRE := regexp.MustCompile(`^git-town-branch\..*\.parent$`)
+	isUnknown := func(key string) bool {
+		if strings.HasPrefix(key, "git-town-branch.") {
+			return !parentKeyRE.MatchString(key)
+		}
+		if strings.HasPrefix(key, config.HooksKeyPrefix) {
+			return !stringslice.Contains(hooks.Names(), strings.TrimPrefix(key, config.HooksKeyPrefix))
+		}
+		return !stringslice.Contains(knownKeys, key)
+	}
+	localKeys := repoConfig.LocalConfigKeysMatching(`^git-town(-branch)?\.`)
+	sort.Strings(localKeys)
+	for _, key := range localKeys {
+		if isUnknown(key) {
+			result = append(result, Problem{
+				Description: fmt.Sprintf("Git Town doesn't know the local setting %q. Please check it for typos.", key),
+				Fix:         "",
+				repair:      nil,
+			})
+		}
+	}
+	globalKeys := repoConfig.GlobalConfigKeysMatching(`^git-town(-branch)?\.`)
+	sort.Strings(globalKeys)
+	for _, key := range globalKeys {
+		if isUnknown(key) {
+			result = append(result, Problem{
+				Description: fmt.Sprintf("Git Town doesn't know the global setting %q. Please check it for typos.", key),
+				Fix:         "",
+				repair:      nil,
+			})
+		}
+	}
+	return result
+}
+
+// invalidValues finds settings whose values Git Town cannot parse.
+func invalidValues(repoConfig *git.RepoConfig) []Problem {
+	result := []Problem{}
+	validators := map[string]func(string) error{
+		config.CodeHostingDriverKey:      func(value string) error { _, err := config.NewHostingService(value); return err },
+		config.LogKey:                    parseBool,
+		config.OfflineKey:                func(value string) error { _, err := config.NewOfflineMode(value); return err },
+		config.PullBranchStrategyKey:     func(value string) error { _, err := config.NewPullBranchStrategy(value); return err },
+		config.PushHookKey:               parseBool,
+		config.PushNewBranchesKey:        parseBool,
+		config.ShipDeleteRemoteBranchKey: parseBool,
+		config.SyncStrategyKey:           func(value string) error { _, err := config.ToSyncStrategy(value); return err },
+		config.SyncUpstreamKey:           parseBool,
+	}
+	keys := make([]string, 0, len(validators))
+	for key := range validators {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		key, validate := key, validators[key]
+		if value := repoConfig.LocalConfigValue(key); value != "" && validate(value) != nil {
+			result = append(result, Problem{
+				Description: fmt.Sprintf("the local setting %q has the invalid value %q", key, value),
+				Fix:         "remove it so that Git Town uses the default value",
+				repair:      func() error { return repoConfig.RemoveLocalConfigValue(key) },
+			})
+		}
+		if value := repoConfig.GlobalConfigValue(key); value != "" && validate(value) != nil {
+			result = append(result, Problem{
+				Description: fmt.Sprintf("the global setting %q has the invalid value %q", key, value),
+				Fix:         "remove it so that Git Town uses the default value",
+				repair: func() error {
+					_, err := repoConfig.RemoveGlobalConfigValue(key)
+					return err
+				},
+			})
+		}
+	}
+	return result
+}
+
+func parseBool(value string) error {
+	_, err := config.ParseBool(value)
+	return err
+}
+
+// mainBranch finds problems with the main branch setting.
+func mainBranch(repoConfig *git.RepoConfig, branches []string) []Problem {
+	mainBranch := repoConfig.MainBranch()
+	if mainBranch == "" {
+		return []Problem{{
+			Description: `the main branch is not configured. Please run "git town config main-branch <branch>" to configure it.`,
+			Fix:         "",
+			repair:      nil,
+		}}
+	}
+	if !stringslice.Contains(branches, mainBranch) {
+		return []Problem{{
+			Description: fmt.Sprintf(`the main branch %q doesn't exist. Please run "git town config main-branch <branch>" to configure an existing branch.`, mainBranch),
+			Fix:         "",
+			repair:      nil,
+		}}
+	}
+	return []Problem{}
+}
+
+// missingBranchesInLists finds branch type settings that contain branches that don't exist.
+func missingBranchesInLists(repoConfig *git.RepoConfig, branches []string) []Problem {
+	result := []Problem{}
+	lists := []struct {
+		name     string
+		branches []string
+		remove   func(string) error
+	}{
+		{"contribution", repoConfig.ContributionBranches(), repoConfig.RemoveFromContributionBranches},
+		{"observed", repoConfig.ObservedBranches(), repoConfig.RemoveFromObservedBranches},
+		{"parked", repoConfig.ParkedBranches(), repoConfig.RemoveFromParkedBranches},
+		{"perennial", repoConfig.PerennialBranches(), repoConfig.RemoveFromPerennialBranches},
+		{"prototype", repoConfig.PrototypeBranches(), repoConfig.RemoveFromPrototypeBranches},
+	}
+	for _, list := range lists {
+		list := list
+		for _, branch := range list.branches {
+			branch := branch
+			if stringslice.Contains(branches, branch) {
+				continue
+			}
+			result = append(result, Problem{
+				Description: fmt.Sprintf("the %s branches contain %q, which doesn't exist", list.name, branch),
+				Fix:         fmt.Sprintf("remove %q from the %s branches", branch, list.name),
+				repair:      func() error { return list.remove(branch) },
+			})
+		}
+	}
+	return result
+}
+
+// lineage finds problems with the parent branch entries:
+// cycles, parents of perennial branches, entries for branches that don't exist,
+// and branches whose parent doesn't exist.
+// It reports at most one problem per branch because all repairs change the parent entry of that branch.
+func lineage(repoConfig *git.RepoConfig, branches []string) []Problem {
+	result := []Problem{}
+	parentMap := repoConfig.ParentBranchMap()
+	children := make([]string, 0, len(parentMap))
+	for child := range parentMap {
+		children = append(children, child)
+	}
+	sort.Strings(children)
+	cycles := Cycles(parentMap)
+	inCycle := map[string]bool{}
+	for _, cycle := range cycles {
+		for _, branch := range cycle {
+			inCycle[branch] = true
+		}
+		cycle := cycle
+		result = append(result, Problem{
+			Description: fmt.Sprintf("the branches %s are their own ancestors: %s", quotedList(cycle), strings.Join(append(cycle, cycle[0]), " -> ")),
+			Fix:         fmt.Sprintf("remove the parents of %s so that Git Town asks for them again", quotedList(cycle)),
+			repair: func() error {
+				for _, branch := range cycle {
+					err := repoConfig.RemoveParent(branch)
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		})
+	}
+	for _, child := range children {
+		child, parent := child, parentMap[child]
+		switch {
+		case inCycle[child]:
+			continue
+		case repoConfig.IsMainBranch(child) || repoConfig.IsPerennialBranch(child):
+			result = append(result, Problem{
+				Description: fmt.Sprintf("the perennial branch %q has the parent %q, but perennial branches have no parents", child, parent),
+				Fix:         fmt.Sprintf("remove the parent of %q", child),
+				repair:      func() error { return repoConfig.RemoveParent(child) },
+			})
+		case !stringslice.Contains(branches, child):
+			result = append(result, Problem{
+				Description: fmt.Sprintf("the lineage contains the branch %q, which doesn't exist", child),
+				Fix:         fmt.Sprintf("remove the parent entry of %q", child),
+				repair:      func() error { return repoConfig.RemoveParent(child) },
+			})
+		case !stringslice.Contains(branches, parent):
+			problem := Problem{
+				Description: fmt.Sprintf("the parent %q of branch %q doesn't exist", parent, child),
+				Fix:         "",
+				repair:      nil,
+			}
+			newParent := closestExistingAncestor(child, parentMap, branches, repoConfig.MainBranch())
+			if newParent != "" {
+				problem.Fix = fmt.Sprintf("make %q the parent of %q", newParent, child)
+				problem.repair = func() error { return repoConfig.SetParent(child, newParent) }
+			}
+			result = append(result, problem)
+		}
+	}
+	return result
+}
+
+// Cycles provides the cycles in the given branch lineage.
+// Each cycle starts with its alphabetically first branch, followed by the parents of that branch.
+func Cycles(parentMap map[string]string) [][]string {
+	result := [][]string{}
+	children := make([]string, 0, len(parentMap))
+	for child := range parentMap {
+		children = append(children, child)
+	}
+	sort.Strings(children)
+	checked := map[string]bool{} // branches whose ancestry is known to contain no new cycles
+	for _, child := range children {
+		path := []string{}
+		positions := map[string]int{}
+		current := child
+		for {
+			if checked[current] {
+				break
+			}
+			if position, visited := positions[current]; visited {
+				result = append(result, normalizeCycle(path[position:]))
+				break
+			}
+			positions[current] = len(path)
+			path = append(path, current)
+			parent, hasParent := parentMap[current]
+			if !hasParent || parent == "" {
+				break
+			}
+			current = parent
+		}
+		for _, branch := range path {
+			checked[branch] = true
+		}
+	}
+	return result
+}
+
+// normalizeCycle rotates the given cycle so that it starts with its alphabetically first branch.
+func normalizeCycle(cycle []string) []string {
+	first := 0
+	for b, branch := range cycle {
+		if branch < cycle[first] {
+			first = b
+		}
+	}
+	return append(append([]string{}, cycle[first:]...), cycle[:first]...)
+}
+
+// closestExistingAncestor provides the closest ancestor of the given branch that exists,
+// or the main branch if none exists.
+func closestExistingAncestor(branch string, parentMap map[string]string, branches []string, mainBranch string) string {
+	visited := map[string]bool{branch: true}
+	current := parentMap[branch]
+	for current != "" && !visited[current] {
+		if stringslice.Contains(branches, current) {
+			return current
+		}
+		visited[current] = true
+		current = parentMap[current]
+	}
+	if mainBranch != "" && stringslice.Contains(branches, mainBranch) && mainBranch != branch {
+		return mainBranch
+	}
+	return ""
+}
+
+// unreachableTokens finds API tokens in the local configuration
+// that Git Town never uses because the repository is hosted elsewhere.
+// Tokens in the global configuration might be used by other repositories.
+func unreachableTokens(repoConfig *git.RepoConfig, hostingService string) []Problem {
+	result := []Problem{}
+	tokens := []struct {
+		key     string
+		service string
+	}{
+		{config.GiteaTokenKey, "Gitea"},
+		{config.GithubTokenKey, "GitHub"},
+		{config.GitlabTokenKey, "GitLab"},
+	}
+	for _, token := range tokens {
+		token := token
+		if repoConfig.LocalConfigValue(token.key) == "" || token.service == hostingService {
+			continue
+		}
+		if hostingService == "" {
+			result = append(result, Problem{
+				Description: fmt.Sprintf(`the local setting %q contains an API token for %s, but Git Town doesn't know the code hosting service of this repository. Please set "%s" if this repository is hosted on %s.`, token.key, token.service, config.CodeHostingDriverKey, token.service),
+				Fix:         "",
+				repair:      nil,
+			})
+			continue
+		}
+		result = append(result, Problem{
+			Description: fmt.Sprintf("the local setting %q contains an API token for %s, but this repository is hosted on %s", token.key, token.service, hostingService),
+			Fix:         "remove it",
+			repair:      func() error { return repoConfig.RemoveLocalConfigValue(token.key) },
+		})
+	}
+	return result
+}
+
+// quotedList provides the given branch names as a human-readable list.
+func quotedList(branches []string) string {
+	quoted := make([]string, len(branches))
+	for b, branch := range branches {
+		quoted[b] = fmt.Sprintf("%q", branch)
+	}
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " and " + quoted[len(quoted)-1]
+}
@@ -0,0 +1,93 @@
+package doctor_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v8/src/doctor"
+	"github.com/git-town/git-town/v8/test/testruntime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCycles(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		parentMap map[string]string
+		want      [][]string
+	}{
+		"no cycles": {
+			parentMap: map[string]string{"alpha": "main", "beta": "alpha"},
+			want:      [][]string{},
+		},
+		"branch is its own parent": {
+			parentMap: map[string]string{"alpha": "alpha"},
+			want:      [][]string{{"alpha"}},
+		},
+		"two branches": {
+			parentMap: map[string]string{"beta": "alpha", "alpha": "beta", "child": "beta"},
+			want:      [][]string{{"alpha", "beta"}},
+		},
+		"several cycles": {
+			parentMap: map[string]string{"c": "d", "d": "e", "e": "c", "a": "b", "b": "a", "x": "main"},
+			want:      [][]string{{"a", "b"}, {"c", "d", "e"}},
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, doctor.Cycles(test.parentMap))
+		})
+	}
+}
+
+func TestDiagnose(t *testing.T) {
+	t.Parallel()
+
+	t.Run("healthy configuration", func(t *testing.T) {
+		t.Parallel()
+		repo := testruntime.CreateGitTown(t)
+		assert.NoError(t, repo.CreateFeatureBranch("feature"))
+		problems := doctor.Diagnose(repo.Config, []string{"main", "feature"}, "")
+		assert.Empty(t, problems)
+	})
+
+	t.Run("orphaned branch", func(t *testing.T) {
+		t.Parallel()
+		repo := testruntime.CreateGitTown(t)
+		assert.NoError(t, repo.Config.SetParent("child", "deleted"))
+		assert.NoError(t, repo.Config.SetParent("deleted", "parent"))
+		assert.NoError(t, repo.Config.SetParent("parent", "main"))
+		problems := doctor.Diagnose(repo.Config, []string{"main", "parent", "child"}, "")
+		assert.Len(t, problems, 2)
+		assert.Equal(t, `the parent "deleted" of branch "child" doesn't exist`, problems[0].Description)
+		assert.Equal(t, `make "parent" the parent of "child"`, problems[0].Fix)
+		assert.Equal(t, `the lineage contains the branch "deleted", which doesn't exist`, problems[1].Description)
+		for _, problem := range problems {
+			assert.NoError(t, problem.Repair())
+		}
+		assert.Equal(t, map[string]string{"child": "parent", "parent": "main"}, repo.Config.ParentBranchMap())
+	})
+
+	t.Run("API tokens", func(t *testing.T) {
+		t.Parallel()
+		repo := testruntime.CreateGitTown(t)
+		assert.NoError(t, repo.Config.SetLocalConfigValue("git-town.github-token", "123"))
+		assert.Empty(t, doctor.Diagnose(repo.Config, []string{"main"}, "GitHub"))
+		problems := doctor.Diagnose(repo.Config, []string{"main"}, "GitLab")
+		assert.Len(t, problems, 1)
+		assert.True(t, problems[0].CanFix())
+		problems = doctor.Diagnose(repo.Config, []string{"main"}, "")
+		assert.Len(t, problems, 1)
+		assert.False(t, problems[0].CanFix())
+	})
+
+	t.Run("hook settings", func(t *testing.T) {
+		t.Parallel()
+		repo := testruntime.CreateGitTown(t)
+		assert.NoError(t, repo.Config.SetLocalConfigValue("git-town.hooks.pre-sync", "make lint"))
+		assert.NoError(t, repo.Config.SetLocalConfigValue("git-town.hooks.pre-snyc", "make lint"))
+		problems := doctor.Diagnose(repo.Config, []string{"main"}, "")
+		assert.Len(t, problems, 1)
+		assert.Equal(t, `Git Town doesn't know the local setting "git-town.hooks.pre-snyc". Please check it for typos.`, problems[0].Description)
+	})
+}
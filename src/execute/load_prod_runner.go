@@ -1,21 +1,27 @@
 package execute
 
 import (
+	"os"
+
 	"github.com/git-town/git-town/v8/src/cache"
 	"github.com/git-town/git-town/v8/src/failure"
+	"github.com/git-town/git-town/v8/src/flags"
 	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/runlog"
 	"github.com/git-town/git-town/v8/src/subshell"
 	"github.com/git-town/git-town/v8/src/validate"
 )
 
 func LoadProdRunner(args LoadArgs) (prodRunner git.ProdRunner, exit bool, err error) { //nolint:nonamedreturns // so many return values require names
-	var stats Statistics
-	if args.Debug {
-		stats = &CommandsStatistics{CommandsCount: 0}
+	var debugStats Statistics
+	if args.Debug.Enabled {
+		debugStats = NewCommandsStatistics(args.Debug.TraceFile)
 	} else {
-		stats = &NoStatistics{}
+		debugStats = &NoStatistics{}
 	}
-	backendRunner := subshell.BackendRunner{Dir: nil, Verbose: args.Debug, Stats: stats}
+	// the run log gets enabled once the configuration is loaded
+	stats := &LoggedStatistics{Statistics: debugStats, Log: nil}
+	backendRunner := subshell.BackendRunner{Dir: nil, Verbose: args.Debug.Enabled, Stats: stats}
 	config := git.NewRepoConfig(backendRunner)
 	prodRunner = git.ProdRunner{
 		Config: config,
@@ -28,7 +34,10 @@ func LoadProdRunner(args LoadArgs) (prodRunner git.ProdRunner, exit bool, err er
 			Config:         &config,
 		},
 		Stats: stats,
+		Log:   nil,
 	}
+	prodRunner.Log = openRunLog(&prodRunner.Config)
+	stats.Log = prodRunner.Log
 	if args.ValidateIsRepository {
 		err := validate.IsRepository(&prodRunner)
 		if err != nil {
@@ -61,8 +70,31 @@ func LoadProdRunner(args LoadArgs) (prodRunner git.ProdRunner, exit bool, err er
 	return prodRunner, exit, fc.Err
 }
 
+// openRunLog provides the run log for this run of Git Town if the user has enabled it.
+// Problems with the run log don't prevent Git Town from running.
+func openRunLog(config *git.RepoConfig) *runlog.Log {
+	enabled, err := config.IsLogEnabled()
+	if err != nil || !enabled {
+		return nil
+	}
+	dir, err := runlog.Dir()
+	if err != nil {
+		return nil
+	}
+	log, err := runlog.Open(dir)
+	if err != nil {
+		return nil
+	}
+	workingDir, err := os.Getwd()
+	if err != nil {
+		workingDir = ""
+	}
+	log.LogStart(os.Args, workingDir, config.LoggableValues())
+	return log
+}
+
 type LoadArgs struct {
-	Debug                 bool
+	Debug                 flags.DebugArgs
 	DryRun                bool
 	HandleUnfinishedState bool
 	OmitBranchNames       bool `exhaustruct:"optional"`
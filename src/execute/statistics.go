@@ -1,28 +1,226 @@
 package execute
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/git-town/git-town/v8/src/cli"
+	"github.com/git-town/git-town/v8/src/runlog"
+)
 
 type Statistics interface {
-	RegisterRun()
+	RegisterAPICall(name string, start time.Time, duration time.Duration)
+	RegisterCommand(command string, exitCode int, start time.Time, duration time.Duration)
+	RegisterStep(name string, start time.Time, duration time.Duration)
 	PrintAnalysis()
 }
 
-// CommandsStatistics is a Statistics implementation that counts how many commands were run.
+// the categories of timed events
+const (
+	apiCallEvent = "api call"
+	commandEvent = "command"
+	stepEvent    = "step"
+)
+
+// CommandsStatistics is a Statistics implementation that counts how many commands were run
+// and records how long the commands, steps, and API calls took.
 type CommandsStatistics struct {
 	CommandsCount int
+	Events        []TimedEvent
+	mutex         sync.Mutex // API calls and parallel commands can get registered concurrently
+	start         time.Time  // when Git Town started recording statistics
+	traceFile     string     // path of the file to write a Chrome trace into, empty if none
+}
+
+// TimedEvent is a command, step, or API call that ran at some point for some time.
+type TimedEvent struct {
+	Category string
+	Name     string
+	Start    time.Time
+	Duration time.Duration
+}
+
+// NewCommandsStatistics provides a new CommandsStatistics instance.
+// If traceFile is not empty, PrintAnalysis writes a Chrome trace of the recorded events into that file.
+func NewCommandsStatistics(traceFile string) *CommandsStatistics {
+	return &CommandsStatistics{
+		CommandsCount: 0,
+		Events:        []TimedEvent{},
+		mutex:         sync.Mutex{},
+		start:         time.Now(),
+		traceFile:     traceFile,
+	}
+}
+
+func (s *CommandsStatistics) RegisterAPICall(name string, start time.Time, duration time.Duration) {
+	s.register(apiCallEvent, name, start, duration)
 }
 
-func (s *CommandsStatistics) RegisterRun() {
+func (s *CommandsStatistics) RegisterCommand(command string, _ int, start time.Time, duration time.Duration) {
+	s.mutex.Lock()
 	s.CommandsCount++
+	s.mutex.Unlock()
+	s.register(commandEvent, command, start, duration)
+}
+
+func (s *CommandsStatistics) RegisterStep(name string, start time.Time, duration time.Duration) {
+	s.register(stepEvent, name, start, duration)
+}
+
+func (s *CommandsStatistics) register(category, name string, start time.Time, duration time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.Events = append(s.Events, TimedEvent{Category: category, Name: name, Start: start, Duration: duration})
 }
 
 func (s *CommandsStatistics) PrintAnalysis() {
 	fmt.Printf("Ran %d shell commands.", s.CommandsCount)
+	fmt.Print("\n\nDurations:\n")
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "TOTAL\tCOUNT\tTYPE\tNAME")
+	for _, entry := range s.Breakdown() {
+		fmt.Fprintf(writer, "%s\t%d\t%s\t%s\n", entry.Total.Round(10*time.Microsecond), entry.Count, entry.Category, entry.Name)
+	}
+	writer.Flush()
+	if s.traceFile != "" {
+		err := s.WriteTrace(s.traceFile)
+		if err != nil {
+			cli.PrintError(err)
+		} else {
+			fmt.Printf("\nWrote a trace of this run to %s.\n", s.traceFile)
+		}
+	}
+}
+
+// BreakdownEntry is the accumulated duration of all events with the same category and name.
+type BreakdownEntry struct {
+	Category string
+	Name     string
+	Count    int
+	Total    time.Duration
+}
+
+// Breakdown provides the accumulated durations of the recorded events, slowest first.
+func (s *CommandsStatistics) Breakdown() []BreakdownEntry {
+	type key struct{ category, name string }
+	entries := map[key]*BreakdownEntry{}
+	for _, event := range s.Events {
+		eventKey := key{event.Category, event.Name}
+		entry, exists := entries[eventKey]
+		if !exists {
+			entry = &BreakdownEntry{Category: event.Category, Name: event.Name, Count: 0, Total: 0}
+			entries[eventKey] = entry
+		}
+		entry.Count++
+		entry.Total += event.Duration
+	}
+	result := make([]BreakdownEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, *entry)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].Total != result[b].Total {
+			return result[a].Total > result[b].Total
+		}
+		if result[a].Category != result[b].Category {
+			return result[a].Category < result[b].Category
+		}
+		return result[a].Name < result[b].Name
+	})
+	return result
+}
+
+// traceEvent is a "complete" event in the Chrome trace event format,
+// see https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU.
+type traceEvent struct {
+	Name     string `json:"name"`
+	Category string `json:"cat"`
+	Phase    string `json:"ph"`
+	Start    int64  `json:"ts"`  // microseconds since the start of the trace
+	Duration int64  `json:"dur"` // microseconds
+	Process  int    `json:"pid"`
+	Thread   int    `json:"tid"`
+}
+
+// Trace provides the recorded events in the Chrome trace event format.
+// Viewers display each category on its own row.
+// Overlapping events of the same category, like commands that run in parallel, go into additional rows.
+func (s *CommandsStatistics) Trace() ([]byte, error) {
+	events := make([]TimedEvent, len(s.Events))
+	copy(events, s.Events)
+	sort.SliceStable(events, func(a, b int) bool { return events[a].Start.Before(events[b].Start) })
+	rowEnds := map[string][]time.Time{} // for each category, when the last event in each of its rows ends
+	rowIDs := map[string]int{}          // for each category, the ID of its first row
+	traceEvents := make([]traceEvent, 0, len(events))
+	for _, event := range events {
+		if _, exists := rowIDs[event.Category]; !exists {
+			rowIDs[event.Category] = (len(rowIDs) + 1) * 100
+		}
+		ends := rowEnds[event.Category]
+		row := 0
+		for row < len(ends) && ends[row].After(event.Start) {
+			row++
+		}
+		end := event.Start.Add(event.Duration)
+		if row == len(ends) {
+			rowEnds[event.Category] = append(ends, end)
+		} else {
+			ends[row] = end
+		}
+		traceEvents = append(traceEvents, traceEvent{
+			Name:     event.Name,
+			Category: event.Category,
+			Phase:    "X",
+			Start:    event.Start.Sub(s.start).Microseconds(),
+			Duration: event.Duration.Microseconds(),
+			Process:  1,
+			Thread:   rowIDs[event.Category] + row,
+		})
+	}
+	return json.MarshalIndent(map[string][]traceEvent{"traceEvents": traceEvents}, "", "  ")
+}
+
+// WriteTrace writes the recorded events in the Chrome trace event format into the given file.
+func (s *CommandsStatistics) WriteTrace(path string) error {
+	content, err := s.Trace()
+	if err != nil {
+		return fmt.Errorf("cannot serialize the trace: %w", err)
+	}
+	err = os.WriteFile(path, content, 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot write the trace to %q: %w", path, err)
+	}
+	return nil
 }
 
 // NoStatistics is a statistics implementation that does nothing.
 type NoStatistics struct{}
 
-func (s *NoStatistics) RegisterRun() {}
+func (s *NoStatistics) RegisterAPICall(string, time.Time, time.Duration) {}
+
+func (s *NoStatistics) RegisterCommand(string, int, time.Time, time.Duration) {}
+
+func (s *NoStatistics) RegisterStep(string, time.Time, time.Duration) {}
 
 func (s *NoStatistics) PrintAnalysis() {}
+
+// LoggedStatistics is a Statistics implementation that also records the executed commands and API calls in the run log.
+type LoggedStatistics struct {
+	Statistics
+	Log *runlog.Log // nil if the run log is disabled
+}
+
+func (s *LoggedStatistics) RegisterAPICall(name string, start time.Time, duration time.Duration) {
+	s.Statistics.RegisterAPICall(name, start, duration)
+	s.Log.LogAPICall(name, start, duration)
+}
+
+func (s *LoggedStatistics) RegisterCommand(command string, exitCode int, start time.Time, duration time.Duration) {
+	s.Statistics.RegisterCommand(command, exitCode, start, duration)
+	s.Log.LogCommand(command, exitCode, start, duration)
+}
@@ -0,0 +1,65 @@
+package execute_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/git-town/git-town/v8/src/execute"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandsStatistics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Breakdown", func(t *testing.T) {
+		t.Parallel()
+		stats := execute.NewCommandsStatistics("")
+		start := time.Now()
+		stats.RegisterCommand("git status", 0, start, 2*time.Millisecond)
+		stats.RegisterStep("CheckoutStep", start, 5*time.Millisecond)
+		stats.RegisterCommand("git status", 0, start, 4*time.Millisecond)
+		stats.RegisterAPICall("GitHub FindProposal", start, 100*time.Millisecond)
+		have := stats.Breakdown()
+		want := []execute.BreakdownEntry{
+			{Category: "api call", Name: "GitHub FindProposal", Count: 1, Total: 100 * time.Millisecond},
+			{Category: "command", Name: "git status", Count: 2, Total: 6 * time.Millisecond},
+			{Category: "step", Name: "CheckoutStep", Count: 1, Total: 5 * time.Millisecond},
+		}
+		assert.Equal(t, want, have)
+		assert.Equal(t, 2, stats.CommandsCount)
+	})
+
+	t.Run("Trace", func(t *testing.T) {
+		t.Parallel()
+		stats := execute.NewCommandsStatistics("")
+		start := time.Now().Add(time.Second)
+		stats.RegisterStep("SyncStep", start, 30*time.Millisecond)
+		stats.RegisterCommand("git fetch origin", 0, start, 20*time.Millisecond)
+		stats.RegisterCommand("git fetch upstream", 0, start.Add(5*time.Millisecond), 10*time.Millisecond)
+		stats.RegisterCommand("git status", 0, start.Add(25*time.Millisecond), time.Millisecond)
+		content, err := stats.Trace()
+		assert.NoError(t, err)
+		var trace struct {
+			TraceEvents []struct {
+				Name     string `json:"name"`
+				Category string `json:"cat"`
+				Phase    string `json:"ph"`
+				Duration int64  `json:"dur"`
+				Thread   int    `json:"tid"`
+			} `json:"traceEvents"`
+		}
+		err = json.Unmarshal(content, &trace)
+		assert.NoError(t, err)
+		assert.Len(t, trace.TraceEvents, 4)
+		threads := map[string]int{}
+		for _, event := range trace.TraceEvents {
+			assert.Equal(t, "X", event.Phase)
+			threads[event.Name] = event.Thread
+		}
+		assert.Equal(t, threads["git fetch origin"], threads["git status"], "sequential commands share a row")
+		assert.NotEqual(t, threads["git fetch origin"], threads["git fetch upstream"], "overlapping commands get separate rows")
+		assert.NotEqual(t, threads["SyncStep"], threads["git fetch origin"], "steps and commands get separate rows")
+		assert.Equal(t, int64(30000), trace.TraceEvents[0].Duration)
+	})
+}
@@ -46,6 +46,13 @@ func (ec *Collector) HostingService(value config.HostingService, err error) conf
 	return value
 }
 
+// OfflineMode provides the config.OfflineMode part of the given fallible function result
+// while registering the given error.
+func (ec *Collector) OfflineMode(value config.OfflineMode, err error) config.OfflineMode {
+	ec.Check(err)
+	return value
+}
+
 // PullBranchStrategy provides the string part of the given fallible function result
 // while registering the given error.
 func (ec *Collector) PullBranchStrategy(value config.PullBranchStrategy, err error) config.PullBranchStrategy {
@@ -80,6 +80,25 @@ func TestCollector(t *testing.T) {
 		})
 	})
 
+	t.Run("OfflineMode", func(t *testing.T) {
+		t.Parallel()
+		t.Run("returns the given OfflineMode value", func(t *testing.T) {
+			t.Parallel()
+			fc := failure.Collector{}
+			assert.Equal(t, config.OfflineModeAuto, fc.OfflineMode(config.OfflineModeAuto, nil))
+			assert.Equal(t, config.OfflineModeOn, fc.OfflineMode(config.OfflineModeOn, errors.New("")))
+		})
+		t.Run("captures the first error it receives", func(t *testing.T) {
+			t.Parallel()
+			fc := failure.Collector{}
+			fc.OfflineMode(config.OfflineModeOff, nil)
+			assert.Nil(t, fc.Err)
+			fc.OfflineMode(config.OfflineModeOff, errors.New("first"))
+			fc.OfflineMode(config.OfflineModeOff, errors.New("second"))
+			assert.Error(t, fc.Err, "first")
+		})
+	})
+
 	t.Run("PullBranchStrategy", func(t *testing.T) {
 		t.Parallel()
 		t.Run("returns the given PullBranchStrategy value", func(t *testing.T) {
@@ -1,6 +1,31 @@
 package flags
 
-// DryRun provides mistake-safe access to the "--debug" Cobra command-line flag.
-func Debug() (AddFunc, ReadBoolFlagFunc) {
-	return Bool("debug", "d", "Print all Git commands run under the hood")
+import "github.com/spf13/cobra"
+
+// DebugArgs contains the values of the command-line flags that configure debug output.
+type DebugArgs struct {
+	Enabled   bool   // whether to print all Git commands run under the hood and how long they took
+	TraceFile string // the file to write a Chrome trace of the run into, empty if none
+}
+
+// Debug provides mistake-safe access to the "--debug" and "--trace-file" Cobra command-line flags.
+// Providing a trace file enables debug output.
+func Debug() (AddFunc, ReadDebugFlagFunc) {
+	addDebugFlag, readDebugFlag := Bool("debug", "d", "Print all Git commands run under the hood and how long they took")
+	addTraceFileFlag, readTraceFileFlag := String("trace-file", "", "", "Write a Chrome trace of this run into the given file, implies --debug")
+	addFlags := func(cmd *cobra.Command) {
+		addDebugFlag(cmd)
+		addTraceFileFlag(cmd)
+	}
+	readFlags := func(cmd *cobra.Command) DebugArgs {
+		traceFile := readTraceFileFlag(cmd)
+		return DebugArgs{
+			Enabled:   readDebugFlag(cmd) || traceFile != "",
+			TraceFile: traceFile,
+		}
+	}
+	return addFlags, readFlags
 }
+
+// ReadDebugFlagFunc defines the type signature for helper functions that provide the values of the debug flags associated with a Cobra command.
+type ReadDebugFlagFunc func(*cobra.Command) DebugArgs
@@ -17,7 +17,7 @@ func TestDebug(t *testing.T) {
 		addFlag(&cmd)
 		err := cmd.ParseFlags([]string{"--debug"})
 		assert.NoError(t, err)
-		assert.Equal(t, true, readFlag(&cmd))
+		assert.Equal(t, flags.DebugArgs{Enabled: true, TraceFile: ""}, readFlag(&cmd))
 	})
 
 	t.Run("short version", func(t *testing.T) {
@@ -27,6 +27,15 @@ func TestDebug(t *testing.T) {
 		addFlag(&cmd)
 		err := cmd.ParseFlags([]string{"-d"})
 		assert.NoError(t, err)
-		assert.Equal(t, true, readFlag(&cmd))
+		assert.Equal(t, flags.DebugArgs{Enabled: true, TraceFile: ""}, readFlag(&cmd))
+	})
+	t.Run("trace file", func(t *testing.T) {
+		t.Parallel()
+		cmd := cobra.Command{}
+		addFlag, readFlag := flags.Debug()
+		addFlag(&cmd)
+		err := cmd.ParseFlags([]string{"--trace-file", "trace.json"})
+		assert.NoError(t, err)
+		assert.Equal(t, flags.DebugArgs{Enabled: true, TraceFile: "trace.json"}, readFlag(&cmd))
 	})
 }
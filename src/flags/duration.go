@@ -0,0 +1,27 @@
+package flags
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Duration provides mistake-safe access to duration Cobra command-line flags.
+// The flag is zero if not given.
+func Duration(name, short string, desc string) (AddFunc, ReadDurationFlagFunc) {
+	addFlag := func(cmd *cobra.Command) {
+		cmd.PersistentFlags().DurationP(name, short, 0, desc)
+	}
+	readFlag := func(cmd *cobra.Command) time.Duration {
+		value, err := cmd.Flags().GetDuration(name)
+		if err != nil {
+			panic(fmt.Sprintf("command %q does not have a duration %q flag", cmd.Name(), name))
+		}
+		return value
+	}
+	return addFlag, readFlag
+}
+
+// ReadDurationFlagFunc defines the type signature for helper functions that provide the value a duration CLI flag associated with a Cobra command.
+type ReadDurationFlagFunc func(*cobra.Command) time.Duration
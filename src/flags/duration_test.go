@@ -0,0 +1,44 @@
+package flags_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/git-town/git-town/v8/src/flags"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuration(t *testing.T) {
+	t.Parallel()
+	t.Run("with value", func(t *testing.T) {
+		t.Parallel()
+		cmd := cobra.Command{}
+		addFlag, readFlag := flags.Duration("myflag", "m", "desc")
+		addFlag(&cmd)
+		err := cmd.ParseFlags([]string{"--myflag=90s"})
+		assert.NoError(t, err)
+		assert.Equal(t, 90*time.Second, readFlag(&cmd))
+	})
+
+	t.Run("with separate value", func(t *testing.T) {
+		t.Parallel()
+		cmd := cobra.Command{}
+		addFlag, readFlag := flags.Duration("myflag", "m", "desc")
+		addFlag(&cmd)
+		err := cmd.ParseFlags([]string{"--myflag", "5m", "branch"})
+		assert.NoError(t, err)
+		assert.Equal(t, 5*time.Minute, readFlag(&cmd))
+		assert.Equal(t, []string{"branch"}, cmd.Flags().Args())
+	})
+
+	t.Run("not given", func(t *testing.T) {
+		t.Parallel()
+		cmd := cobra.Command{}
+		addFlag, readFlag := flags.Duration("myflag", "m", "desc")
+		addFlag(&cmd)
+		err := cmd.ParseFlags([]string{})
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), readFlag(&cmd))
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/git-town/git-town/v8/src/config"
 	"github.com/git-town/git-town/v8/src/stringslice"
@@ -15,6 +16,8 @@ import (
 
 type BackendRunner interface {
 	Query(executable string, args ...string) (string, error)
+	QueryWithInput(input string, env []string, executable string, args ...string) (string, error)
+	QueryWithTimeout(timeout time.Duration, env []string, executable string, args ...string) (string, error)
 	Run(executable string, args ...string) error
 	RunMany([][]string) error
 }
@@ -66,6 +69,50 @@ func (bc *BackendCommands) BranchHasUnmergedCommits(branch, parent string) (bool
 	return out != "", nil
 }
 
+// BranchesSnapshot provides the branches of this repo and their SHAs.
+// It loads them with a single Git command and caches them until a frontend command changes the repo.
+func (bc *BackendCommands) BranchesSnapshot() (BranchesSnapshot, error) {
+	snapshotCache := bc.Config.BranchesSnapshotCache
+	if snapshotCache != nil && snapshotCache.Initialized() {
+		return snapshotCache.Value(), nil
+	}
+	output, err := bc.Query("git", "for-each-ref", branchesSnapshotFormat, "refs/heads", "refs/remotes")
+	if err != nil {
+		return ParseBranchesSnapshot(""), fmt.Errorf("cannot determine the branches: %w", err)
+	}
+	snapshot := ParseBranchesSnapshot(output)
+	if snapshotCache != nil {
+		snapshotCache.Set(snapshot)
+	}
+	return snapshot, nil
+}
+
+// BranchesInOtherWorktrees provides the branches that are checked out in other worktrees of this repository,
+// mapped to the directory of the respective worktree.
+func (bc *BackendCommands) BranchesInOtherWorktrees() (map[string]string, error) {
+	output, err := bc.Query("git", "worktree", "list", "--porcelain")
+	if err != nil {
+		return map[string]string{}, fmt.Errorf("cannot determine worktrees: %w", err)
+	}
+	rootDir, err := bc.RootDirectory()
+	if err != nil {
+		return map[string]string{}, err
+	}
+	result := map[string]string{}
+	worktreeDir := ""
+	for _, line := range stringslice.Lines(output) {
+		switch {
+		case strings.HasPrefix(line, "worktree "):
+			worktreeDir = filepath.FromSlash(strings.TrimPrefix(line, "worktree "))
+		case strings.HasPrefix(line, "branch refs/heads/"):
+			if filepath.Clean(worktreeDir) != filepath.Clean(rootDir) {
+				result[strings.TrimPrefix(line, "branch refs/heads/")] = worktreeDir
+			}
+		}
+	}
+	return result, nil
+}
+
 // CheckoutBranch checks out the Git branch with the given name.
 func (bc *BackendCommands) CheckoutBranch(name string) error {
 	if !bc.Config.DryRun {
@@ -82,6 +129,38 @@ func (bc *BackendCommands) CheckoutBranch(name string) error {
 	return nil
 }
 
+// SwitchArgs contains the arguments for BackendCommands.SwitchBranch.
+type SwitchArgs struct {
+	// the branch to switch to
+	Branch string
+	// carry uncommitted changes over to the branch via a three-way merge
+	Merge bool `exhaustruct:"optional"`
+	// create a local branch that tracks the branch with the same name at origin
+	Track bool `exhaustruct:"optional"`
+}
+
+// SwitchBranch checks out the given branch, creating a local tracking branch if requested.
+func (bc *BackendCommands) SwitchBranch(args SwitchArgs) error {
+	gitArgs := []string{"checkout"}
+	if args.Merge {
+		gitArgs = append(gitArgs, "-m")
+	}
+	if args.Track {
+		gitArgs = append(gitArgs, "-b", args.Branch, "--track", config.OriginRemote+"/"+args.Branch)
+	} else {
+		gitArgs = append(gitArgs, args.Branch)
+	}
+	err := bc.Run("git", gitArgs...)
+	if err != nil {
+		return fmt.Errorf("cannot check out branch %q: %w", args.Branch, err)
+	}
+	if args.Track {
+		bc.Config.InvalidateBranchesSnapshot()
+	}
+	bc.Config.CurrentBranchCache.Set(args.Branch)
+	return nil
+}
+
 // CommentOutSquashCommitMessage comments out the message for the current squash merge
 // Adds the given prefix with the newline if provided.
 func (bc *BackendCommands) CommentOutSquashCommitMessage(prefix string) error {
@@ -98,6 +177,49 @@ func (bc *BackendCommands) CommentOutSquashCommitMessage(prefix string) error {
 	return os.WriteFile(squashMessageFile, []byte(content), 0o600)
 }
 
+// CommitMessage provides the full commit message of the commit with the given SHA.
+func (bc *BackendCommands) CommitMessage(sha string) (string, error) {
+	output, err := bc.Query("git", "show", "--no-patch", "--format=%B", sha)
+	if err != nil {
+		return "", fmt.Errorf("cannot determine the message of commit %q: %w", sha, err)
+	}
+	return output, nil
+}
+
+// CommitsInBranch provides the SHAs of the commits in the given branch
+// that are not in the given base, oldest first.
+func (bc *BackendCommands) CommitsInBranch(branch, base string) ([]string, error) {
+	output, err := bc.Query("git", "rev-list", "--reverse", base+".."+branch)
+	if err != nil {
+		return []string{}, fmt.Errorf("cannot determine the commits in branch %q: %w", branch, err)
+	}
+	if output == "" {
+		return []string{}, nil
+	}
+	return stringslice.Lines(output), nil
+}
+
+// CommitTree creates a commit with the given tree and parents and provides its SHA.
+// The commit exists only in the object database, no branch points to it.
+func (bc *BackendCommands) CommitTree(tree string, parents ...string) (string, error) {
+	args := []string{"commit-tree", tree, "-m", "Git Town simulation"}
+	for _, parent := range parents {
+		args = append(args, "-p", parent)
+	}
+	// the simulated commit doesn't need the identity of the user
+	env := []string{
+		"GIT_AUTHOR_NAME=Git Town",
+		"GIT_AUTHOR_EMAIL=git-town@localhost",
+		"GIT_COMMITTER_NAME=Git Town",
+		"GIT_COMMITTER_EMAIL=git-town@localhost",
+	}
+	output, err := bc.QueryWithInput("", env, "git", args...)
+	if err != nil {
+		return "", fmt.Errorf("cannot create a commit for tree %q: %w", tree, err)
+	}
+	return output, nil
+}
+
 // CreateFeatureBranch creates a feature branch with the given name in this repository.
 func (bc *BackendCommands) CreateFeatureBranch(name string) error {
 	err := bc.RunMany([][]string{
@@ -107,9 +229,28 @@ func (bc *BackendCommands) CreateFeatureBranch(name string) error {
 	if err != nil {
 		return fmt.Errorf("cannot create feature branch %q: %w", name, err)
 	}
+	bc.Config.InvalidateBranchesSnapshot()
 	return nil
 }
 
+// CredentialPassword provides the password that the Git credential helpers of the user
+// store for the given protocol and host, or an empty string if they don't store one.
+// This never asks the user for credentials.
+func (bc *BackendCommands) CredentialPassword(protocol, host string) string {
+	input := fmt.Sprintf("protocol=%s\nhost=%s\n\n", protocol, host)
+	env := []string{"GIT_TERMINAL_PROMPT=0", "GIT_ASKPASS=", "SSH_ASKPASS=", "GCM_INTERACTIVE=never"}
+	output, err := bc.QueryWithInput(input, env, "git", "credential", "fill")
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "password=") {
+			return strings.TrimPrefix(line, "password=")
+		}
+	}
+	return ""
+}
+
 // CurrentBranch provides the currently checked out branch.
 func (bc *BackendCommands) CurrentBranch() (string, error) {
 	if bc.Config.DryRun {
@@ -157,7 +298,11 @@ func (bc *BackendCommands) currentBranchDuringRebase() (string, error) {
 
 // CurrentSha provides the SHA of the currently checked out branch/commit.
 func (bc *BackendCommands) CurrentSha() (string, error) {
-	return bc.ShaForBranch("HEAD")
+	output, err := bc.Query("git", "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("cannot determine the current SHA: %w", err)
+	}
+	return output, nil
 }
 
 // ExpectedPreviouslyCheckedOutBranch returns what is the expected previously checked out branch
@@ -184,6 +329,30 @@ func (bc *BackendCommands) ExpectedPreviouslyCheckedOutBranch(initialPreviouslyC
 	return mainBranch, nil
 }
 
+// GitDirectory provides the absolute path of the ".git" directory of the current repository.
+// In linked worktrees, this is the ".git" directory of the main worktree.
+func (bc *BackendCommands) GitDirectory() (string, error) {
+	rootDir, err := bc.RootDirectory()
+	if err != nil {
+		return "", err
+	}
+	gitDir := filepath.Join(rootDir, ".git")
+	if info, err := os.Stat(gitDir); err == nil && info.IsDir() {
+		return gitDir, nil
+	}
+	// in linked worktrees ".git" is a file that points elsewhere, so ask Git.
+	// Git provides this path relative to the directory it runs in, so run it in a known directory.
+	output, err := bc.Query("git", "-C", rootDir, "rev-parse", "--git-common-dir")
+	if err != nil {
+		return "", fmt.Errorf("cannot determine the Git directory: %w", err)
+	}
+	gitDir = filepath.FromSlash(output)
+	if filepath.IsAbs(gitDir) {
+		return gitDir, nil
+	}
+	return filepath.Join(rootDir, gitDir), nil
+}
+
 // HasConflicts returns whether the local repository currently has unresolved merge conflicts.
 func (bc *BackendCommands) HasConflicts() (bool, error) {
 	output, err := bc.Query("git", "status")
@@ -272,12 +441,7 @@ func (bc *BackendCommands) HasTrackingBranch(name string) (bool, error) {
 	if err != nil {
 		return false, fmt.Errorf("cannot determine if tracking branch %q exists: %w", name, err)
 	}
-	for _, line := range remoteBranches {
-		if strings.TrimSpace(line) == trackingBranch {
-			return true, nil
-		}
-	}
-	return false, nil
+	return stringslice.Contains(remoteBranches, trackingBranch), nil
 }
 
 // IsBranchInSync returns whether the branch with the given name is in sync with its tracking branch.
@@ -315,23 +479,24 @@ func (bc *BackendCommands) LastCommitMessage() (string, error) {
 	return out, nil
 }
 
-// LocalAndOriginBranches provides the names of all local branches in this repo.
+// LocalAndOriginBranches provides the names of all local branches and branches at origin in this repo.
 func (bc *BackendCommands) LocalAndOriginBranches(mainBranch string) ([]string, error) {
-	output, err := bc.Query("git", "branch", "-a")
+	snapshot, err := bc.BranchesSnapshot()
 	if err != nil {
 		return []string{}, fmt.Errorf("cannot determine the local branches")
 	}
-	branch := make(map[string]struct{})
-	for _, line := range stringslice.Lines(output) {
-		if !strings.Contains(line, " -> ") {
-			branch[strings.TrimSpace(strings.Replace(strings.Replace(line, "* ", "", 1), "remotes/origin/", "", 1))] = struct{}{}
+	branches := make(map[string]struct{})
+	for _, branch := range snapshot.LocalBranches {
+		branches[branch] = struct{}{}
+	}
+	for _, remoteBranch := range snapshot.RemoteBranches {
+		if strings.HasPrefix(remoteBranch, config.OriginRemote+"/") {
+			branches[strings.TrimPrefix(remoteBranch, config.OriginRemote+"/")] = struct{}{}
 		}
 	}
-	result := make([]string, len(branch))
-	i := 0
-	for branch := range branch {
-		result[i] = branch
-		i++
+	result := make([]string, 0, len(branches))
+	for branch := range branches {
+		result = append(result, branch)
 	}
 	sort.Strings(result)
 	return stringslice.Hoist(result, mainBranch), nil
@@ -340,17 +505,11 @@ func (bc *BackendCommands) LocalAndOriginBranches(mainBranch string) ([]string,
 // LocalBranches provides the names of all branches in the local repository,
 // ordered alphabetically.
 func (bc *BackendCommands) LocalBranches() ([]string, error) {
-	output, err := bc.Query("git", "branch")
+	snapshot, err := bc.BranchesSnapshot()
 	if err != nil {
 		return []string{}, err
 	}
-	result := []string{}
-	for _, line := range stringslice.Lines(output) {
-		line = strings.Trim(line, "* ")
-		line = strings.TrimSpace(line)
-		result = append(result, line)
-	}
-	return result, nil
+	return snapshot.LocalBranches, nil
 }
 
 // LocalBranchesMainFirst provides the names of all local branches in this repo.
@@ -371,7 +530,7 @@ func (bc *BackendCommands) LocalBranchesWithDeletedTrackingBranches() ([]string,
 	}
 	result := []string{}
 	for _, line := range stringslice.Lines(output) {
-		line = strings.Trim(line, "* ")
+		line = strings.Trim(line, "*+ ") // "+" marks branches checked out in other worktrees
 		parts := strings.SplitN(line, " ", 2)
 		branch := parts[0]
 		deleteTrackingBranchStatus := fmt.Sprintf("[%s: gone]", bc.TrackingBranch(branch))
@@ -398,6 +557,46 @@ func (bc *BackendCommands) LocalBranchesWithoutMain(mainBranch string) ([]string
 	return result, nil
 }
 
+// MergeBase provides the SHA of the most recent common ancestor of the two given branches.
+func (bc *BackendCommands) MergeBase(branch, otherBranch string) (string, error) {
+	output, err := bc.Query("git", "merge-base", branch, otherBranch)
+	if err != nil {
+		return "", fmt.Errorf("cannot determine the merge base of %q and %q: %w", branch, otherBranch, err)
+	}
+	return output, nil
+}
+
+// MergeTreeResult is the outcome of merging two commits in the object database.
+type MergeTreeResult struct {
+	Tree             string   // SHA of the tree that the merge results in, including conflict markers
+	ConflictingFiles []string // the files that the merge cannot resolve automatically
+}
+
+// MergeTree merges the given commits in the object database without touching the working tree.
+// This requires Git 2.38 or later.
+func (bc *BackendCommands) MergeTree(commit, otherCommit string) (MergeTreeResult, error) {
+	objectNameRE := regexp.MustCompile(`^[0-9a-f]{40}([0-9a-f]{24})?$`)
+	output, err := bc.Query("git", "merge-tree", "--write-tree", "--name-only", "--no-messages", commit, otherCommit)
+	lines := []string{}
+	if output != "" {
+		lines = stringslice.Lines(output)
+	}
+	// Git exits with an error if the merge has conflicts, the output then lists the conflicting files after the tree
+	if len(lines) == 0 || !objectNameRE.MatchString(lines[0]) || (err != nil && len(lines) == 1) {
+		if err == nil {
+			err = fmt.Errorf("unexpected output: %q", output)
+		}
+		return MergeTreeResult{Tree: "", ConflictingFiles: []string{}}, fmt.Errorf("cannot merge %q and %q: %w", commit, otherCommit, err)
+	}
+	conflictingFiles := []string{}
+	for _, file := range lines[1:] {
+		if !stringslice.Contains(conflictingFiles, file) {
+			conflictingFiles = append(conflictingFiles, file)
+		}
+	}
+	return MergeTreeResult{Tree: lines[0], ConflictingFiles: conflictingFiles}, nil
+}
+
 // PreviouslyCheckedOutBranch provides the name of the branch that was previously checked out in this repo.
 func (bc *BackendCommands) PreviouslyCheckedOutBranch() (string, error) {
 	output, err := bc.Query("git", "rev-parse", "--verify", "--abbrev-ref", "@{-1}")
@@ -409,21 +608,11 @@ func (bc *BackendCommands) PreviouslyCheckedOutBranch() (string, error) {
 
 // RemoteBranches provides the names of the remote branches in this repo.
 func (bc *BackendCommands) RemoteBranches() ([]string, error) {
-	if !bc.Config.RemoteBranchCache.Initialized() {
-		output, err := bc.Query("git", "branch", "-r")
-		if err != nil {
-			return []string{}, fmt.Errorf("cannot determine remote branches: %w", err)
-		}
-		lines := stringslice.Lines(output)
-		branches := make([]string, 0, len(lines)-1)
-		for _, line := range lines {
-			if !strings.Contains(line, " -> ") {
-				branches = append(branches, strings.TrimSpace(line))
-			}
-		}
-		bc.Config.RemoteBranchCache.Set(branches)
+	snapshot, err := bc.BranchesSnapshot()
+	if err != nil {
+		return []string{}, fmt.Errorf("cannot determine remote branches: %w", err)
 	}
-	return bc.Config.RemoteBranchCache.Value(), nil
+	return snapshot.RemoteBranches, nil
 }
 
 // Remotes provides the names of all Git remotes in this repository.
@@ -474,8 +663,15 @@ func (bc *BackendCommands) RootDirectory() (string, error) {
 	return bc.Config.RootDirCache.Value(), nil
 }
 
-// ShaForBranch provides the SHA for the local branch with the given name.
+// ShaForBranch provides the SHA for the local or remote branch with the given name.
 func (bc *BackendCommands) ShaForBranch(name string) (string, error) {
+	snapshot, err := bc.BranchesSnapshot()
+	if err != nil {
+		return "", err
+	}
+	if sha, hasBranch := snapshot.Shas[name]; hasBranch {
+		return sha, nil
+	}
 	output, err := bc.Query("git", "rev-parse", name)
 	if err != nil {
 		return "", fmt.Errorf("cannot determine SHA of local branch %q: %w", name, err)
@@ -504,10 +700,14 @@ func (bc *BackendCommands) TrackingBranch(branch string) string {
 //nolint:nonamedreturns  // multiple int return values justify using names for return values
 func (bc *BackendCommands) Version() (major int, minor int, err error) {
 	versionRegexp := regexp.MustCompile(`git version (\d+).(\d+).(\d+)`)
-	output, err := bc.Query("git", "version")
-	if err != nil {
-		return 0, 0, fmt.Errorf("cannot determine Git version: %w", err)
+	if !bc.Config.GitVersionCache.Initialized() {
+		output, queryErr := bc.Query("git", "version")
+		if queryErr != nil {
+			return 0, 0, fmt.Errorf("cannot determine Git version: %w", queryErr)
+		}
+		bc.Config.GitVersionCache.Set(output)
 	}
+	output := bc.Config.GitVersionCache.Value()
 	matches := versionRegexp.FindStringSubmatch(output)
 	if matches == nil {
 		return 0, 0, fmt.Errorf("'git version' returned unexpected output: %q.\nPlease open an issue and supply the output of running 'git version'", output)
@@ -0,0 +1,54 @@
+package git
+
+import (
+	"strings"
+
+	"github.com/git-town/git-town/v8/src/stringslice"
+)
+
+// BranchesSnapshot describes the branches of a Git repository at a point in time.
+// Git Town loads it with a single "git for-each-ref" call
+// and answers queries about branches and their SHAs from memory.
+type BranchesSnapshot struct {
+	LocalBranches  []string          // names of the local branches, ordered alphabetically
+	RemoteBranches []string          // names of the remote branches, e.g. "origin/main", ordered alphabetically
+	Shas           map[string]string // SHA of each local and remote branch
+}
+
+// branchesSnapshotFormat is the format in which "git for-each-ref" provides the data for ParseBranchesSnapshot.
+// The symref field is empty for regular branches and therefore can't be at the end of the line,
+// where it would get trimmed away.
+const branchesSnapshotFormat = "--format=%(refname) %(symref) %(objectname)"
+
+// ParseBranchesSnapshot parses the output of "git for-each-ref" in branchesSnapshotFormat.
+func ParseBranchesSnapshot(output string) BranchesSnapshot {
+	result := BranchesSnapshot{
+		LocalBranches:  []string{},
+		RemoteBranches: []string{},
+		Shas:           map[string]string{},
+	}
+	for _, line := range stringslice.Lines(output) {
+		parts := strings.Split(line, " ")
+		if len(parts) != 3 {
+			continue
+		}
+		refName, symRef, sha := parts[0], parts[1], parts[2]
+		if symRef != "" {
+			// symbolic refs like "origin/HEAD" aren't branches
+			continue
+		}
+		switch {
+		case strings.HasPrefix(refName, "refs/heads/"):
+			branch := strings.TrimPrefix(refName, "refs/heads/")
+			result.LocalBranches = append(result.LocalBranches, branch)
+			result.Shas[branch] = sha
+		case strings.HasPrefix(refName, "refs/remotes/"):
+			branch := strings.TrimPrefix(refName, "refs/remotes/")
+			result.RemoteBranches = append(result.RemoteBranches, branch)
+			if _, hasLocalBranch := result.Shas[branch]; !hasLocalBranch {
+				result.Shas[branch] = sha
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,47 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBranchesSnapshot(t *testing.T) {
+	t.Parallel()
+
+	t.Run("local and remote branches", func(t *testing.T) {
+		t.Parallel()
+		give := `
+refs/heads/feature  111111
+refs/heads/main  222222
+refs/remotes/origin/HEAD refs/remotes/origin/main 222222
+refs/remotes/origin/feature  333333
+refs/remotes/origin/main  222222
+refs/remotes/upstream/main  444444`[1:]
+		have := git.ParseBranchesSnapshot(give)
+		want := git.BranchesSnapshot{
+			LocalBranches:  []string{"feature", "main"},
+			RemoteBranches: []string{"origin/feature", "origin/main", "upstream/main"},
+			Shas: map[string]string{
+				"feature":        "111111",
+				"main":           "222222",
+				"origin/feature": "333333",
+				"origin/main":    "222222",
+				"upstream/main":  "444444",
+			},
+		}
+		assert.Equal(t, want, have)
+	})
+
+	t.Run("empty repo", func(t *testing.T) {
+		t.Parallel()
+		have := git.ParseBranchesSnapshot("")
+		want := git.BranchesSnapshot{
+			LocalBranches:  []string{},
+			RemoteBranches: []string{},
+			Shas:           map[string]string{},
+		}
+		assert.Equal(t, want, have)
+	})
+}
@@ -1,29 +1,133 @@
 package git
 
 import (
+	"fmt"
+	"os"
+	"time"
+
 	"github.com/git-town/git-town/v8/src/cache"
+	"github.com/git-town/git-town/v8/src/cli"
 	"github.com/git-town/git-town/v8/src/config"
 )
 
+// OriginProbeTimeout is how long Git Town waits for origin to respond in automatic offline mode.
+const OriginProbeTimeout = 3 * time.Second
+
 // RepoConfig represents the known state of a Git repository.
 type RepoConfig struct {
 	*config.GitTown
-	CurrentBranchCache *cache.String  // caches the currently checked out Git branch
-	DryRun             bool           // single source of truth for whether to dry-run Git commands in this repo
-	IsRepoCache        *cache.Bool    // caches whether the current directory is a Git repo
-	RemoteBranchCache  *cache.Strings // caches the remote branches of this Git repo
-	RemotesCache       *cache.Strings // caches Git remotes
-	RootDirCache       *cache.String  // caches the base of the Git directory
+	BranchesSnapshotCache *cache.Cache[BranchesSnapshot] // caches the branches of this Git repo, nil disables caching
+	CurrentBranchCache    *cache.String                  // caches the currently checked out Git branch
+	DryRun                bool                           // single source of truth for whether to dry-run Git commands in this repo
+	GitVersionCache       *cache.String                  // caches the output of "git version"
+	IsOfflineCache        *cache.Bool                    // caches whether Git Town runs offline in automatic offline mode
+	IsRepoCache           *cache.Bool                    // caches whether the current directory is a Git repo
+	ProbeRunner           BackendRunner                  // runs the commands that check whether origin is reachable
+	RemotesCache          *cache.Strings                 // caches Git remotes
+	RootDirCache          *cache.String                  // caches the base of the Git directory
 }
 
 func NewRepoConfig(runner BackendRunner) RepoConfig {
 	return RepoConfig{
-		GitTown:            config.NewGitTown(runner),
-		CurrentBranchCache: &cache.String{},
-		DryRun:             false, // to bootstrap this, DryRun always gets initialized as false and later enabled if needed
-		IsRepoCache:        &cache.Bool{},
-		RemoteBranchCache:  &cache.Strings{},
-		RemotesCache:       &cache.Strings{},
-		RootDirCache:       &cache.String{},
+		GitTown:               config.NewGitTown(runner),
+		BranchesSnapshotCache: &cache.Cache[BranchesSnapshot]{},
+		CurrentBranchCache:    &cache.String{},
+		DryRun:                false, // to bootstrap this, DryRun always gets initialized as false and later enabled if needed
+		GitVersionCache:       &cache.String{},
+		IsOfflineCache:        &cache.Bool{},
+		IsRepoCache:           &cache.Bool{},
+		ProbeRunner:           runner,
+		RemotesCache:          &cache.Strings{},
+		RootDirCache:          &cache.String{},
+	}
+}
+
+// InvalidateBranchesSnapshot forgets the cached branches of this Git repo.
+// Call this after changing branches.
+func (rc *RepoConfig) InvalidateBranchesSnapshot() {
+	if rc.BranchesSnapshotCache != nil {
+		rc.BranchesSnapshotCache.Invalidate()
+	}
+}
+
+// IsOffline indicates whether Git Town should skip network operations.
+// In automatic offline mode, it checks once per run whether origin is reachable.
+func (rc *RepoConfig) IsOffline() (bool, error) {
+	mode, err := rc.OfflineMode()
+	if err != nil {
+		return false, err
+	}
+	switch mode {
+	case config.OfflineModeOn:
+		return true, nil
+	case config.OfflineModeOff:
+		return false, nil
+	case config.OfflineModeAuto:
 	}
+	wasDetected := rc.IsOfflineCache.Initialized()
+	isOffline := !rc.IsOriginReachable()
+	if isOffline && !wasDetected {
+		printOfflineWarning("cannot reach origin")
+	}
+	return isOffline, nil
+}
+
+// IsOriginReachable indicates whether origin responds within OriginProbeTimeout.
+// It checks this once per run.
+// Repositories without origin have no network operations and count as reachable.
+func (rc *RepoConfig) IsOriginReachable() bool {
+	if !rc.IsOfflineCache.Initialized() {
+		rc.IsOfflineCache.Set(!rc.probeOrigin())
+	}
+	return !rc.IsOfflineCache.Value()
+}
+
+// SwitchToOffline makes Git Town skip network operations for the rest of this run
+// after the given network operation failed, if the user has enabled automatic offline mode.
+// Returns the given error if automatic offline mode is disabled.
+func (rc *RepoConfig) SwitchToOffline(operation string, err error) error {
+	mode, modeErr := rc.OfflineMode()
+	if modeErr != nil || mode != config.OfflineModeAuto {
+		return err
+	}
+	rc.IsOfflineCache.Set(true)
+	printOfflineWarning(operation + " failed")
+	return nil
+}
+
+// probeOrigin indicates whether origin responds to a request within OriginProbeTimeout.
+func (rc *RepoConfig) probeOrigin() bool {
+	_, err := rc.ProbeRunner.Query("git", "remote", "get-url", config.OriginRemote)
+	if err != nil {
+		// the repo has no origin remote
+		return true
+	}
+	env := []string{"GIT_TERMINAL_PROMPT=0"}
+	if sshCommand := rc.sshCommand(); sshCommand != "" {
+		env = append(env, "GIT_SSH_COMMAND="+sshCommand+" -o BatchMode=yes")
+	}
+	_, err = rc.ProbeRunner.QueryWithTimeout(OriginProbeTimeout, env, "git", "ls-remote", config.OriginRemote, "HEAD")
+	return err == nil
+}
+
+// sshCommand provides the SSH command that Git uses to connect to origin,
+// following the same precedence as Git.
+// Returns an empty string if the user has configured an SSH program via GIT_SSH,
+// which doesn't accept additional options.
+func (rc *RepoConfig) sshCommand() string {
+	if command := os.Getenv("GIT_SSH_COMMAND"); command != "" {
+		return command
+	}
+	if command, err := rc.ProbeRunner.Query("git", "config", "core.sshCommand"); err == nil && command != "" {
+		return command
+	}
+	if os.Getenv("GIT_SSH") != "" {
+		return ""
+	}
+	return "ssh"
+}
+
+// printOfflineWarning tells the user that Git Town skips network operations for the given reason.
+func printOfflineWarning(reason string) {
+	cli.PrintWarning(fmt.Sprintf("%s, continuing in offline mode.\nGit Town skips network operations and remembers the pushes it skips for the next sync.", reason))
 }
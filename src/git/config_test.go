@@ -0,0 +1,97 @@
+package git_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/git-town/git-town/v8/src/config"
+	"github.com/git-town/git-town/v8/test/testruntime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run(".IsOffline()", func(t *testing.T) {
+		t.Parallel()
+		t.Run("offline mode enabled", func(t *testing.T) {
+			t.Parallel()
+			runtime := testruntime.Create(t)
+			assert.NoError(t, runtime.Config.SetOfflineMode(config.OfflineModeOn))
+			isOffline, err := runtime.Config.IsOffline()
+			assert.NoError(t, err)
+			assert.True(t, isOffline)
+		})
+		t.Run("automatic offline mode with reachable origin", func(t *testing.T) {
+			t.Parallel()
+			origin := testruntime.Create(t)
+			repo, err := testruntime.Clone(origin.TestRunner, filepath.Join(t.TempDir(), "repo"))
+			assert.NoError(t, err)
+			assert.NoError(t, repo.Config.SetOfflineMode(config.OfflineModeAuto))
+			isOffline, err := repo.Config.IsOffline()
+			assert.NoError(t, err)
+			assert.False(t, isOffline)
+		})
+		t.Run("automatic offline mode with unreachable origin", func(t *testing.T) {
+			t.Parallel()
+			runtime := testruntime.Create(t)
+			assert.NoError(t, runtime.Run("git", "remote", "add", config.OriginRemote, filepath.Join(t.TempDir(), "missing")))
+			assert.NoError(t, runtime.Config.SetOfflineMode(config.OfflineModeAuto))
+			isOffline, err := runtime.Config.IsOffline()
+			assert.NoError(t, err)
+			assert.True(t, isOffline)
+		})
+		t.Run("automatic offline mode keeps the SSH command of the user", func(t *testing.T) {
+			t.Parallel()
+			runtime := testruntime.Create(t)
+			dir := t.TempDir()
+			argsPath := filepath.Join(dir, "args")
+			scriptPath := filepath.Join(dir, "ssh.sh")
+			assert.NoError(t, os.WriteFile(scriptPath, []byte("echo \"$@\" >> '"+argsPath+"'\nexit 1\n"), 0o600))
+			assert.NoError(t, runtime.Run("git", "config", "core.sshCommand", "sh '"+scriptPath+"'"))
+			assert.NoError(t, runtime.Run("git", "remote", "add", config.OriginRemote, "ssh://git@example.com/repo.git"))
+			assert.NoError(t, runtime.Config.SetOfflineMode(config.OfflineModeAuto))
+			isOffline, err := runtime.Config.IsOffline()
+			assert.NoError(t, err)
+			assert.True(t, isOffline)
+			args, err := os.ReadFile(argsPath)
+			assert.NoError(t, err)
+			assert.Contains(t, string(args), "-o BatchMode=yes")
+			assert.Contains(t, string(args), "example.com")
+		})
+		t.Run("automatic offline mode without origin", func(t *testing.T) {
+			t.Parallel()
+			runtime := testruntime.Create(t)
+			assert.NoError(t, runtime.Config.SetOfflineMode(config.OfflineModeAuto))
+			isOffline, err := runtime.Config.IsOffline()
+			assert.NoError(t, err)
+			assert.False(t, isOffline)
+		})
+	})
+
+	t.Run(".SwitchToOffline()", func(t *testing.T) {
+		t.Parallel()
+		t.Run("automatic offline mode", func(t *testing.T) {
+			t.Parallel()
+			origin := testruntime.Create(t)
+			repo, err := testruntime.Clone(origin.TestRunner, filepath.Join(t.TempDir(), "repo"))
+			assert.NoError(t, err)
+			assert.NoError(t, repo.Config.SetOfflineMode(config.OfflineModeAuto))
+			assert.NoError(t, repo.Config.SwitchToOffline("fetching", errors.New("network down")))
+			isOffline, err := repo.Config.IsOffline()
+			assert.NoError(t, err)
+			assert.True(t, isOffline)
+		})
+		t.Run("offline mode disabled", func(t *testing.T) {
+			t.Parallel()
+			runtime := testruntime.Create(t)
+			fetchErr := errors.New("network down")
+			assert.Equal(t, fetchErr, runtime.Config.SwitchToOffline("fetching", fetchErr))
+			isOffline, err := runtime.Config.IsOffline()
+			assert.NoError(t, err)
+			assert.False(t, isOffline)
+		})
+	})
+}
@@ -3,13 +3,17 @@ package git
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/git-town/git-town/v8/src/config"
+	"github.com/git-town/git-town/v8/src/stringslice"
 )
 
 type FrontendRunner interface {
 	Run(executable string, args ...string) error
 	RunMany([][]string) error
+	RunParallel([][]string) error
+	RunWithEnv(env []string, executable string, args ...string) error
 }
 
 // FrontendCommands are Git commands that Git Town executes for the user to change the user's repository.
@@ -37,7 +41,13 @@ func (fc *FrontendCommands) AddGitAlias(aliasType config.AliasType) error {
 
 // CheckoutBranch checks out the Git branch with the given name in this repo.
 func (fc *FrontendCommands) CheckoutBranch(name string) error {
-	err := fc.Run("git", "checkout", name)
+	// checking out a branch that exists only at a remote creates a local tracking branch,
+	// checking out an existing local branch leaves the branches snapshot valid
+	snapshotCache := fc.Config.BranchesSnapshotCache
+	if snapshotCache == nil || !snapshotCache.Initialized() || !stringslice.Contains(snapshotCache.Value().LocalBranches, name) {
+		defer fc.Config.InvalidateBranchesSnapshot()
+	}
+	err := fc.FrontendRunner.Run("git", "checkout", name)
 	if err != nil {
 		return fmt.Errorf("cannot check out branch %q: %w", name, err)
 	}
@@ -89,6 +99,10 @@ func (fc *FrontendCommands) ContinueRebase() error {
 // The created branch is a normal branch.
 // To create feature branches, use CreateFeatureBranch.
 func (fc *FrontendCommands) CreateBranch(name, parent string) error {
+	if strings.HasPrefix(parent, config.OriginRemote+"/") {
+		// branches that start at a remote branch should not track it
+		return fc.Run("git", "branch", "--no-track", name, parent)
+	}
 	return fc.Run("git", "branch", name, parent)
 }
 
@@ -131,6 +145,15 @@ func (fc *FrontendCommands) FetchUpstream(branch string) error {
 	return fc.Run("git", "fetch", "upstream", branch)
 }
 
+// FetchWithUpstream retrieves the updates from the origin repo
+// and the given branch from the upstream remote at the same time.
+func (fc *FrontendCommands) FetchWithUpstream(upstreamBranch string) error {
+	return fc.RunParallel([][]string{
+		{"git", "fetch", "--prune", "--tags"},
+		{"git", "fetch", "upstream", upstreamBranch},
+	})
+}
+
 // MergeBranchNoEdit merges the given branch into the current branch,
 // using the default commit message.
 func (fc *FrontendCommands) MergeBranchNoEdit(branch string) error {
@@ -196,6 +219,12 @@ func (fc *FrontendCommands) Rebase(target string) error {
 	return fc.Run("git", "rebase", target)
 }
 
+// RebaseOnto moves the commits of the current branch that are not in the given base
+// onto the given new base.
+func (fc *FrontendCommands) RebaseOnto(newBase, oldBase string) error {
+	return fc.Run("git", "rebase", "--onto", newBase, oldBase)
+}
+
 // RemoveGitAlias removes the given Git alias.
 func (fc *FrontendCommands) RemoveGitAlias(aliasType config.AliasType) error {
 	return fc.Run("git", "config", "--global", "--unset", "alias."+string(aliasType))
@@ -211,11 +240,42 @@ func (fc *FrontendCommands) ResetToSha(sha string, hard bool) error {
 	return fc.Run("git", args...)
 }
 
+// ResetSoftToSha moves the current branch to the given SHA
+// while keeping the changes of the removed commits staged.
+func (fc *FrontendCommands) ResetSoftToSha(sha string) error {
+	return fc.Run("git", "reset", "--soft", sha)
+}
+
 // RevertCommit reverts the commit with the given SHA.
 func (fc *FrontendCommands) RevertCommit(sha string) error {
 	return fc.Run("git", "revert", sha)
 }
 
+// Run executes the given frontend command.
+// Frontend commands can change the branches of the repo, hence this forgets the cached branches snapshot.
+func (fc *FrontendCommands) Run(executable string, args ...string) error {
+	defer fc.Config.InvalidateBranchesSnapshot()
+	return fc.FrontendRunner.Run(executable, args...)
+}
+
+// RunMany executes the given frontend commands.
+func (fc *FrontendCommands) RunMany(commands [][]string) error {
+	defer fc.Config.InvalidateBranchesSnapshot()
+	return fc.FrontendRunner.RunMany(commands)
+}
+
+// RunParallel executes the given independent frontend commands concurrently.
+func (fc *FrontendCommands) RunParallel(commands [][]string) error {
+	defer fc.Config.InvalidateBranchesSnapshot()
+	return fc.FrontendRunner.RunParallel(commands)
+}
+
+// RunWithEnv executes the given frontend command with the given additional environment variables.
+func (fc *FrontendCommands) RunWithEnv(env []string, executable string, args ...string) error {
+	defer fc.Config.InvalidateBranchesSnapshot()
+	return fc.FrontendRunner.RunWithEnv(env, executable, args...)
+}
+
 // SquashMerge squash-merges the given branch into the current branch.
 func (fc *FrontendCommands) SquashMerge(branch string) error {
 	return fc.Run("git", "merge", "--squash", branch)
@@ -1,13 +1,22 @@
 package git
 
+import (
+	"time"
+
+	"github.com/git-town/git-town/v8/src/runlog"
+)
+
 // ProdRunner provides Git functionality for production code.
 type ProdRunner struct {
 	Config   RepoConfig
 	Backend  BackendCommands
 	Frontend FrontendCommands
 	Stats    Statistics
+	Log      *runlog.Log // nil if the run log is disabled
 }
 
 type Statistics interface {
+	RegisterAPICall(name string, start time.Time, duration time.Duration)
+	RegisterStep(name string, start time.Time, duration time.Duration)
 	PrintAnalysis()
 }
@@ -0,0 +1,134 @@
+package giturl
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// SSHConfig contains the host settings of an SSH client configuration file like "~/.ssh/config".
+type SSHConfig struct {
+	hosts []sshHost
+}
+
+// sshHost contains the settings in a "Host" section of an SSH configuration file.
+type sshHost struct {
+	patterns []string
+	hostname string
+	port     string
+	user     string
+}
+
+// LoadSSHConfig provides the SSH configuration of the current user.
+// Provides an empty configuration if the user has none.
+func LoadSSHConfig() SSHConfig {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return SSHConfig{hosts: []sshHost{}}
+	}
+	content, err := os.ReadFile(filepath.Join(home, ".ssh", "config"))
+	if err != nil {
+		return SSHConfig{hosts: []sshHost{}}
+	}
+	return ParseSSHConfig(string(content))
+}
+
+// ParseSSHConfig provides the host settings in the given content of an SSH configuration file.
+// It ignores the settings that don't affect which server a URL points to
+// as well as "Match" sections.
+func ParseSSHConfig(content string) SSHConfig {
+	hosts := []sshHost{}
+	// settings before the first "Host" section apply to all hosts
+	current := &sshHost{patterns: []string{"*"}} //nolint:exhaustruct
+	for _, line := range strings.Split(content, "\n") {
+		key, value := parseSSHConfigLine(line)
+		switch key {
+		case "host":
+			hosts = append(hosts, *current)
+			current = &sshHost{patterns: strings.Fields(value)} //nolint:exhaustruct
+		case "match":
+			hosts = append(hosts, *current)
+			current = &sshHost{patterns: []string{}} //nolint:exhaustruct
+		case "hostname":
+			current.hostname = value
+		case "port":
+			current.port = value
+		case "user":
+			current.user = value
+		}
+	}
+	hosts = append(hosts, *current)
+	return SSHConfig{hosts: hosts}
+}
+
+// parseSSHConfigLine provides the lowercased keyword and the value of the given line of an SSH configuration file.
+// Lines can have the formats "Keyword value" and "Keyword=value".
+func parseSSHConfigLine(line string) (key, value string) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", ""
+	}
+	separator := strings.IndexAny(line, " \t=")
+	if separator < 0 {
+		return strings.ToLower(line), ""
+	}
+	key = strings.ToLower(line[:separator])
+	value = strings.TrimSpace(line[separator+1:])
+	value = strings.TrimSpace(strings.TrimPrefix(value, "="))
+	value = strings.Trim(value, `"`)
+	return key, value
+}
+
+// Resolve provides the given URL with the SSH host alias in it replaced with the server it stands for.
+// Like SSH, it uses the first value it finds for each setting,
+// and the port and user in the URL take precedence over the ones in the configuration.
+// Only affects URLs that use SSH.
+func (sc SSHConfig) Resolve(parts Parts) Parts {
+	if parts.Scheme != "ssh" {
+		return parts
+	}
+	hostname, port, user := "", "", ""
+	for _, host := range sc.hosts {
+		if !host.matches(parts.Host) {
+			continue
+		}
+		if hostname == "" {
+			hostname = host.hostname
+		}
+		if port == "" {
+			port = host.port
+		}
+		if user == "" {
+			user = host.user
+		}
+	}
+	if hostname != "" {
+		parts.Host = strings.ReplaceAll(hostname, "%h", parts.Host)
+	}
+	if parts.Port == "" {
+		parts.Port = port
+	}
+	if parts.User == "" {
+		parts.User = user
+	}
+	return parts
+}
+
+// matches indicates whether the patterns of this host section match the given hostname.
+// Patterns can contain the wildcards "*" and "?" and get negated by a leading "!".
+func (sh sshHost) matches(hostname string) bool {
+	result := false
+	for _, pattern := range sh.patterns {
+		negated := strings.HasPrefix(pattern, "!")
+		matched, err := path.Match(strings.TrimPrefix(pattern, "!"), hostname)
+		if err != nil || !matched {
+			continue
+		}
+		if negated {
+			return false
+		}
+		result = true
+	}
+	return result
+}
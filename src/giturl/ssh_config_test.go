@@ -0,0 +1,49 @@
+package giturl_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v8/src/giturl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSHConfig(t *testing.T) {
+	t.Parallel()
+	config := giturl.ParseSSHConfig(`
+# work account
+Host github-work
+  HostName github.com
+  IdentityFile ~/.ssh/work
+
+Host gitlab-proxy gl-*
+	Hostname=gitlab.example.com
+	Port 2222
+	User "git"
+
+Host *.internal !secret.internal
+  HostName %h.example.com
+
+Match exec "true"
+  HostName ignored.com
+
+Host *
+  User fallback
+`)
+	tests := map[string]giturl.Parts{
+		"github-work:git-town/git-town.git":            {Scheme: "ssh", User: "fallback", Host: "github.com", Org: "git-town", Repo: "git-town"},
+		"git@github-work:git-town/git-town.git":        {Scheme: "ssh", User: "git", Host: "github.com", Org: "git-town", Repo: "git-town"},
+		"gitlab-proxy:group/sub/repo.git":              {Scheme: "ssh", User: "git", Host: "gitlab.example.com", Port: "2222", Org: "group/sub", Repo: "repo"},
+		"ssh://me@gl-eu:22/group/repo.git":             {Scheme: "ssh", User: "me", Host: "gitlab.example.com", Port: "22", Org: "group", Repo: "repo"},
+		"git@gitea.internal:org/repo.git":              {Scheme: "ssh", User: "git", Host: "gitea.internal.example.com", Org: "org", Repo: "repo"},
+		"git@secret.internal:org/repo.git":             {Scheme: "ssh", User: "git", Host: "secret.internal", Org: "org", Repo: "repo"},
+		"git@github.com:git-town/git-town.git":         {Scheme: "ssh", User: "git", Host: "github.com", Org: "git-town", Repo: "git-town"},
+		"https://github-work/git-town/git-town.git":    {Scheme: "https", Host: "github-work", Org: "git-town", Repo: "git-town"},
+		"https://user@gitlab-proxy/group/sub/repo.git": {Scheme: "https", User: "user", Host: "gitlab-proxy", Org: "group/sub", Repo: "repo"},
+	}
+	for give, want := range tests {
+		parts := giturl.Parse(give)
+		if assert.NotNil(t, parts, give) {
+			assert.Equal(t, want, config.Resolve(*parts), give)
+		}
+	}
+}
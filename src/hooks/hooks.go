@@ -0,0 +1,105 @@
+// Package hooks runs custom scripts of the user before and after Git Town commands.
+//
+// Users define a hook either as an executable file with the name of the hook
+// in the ".git/git-town/hooks" directory of their repository,
+// or as a shell command in the Git configuration entry "git-town.hooks.<name>".
+// If both exist, Git Town runs the executable file first.
+// Hooks receive information about the command that runs them through environment variables.
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+
+	"github.com/git-town/git-town/v8/src/git"
+)
+
+// the names of the hooks that Git Town runs
+const (
+	PostHack = "post-hack"
+	PostShip = "post-ship"
+	PostSync = "post-sync"
+	PreShip  = "pre-ship"
+	PreSync  = "pre-sync"
+)
+
+// Names provides the names of all hooks that Git Town runs.
+func Names() []string {
+	return []string{PostHack, PostShip, PostSync, PreShip, PreSync}
+}
+
+// Context contains the information that Git Town provides to hooks.
+type Context struct {
+	// Branch is the branch that the command operates on.
+	Branch string
+	// Parent is the parent of Branch, empty if Branch has no parent.
+	Parent string
+	// Proposal is the number of the proposal for Branch, 0 if there is none.
+	Proposal int
+}
+
+// Env provides the environment variables through which the hook with the given name receives this context.
+func (c Context) Env(hook string) []string {
+	result := []string{
+		"GIT_TOWN_HOOK=" + hook,
+		"GIT_TOWN_BRANCH=" + c.Branch,
+		"GIT_TOWN_PARENT=" + c.Parent,
+	}
+	if c.Proposal > 0 {
+		result = append(result, "GIT_TOWN_PROPOSAL="+strconv.Itoa(c.Proposal))
+	}
+	return result
+}
+
+// Commands provides the shell commands that run the hook with the given name,
+// given the ".git" directory of the repository and the command configured for this hook.
+func Commands(hook, gitDir, configuredCommand string) [][]string {
+	result := [][]string{}
+	path := filepath.Join(gitDir, "git-town", "hooks", hook)
+	info, err := os.Stat(path)
+	if err == nil && info.Mode().IsRegular() && (runtime.GOOS == "windows" || info.Mode().Perm()&0o111 != 0) {
+		result = append(result, []string{path})
+	}
+	if configuredCommand != "" {
+		if runtime.GOOS == "windows" {
+			result = append(result, []string{"cmd", "/C", configuredCommand})
+		} else {
+			result = append(result, []string{"sh", "-c", configuredCommand})
+		}
+	}
+	return result
+}
+
+// Find provides the shell commands that run the hook with the given name in the current repository.
+func Find(hook string, run *git.ProdRunner) ([][]string, error) {
+	gitDir, err := run.Backend.GitDirectory()
+	if err != nil {
+		return [][]string{}, err
+	}
+	return Commands(hook, gitDir, run.Config.HookCommand(hook)), nil
+}
+
+// Exists indicates whether the user has defined the hook with the given name.
+func Exists(hook string, run *git.ProdRunner) (bool, error) {
+	commands, err := Find(hook, run)
+	return len(commands) > 0, err
+}
+
+// Run runs the hook with the given name with the given context.
+// It does nothing if the user hasn't defined this hook.
+func Run(hook string, context Context, run *git.ProdRunner) error {
+	commands, err := Find(hook, run)
+	if err != nil {
+		return err
+	}
+	for _, command := range commands {
+		err = run.Frontend.RunWithEnv(context.Env(hook), command[0], command[1:]...)
+		if err != nil {
+			return fmt.Errorf("the %s hook failed: %w", hook, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package hooks_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/git-town/git-town/v8/src/hooks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommands(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no hooks", func(t *testing.T) {
+		t.Parallel()
+		assert.Empty(t, hooks.Commands(hooks.PreSync, t.TempDir(), ""))
+	})
+
+	t.Run("executable file and configured command", func(t *testing.T) {
+		t.Parallel()
+		if runtime.GOOS == "windows" {
+			t.Skip("Windows runs configured commands via cmd.exe")
+		}
+		gitDir := t.TempDir()
+		hookPath := filepath.Join(gitDir, "git-town", "hooks", hooks.PreSync)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(hookPath), 0o700))
+		assert.NoError(t, os.WriteFile(hookPath, []byte("#!/bin/sh\n"), 0o700))
+		want := [][]string{{hookPath}, {"sh", "-c", "make lint"}}
+		assert.Equal(t, want, hooks.Commands(hooks.PreSync, gitDir, "make lint"))
+		assert.Empty(t, hooks.Commands(hooks.PostSync, gitDir, ""))
+	})
+
+	t.Run("file that isn't executable", func(t *testing.T) {
+		t.Parallel()
+		if runtime.GOOS == "windows" {
+			t.Skip("Windows has no executable permissions")
+		}
+		gitDir := t.TempDir()
+		hookPath := filepath.Join(gitDir, "git-town", "hooks", hooks.PreShip)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(hookPath), 0o700))
+		assert.NoError(t, os.WriteFile(hookPath, []byte("#!/bin/sh\n"), 0o600))
+		assert.Empty(t, hooks.Commands(hooks.PreShip, gitDir, ""))
+	})
+}
+
+func TestContextEnv(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with proposal", func(t *testing.T) {
+		t.Parallel()
+		context := hooks.Context{Branch: "feature", Parent: "main", Proposal: 123}
+		want := []string{
+			"GIT_TOWN_HOOK=pre-ship",
+			"GIT_TOWN_BRANCH=feature",
+			"GIT_TOWN_PARENT=main",
+			"GIT_TOWN_PROPOSAL=123",
+		}
+		assert.Equal(t, want, context.Env(hooks.PreShip))
+	})
+
+	t.Run("without proposal", func(t *testing.T) {
+		t.Parallel()
+		context := hooks.Context{Branch: "main", Parent: "", Proposal: 0}
+		want := []string{
+			"GIT_TOWN_HOOK=post-sync",
+			"GIT_TOWN_BRANCH=main",
+			"GIT_TOWN_PARENT=",
+		}
+		assert.Equal(t, want, context.Env(hooks.PostSync))
+	})
+}
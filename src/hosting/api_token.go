@@ -0,0 +1,193 @@
+package hosting
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/git-town/git-town/v8/src/config"
+	"gopkg.in/yaml.v3"
+)
+
+// APIToken is an API token for a code hosting service
+// together with the place where Git Town found it.
+type APIToken struct {
+	// the token
+	Value string
+
+	// human-readable description of where Git Town found this token
+	Source string
+}
+
+// Masked provides a version of this token that is safe to display.
+func (t APIToken) Masked() string {
+	if t.Value == "" {
+		return ""
+	}
+	if len(t.Value) <= 8 {
+		return strings.Repeat("*", len(t.Value))
+	}
+	return t.Value[:4] + strings.Repeat("*", len(t.Value)-8) + t.Value[len(t.Value)-4:]
+}
+
+// FindAPIToken provides the API token for the given code hosting service running at the given host.
+// It looks in this order at:
+// - environment variables
+// - the Git Town configuration
+// - the Git credential helpers, if the given gitCommands aren't nil
+// - the configuration files of the official CLI of the code hosting service
+// Returns an empty APIToken if it finds none.
+func FindAPIToken(service config.HostingService, host string, gitConfig gitTownConfig, git gitCommands) APIToken {
+	for _, envVar := range tokenEnvVars(service) {
+		value := os.Getenv(envVar)
+		if value != "" {
+			return APIToken{Value: value, Source: "environment variable " + envVar}
+		}
+	}
+	key, value := configuredToken(service, gitConfig)
+	if value != "" {
+		return APIToken{Value: value, Source: "Git config " + key}
+	}
+	if git != nil && host != "" {
+		value = git.CredentialPassword("https", host)
+		if value != "" {
+			return APIToken{Value: value, Source: "Git credential helper"}
+		}
+	}
+	return cliToken(service, host)
+}
+
+// tokenEnvVars provides the names of the environment variables that can contain API tokens for the given code hosting service.
+func tokenEnvVars(service config.HostingService) []string {
+	switch service {
+	case config.HostingServiceGitHub:
+		return []string{"GITHUB_TOKEN", "GITHUB_AUTH_TOKEN", "GH_TOKEN"}
+	case config.HostingServiceGitLab:
+		return []string{"GITLAB_TOKEN"}
+	case config.HostingServiceGitea:
+		return []string{"GITEA_TOKEN"}
+	case config.HostingServiceAuto, config.HostingServiceBitbucket, config.HostingServiceNone:
+	}
+	return []string{}
+}
+
+// configuredToken provides the configuration key and value of the API token for the given code hosting service
+// in the Git Town configuration.
+func configuredToken(service config.HostingService, gitConfig gitTownConfig) (key, value string) {
+	switch service {
+	case config.HostingServiceGitHub:
+		return config.GithubTokenKey, gitConfig.GitHubToken()
+	case config.HostingServiceGitLab:
+		return config.GitlabTokenKey, gitConfig.GitLabToken()
+	case config.HostingServiceGitea:
+		return config.GiteaTokenKey, gitConfig.GiteaToken()
+	case config.HostingServiceAuto, config.HostingServiceBitbucket, config.HostingServiceNone:
+	}
+	return "", ""
+}
+
+// cliToken provides the API token for the given code hosting service at the given host
+// that the official CLI of the code hosting service stores in its configuration file.
+func cliToken(service config.HostingService, host string) APIToken {
+	var token string
+	var cli string
+	switch service {
+	case config.HostingServiceGitHub:
+		cli = "gh"
+		token = ghToken(cliConfigDir("GH_CONFIG_DIR", "gh", "GitHub CLI"), host)
+	case config.HostingServiceGitLab:
+		cli = "glab"
+		token = glabToken(cliConfigDir("GLAB_CONFIG_DIR", "glab-cli", "glab-cli"), host)
+	case config.HostingServiceGitea:
+		cli = "tea"
+		token = teaToken(cliConfigDir("", "tea", "tea"), host)
+	case config.HostingServiceAuto, config.HostingServiceBitbucket, config.HostingServiceNone:
+	}
+	if token == "" {
+		return APIToken{Value: "", Source: ""}
+	}
+	return APIToken{Value: token, Source: fmt.Sprintf("%s CLI configuration", cli)}
+}
+
+// cliConfigDir provides the directory in which a CLI tool stores its configuration files.
+// Users can override it via the given environment variable.
+func cliConfigDir(envVar, name, windowsName string) string {
+	if envVar != "" {
+		if dir := os.Getenv(envVar); dir != "" {
+			return dir
+		}
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, name)
+	}
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("AppData"); dir != "" {
+			return filepath.Join(dir, windowsName)
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", name)
+}
+
+// ghToken provides the token for the given host in the configuration of the GitHub CLI in the given directory.
+func ghToken(dir, host string) string {
+	hosts := map[string]struct {
+		OAuthToken string `yaml:"oauth_token"`
+	}{}
+	if !readYAML(dir, "hosts.yml", &hosts) {
+		return ""
+	}
+	return hosts[host].OAuthToken
+}
+
+// glabToken provides the token for the given host in the configuration of the GitLab CLI in the given directory.
+func glabToken(dir, host string) string {
+	var content struct {
+		Hosts map[string]struct {
+			Token string `yaml:"token"`
+		} `yaml:"hosts"`
+	}
+	if !readYAML(dir, "config.yml", &content) {
+		return ""
+	}
+	return content.Hosts[host].Token
+}
+
+// teaToken provides the token for the given host in the configuration of the Gitea CLI in the given directory.
+func teaToken(dir, host string) string {
+	var content struct {
+		Logins []struct {
+			URL   string `yaml:"url"`
+			Token string `yaml:"token"`
+		} `yaml:"logins"`
+	}
+	if !readYAML(dir, "config.yml", &content) {
+		return ""
+	}
+	for _, login := range content.Logins {
+		loginURL, err := url.Parse(login.URL)
+		if err == nil && loginURL.Hostname() == host {
+			return login.Token
+		}
+	}
+	return ""
+}
+
+// readYAML loads the content of the YAML file with the given name in the given directory into the given data structure.
+// Indicates whether this was successful.
+func readYAML(dir, file string, data interface{}) bool {
+	if dir == "" {
+		return false
+	}
+	content, err := os.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return false
+	}
+	return yaml.Unmarshal(content, data) == nil
+}
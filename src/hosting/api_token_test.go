@@ -0,0 +1,93 @@
+package hosting_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/git-town/git-town/v8/src/config"
+	"github.com/git-town/git-town/v8/src/hosting"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockGit struct {
+	passwords map[string]string
+}
+
+func (mg mockGit) CredentialPassword(protocol, host string) string {
+	return mg.passwords[protocol+"://"+host]
+}
+
+func (mg mockGit) ShaForBranch(string) (string, error) {
+	return "", nil
+}
+
+func TestAPIToken(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"":                         "",
+		"123456":                   "******",
+		"ghp_1234567890abcdefwxyz": "ghp_****************wxyz",
+	}
+	for give, want := range tests {
+		token := hosting.APIToken{Value: give, Source: "test"}
+		assert.Equal(t, want, token.Masked(), give)
+	}
+}
+
+//nolint:paralleltest
+func TestFindAPIToken(t *testing.T) {
+	// isolate the tests from the tokens of the developer running them
+	for _, envVar := range []string{"GITHUB_TOKEN", "GITHUB_AUTH_TOKEN", "GH_TOKEN", "GITLAB_TOKEN", "GITEA_TOKEN", "GH_CONFIG_DIR", "GLAB_CONFIG_DIR"} {
+		t.Setenv(envVar, "")
+	}
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	writeFile(t, filepath.Join(configDir, "gh", "hosts.yml"), "github.com:\n  user: git-town\n  oauth_token: gh-cli-token\n")
+	writeFile(t, filepath.Join(configDir, "glab-cli", "config.yml"), "hosts:\n  gitlab.example.com:\n    token: glab-cli-token\n")
+	writeFile(t, filepath.Join(configDir, "tea", "config.yml"), "logins:\n  - name: work\n    url: https://gitea.example.com\n    token: tea-cli-token\n")
+	git := mockGit{passwords: map[string]string{"https://gitlab.com": "credential-token"}}
+
+	t.Run("environment variable", func(t *testing.T) {
+		t.Setenv("GH_TOKEN", "env-token")
+		repoConfig := mockRepoConfig{gitHubToken: "config-token", originURL: ""}
+		have := hosting.FindAPIToken(config.HostingServiceGitHub, "github.com", repoConfig, git)
+		assert.Equal(t, hosting.APIToken{Value: "env-token", Source: "environment variable GH_TOKEN"}, have)
+	})
+
+	t.Run("Git Town configuration", func(t *testing.T) {
+		repoConfig := mockRepoConfig{gitLabToken: "config-token", originURL: ""}
+		have := hosting.FindAPIToken(config.HostingServiceGitLab, "gitlab.com", repoConfig, git)
+		assert.Equal(t, hosting.APIToken{Value: "config-token", Source: "Git config git-town.gitlab-token"}, have)
+	})
+
+	t.Run("Git credential helper", func(t *testing.T) {
+		repoConfig := mockRepoConfig{originURL: ""}
+		have := hosting.FindAPIToken(config.HostingServiceGitLab, "gitlab.com", repoConfig, git)
+		assert.Equal(t, hosting.APIToken{Value: "credential-token", Source: "Git credential helper"}, have)
+	})
+
+	t.Run("CLI configurations", func(t *testing.T) {
+		repoConfig := mockRepoConfig{originURL: ""}
+		have := hosting.FindAPIToken(config.HostingServiceGitHub, "github.com", repoConfig, nil)
+		assert.Equal(t, hosting.APIToken{Value: "gh-cli-token", Source: "gh CLI configuration"}, have)
+		have = hosting.FindAPIToken(config.HostingServiceGitLab, "gitlab.example.com", repoConfig, git)
+		assert.Equal(t, hosting.APIToken{Value: "glab-cli-token", Source: "glab CLI configuration"}, have)
+		have = hosting.FindAPIToken(config.HostingServiceGitea, "gitea.example.com", repoConfig, git)
+		assert.Equal(t, hosting.APIToken{Value: "tea-cli-token", Source: "tea CLI configuration"}, have)
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		repoConfig := mockRepoConfig{originURL: ""}
+		have := hosting.FindAPIToken(config.HostingServiceGitea, "gitea.com", repoConfig, git)
+		assert.Equal(t, hosting.APIToken{Value: "", Source: ""}, have)
+		have = hosting.FindAPIToken(config.HostingServiceBitbucket, "bitbucket.org", repoConfig, git)
+		assert.Equal(t, hosting.APIToken{Value: "", Source: ""}, have)
+	})
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
@@ -49,6 +49,18 @@ func (c *BitbucketConnector) HostingServiceName() string {
 	return "Bitbucket"
 }
 
+func (c *BitbucketConnector) MoveProposal(proposal Proposal, head, target string) (int, error) {
+	return 0, errors.New("updating pull requests via the Bitbucket API is currently not supported. If you need this functionality, please vote for it by opening a ticket at https://github.com/git-town/git-town/issues")
+}
+
+func (c *BitbucketConnector) OpenProposals() (map[string]Proposal, error) {
+	return nil, fmt.Errorf("BitBucket API functionality isn't implemented yet")
+}
+
+func (c *BitbucketConnector) ProposalStatus(proposal Proposal) (ProposalStatus, error) {
+	return ProposalStatus{}, fmt.Errorf("BitBucket API functionality isn't implemented yet")
+}
+
 func (c *BitbucketConnector) NewProposalURL(branch, parentBranch string) (string, error) {
 	query := url.Values{}
 	branchSha, err := c.git.ShaForBranch(branch)
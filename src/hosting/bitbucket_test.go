@@ -48,3 +48,11 @@ func TestNewBitbucketConnector(t *testing.T) {
 		assert.Equal(t, "https://bitbucket.org/git-town/git-town", connector.RepositoryURL())
 	})
 }
+
+func TestBitbucketMoveProposal(t *testing.T) {
+	t.Parallel()
+	connector := hosting.BitbucketConnector{} //nolint:exhaustruct
+	proposal := hosting.Proposal{Number: 1}   //nolint:exhaustruct
+	_, err := connector.MoveProposal(proposal, "new", "main")
+	assert.ErrorContains(t, err, "not supported")
+}
@@ -6,6 +6,7 @@ package hosting
 
 import (
 	"errors"
+	"time"
 
 	"github.com/git-town/git-town/v8/src/config"
 	"github.com/git-town/git-town/v8/src/giturl"
@@ -27,6 +28,23 @@ type Connector interface {
 	// supported by the respective connector implementation.
 	HostingServiceName() string
 
+	// MoveProposal makes the given proposal propose the given head branch into the given target branch.
+	// None of the supported hosting platforms can change the head branch of existing proposals,
+	// so this closes the given proposal and re-opens it for the new head branch:
+	// it reopens a closed proposal from the head branch into the target branch if one exists,
+	// otherwise it creates a new proposal with the same title and body.
+	// Returns the number of the proposal for the new head branch.
+	MoveProposal(proposal Proposal, head, target string) (int, error)
+
+	// OpenProposals provides the open proposals of this repository, keyed by their head branch.
+	// This requires only one API call, so it might not provide all proposals
+	// of repositories with many open proposals.
+	OpenProposals() (map[string]Proposal, error)
+
+	// ProposalStatus provides the status of the checks and reviews of the given proposal.
+	// This requires several API calls, only use it where Git Town needs this information.
+	ProposalStatus(proposal Proposal) (ProposalStatus, error)
+
 	// SquashMergeProposal squash-merges the proposal with the given number
 	// using the given commit message.
 	SquashMergeProposal(number int, message string) (mergeSHA string, err error)
@@ -70,13 +88,25 @@ type Proposal struct {
 	// textual title of the proposal
 	Title string
 
+	// textual description of the proposal
+	Body string
+
 	// whether this proposal can be merged via the API
 	CanMergeWithAPI bool
+
+	// whether this proposal is marked as a draft or work in progress
+	Draft bool
+
+	// SHA of the latest commit of this proposal
+	HeadSHA string
 }
 
 // gitTownConfig defines the configuration data needed by the hosting package.
 // This extra interface is necessary to access config.GitTown without creating a cyclic dependency.
 type gitTownConfig interface {
+	// APITimeout provides how long API calls to code hosting services can take.
+	APITimeout() (time.Duration, error)
+
 	// OriginOverride provides the override for the origin URL from the Git Town configuration.
 	OriginOverride() string
 
@@ -92,15 +122,27 @@ type gitTownConfig interface {
 	// GitLabToken provides the personal access token for GitLab stored in the Git configuration.
 	GitLabToken() string
 
+	// DetectedHostingService provides the code hosting service that Git Town detected earlier at the given host
+	// and whether Git Town has probed this host before.
+	DetectedHostingService(hostname string) (config.HostingService, bool)
+
+	// IsOffline indicates whether Git Town skips network operations in this run,
+	// including when automatic offline mode found origin unreachable.
+	IsOffline() (bool, error)
+
 	// MainBranch provides the name of the main branch.
 	MainBranch() string
 
 	// OriginURL provides the URL of the origin remote.
 	OriginURL() *giturl.Parts
+
+	// SetDetectedHostingService remembers that the given code hosting service runs at the given host.
+	SetDetectedHostingService(hostname string, service config.HostingService) error
 }
 
 // gitCommands defines the Git functionality used by the hosting package.
 type gitCommands interface {
+	CredentialPassword(protocol, host string) string
 	ShaForBranch(string) (string, error)
 }
 
@@ -108,15 +150,27 @@ type gitCommands interface {
 type logFn func(string, ...interface{})
 
 // NewConnector provides an instance of the code hosting connector to use based on the given gitConfig.
-func NewConnector(config gitTownConfig, git gitCommands, log logFn) (Connector, error) {
-	githubConnector, err := NewGithubConnector(config, log)
+// The connector registers the durations of its API calls in the given statistics.
+func NewConnector(config gitTownConfig, git gitCommands, log logFn, stats Statistics) (Connector, error) {
+	connector, err := newConnector(config, git, log)
+	if err != nil || connector == nil {
+		return connector, err
+	}
+	return TimedConnector{Connector: connector, Stats: stats}, nil
+}
+
+// newConnector provides the connector for the code hosting platform that the given gitConfig uses.
+// If the user hasn't configured the hosting service of a self-hosted origin server, it detects it.
+func newConnector(config gitTownConfig, git gitCommands, log logFn) (Connector, error) {
+	config = withDetectedHostingService(config, log)
+	githubConnector, err := NewGithubConnector(config, git, log)
 	if err != nil {
 		return nil, err
 	}
 	if githubConnector != nil {
 		return githubConnector, nil
 	}
-	gitlabConnector, err := NewGitlabConnector(config, log)
+	gitlabConnector, err := NewGitlabConnector(config, git, log)
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +184,7 @@ func NewConnector(config gitTownConfig, git gitCommands, log logFn) (Connector,
 	if bitbucketConnector != nil {
 		return bitbucketConnector, nil
 	}
-	giteaConnector, err := NewGiteaConnector(config, log)
+	giteaConnector, err := NewGiteaConnector(config, git, log)
 	if err != nil {
 		return nil, err
 	}
@@ -1,18 +1,76 @@
 package hosting_test
 
 import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
 	"github.com/git-town/git-town/v8/src/config"
 	"github.com/git-town/git-town/v8/src/giturl"
 )
 
+// fakeAPI provides a local HTTP server that answers requests like "GET /path" with the given status codes and bodies.
+// It answers all other requests with 404.
+// The returned function provides the requests that the server received, including their bodies.
+func fakeAPI(t *testing.T, responses map[string]fakeResponse) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mutex sync.Mutex
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		name := request.Method + " " + request.URL.Path
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			t.Errorf("cannot read the body of request %q: %v", name, err)
+		}
+		mutex.Lock()
+		requests = append(requests, strings.TrimSpace(name+" "+string(body)))
+		mutex.Unlock()
+		response, has := responses[name]
+		if !has {
+			writer.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(writer, `{"message":"404 Not Found"}`)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(response.status)
+		fmt.Fprint(writer, response.body)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return requests
+	}
+}
+
 type mockRepoConfig struct {
-	giteaToken     string                `exhaustruct:"optional"`
-	gitHubToken    string                `exhaustruct:"optional"`
-	gitLabToken    string                `exhaustruct:"optional"`
-	hostingService config.HostingService `exhaustruct:"optional"`
-	mainBranch     string                `exhaustruct:"optional"`
-	originOverride string                `exhaustruct:"optional"`
+	apiTimeout     time.Duration                    `exhaustruct:"optional"`
+	detected       map[string]config.HostingService `exhaustruct:"optional"`
+	giteaToken     string                           `exhaustruct:"optional"`
+	gitHubToken    string                           `exhaustruct:"optional"`
+	gitLabToken    string                           `exhaustruct:"optional"`
+	hostingService config.HostingService            `exhaustruct:"optional"`
+	mainBranch     string                           `exhaustruct:"optional"`
+	originOverride string                           `exhaustruct:"optional"`
 	originURL      string
+	offline        bool `exhaustruct:"optional"`
+}
+
+func (mc mockRepoConfig) APITimeout() (time.Duration, error) {
+	if mc.apiTimeout == 0 {
+		return config.DefaultAPITimeout, nil
+	}
+	return mc.apiTimeout, nil
+}
+
+func (mc mockRepoConfig) DetectedHostingService(hostname string) (config.HostingService, bool) {
+	service, found := mc.detected[hostname]
+	return service, found
 }
 
 func (mc mockRepoConfig) GiteaToken() string {
@@ -31,6 +89,10 @@ func (mc mockRepoConfig) HostingService() (config.HostingService, error) {
 	return mc.hostingService, nil
 }
 
+func (mc mockRepoConfig) IsOffline() (bool, error) {
+	return mc.offline, nil
+}
+
 func (mc mockRepoConfig) MainBranch() string {
 	return mc.mainBranch
 }
@@ -46,3 +108,8 @@ func (mc mockRepoConfig) OriginURL() *giturl.Parts {
 	}
 	return url
 }
+
+func (mc mockRepoConfig) SetDetectedHostingService(hostname string, service config.HostingService) error {
+	mc.detected[hostname] = service
+	return nil
+}
@@ -0,0 +1,170 @@
+package hosting
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/git-town/git-town/v8/src/config"
+	"github.com/git-town/git-town/v8/src/giturl"
+)
+
+// probeTimeout is how long Git Town waits for each API endpoint it probes.
+const probeTimeout = 3 * time.Second
+
+// probe describes an API endpoint that identifies the code hosting service that provides it.
+type probe struct {
+	path    string
+	service config.HostingService
+	// matches indicates whether the given response of the endpoint identifies the code hosting service
+	matches func(status int, body map[string]interface{}) bool
+}
+
+// probes provides the API endpoints that Git Town probes to detect code hosting services.
+func probes() []probe {
+	return []probe{
+		{
+			path:    "/api/v3/meta",
+			service: config.HostingServiceGitHub,
+			matches: func(status int, body map[string]interface{}) bool {
+				_, hasPasswordAuth := body["verifiable_password_authentication"]
+				_, hasVersion := body["installed_version"]
+				return status == http.StatusOK && (hasPasswordAuth || hasVersion)
+			},
+		},
+		{
+			path:    "/api/v1/version",
+			service: config.HostingServiceGitea,
+			matches: func(status int, body map[string]interface{}) bool {
+				_, hasVersion := body["version"]
+				return status == http.StatusOK && hasVersion
+			},
+		},
+		{
+			// GitLab requires authentication for this endpoint
+			// but identifies itself through the error message for unauthenticated requests
+			path:    "/api/v4/version",
+			service: config.HostingServiceGitLab,
+			matches: func(status int, body map[string]interface{}) bool {
+				_, hasVersion := body["version"]
+				message, _ := body["message"].(string)
+				return (status == http.StatusOK && hasVersion) || (status == http.StatusUnauthorized && message == "401 Unauthorized")
+			},
+		},
+	}
+}
+
+// DetectHostingService determines the code hosting service running at the given base URL
+// by probing well-known API endpoints.
+// The boolean return value indicates whether the server answered all probes,
+// i.e. whether the result is definitive.
+func DetectHostingService(baseURL string, client *http.Client) (config.HostingService, bool) {
+	for _, probe := range probes() {
+		status, body, err := getJSON(client, baseURL+probe.path)
+		if err != nil {
+			// the other probes would most likely fail the same way
+			return config.HostingServiceNone, false
+		}
+		if probe.matches(status, body) {
+			return probe.service, true
+		}
+	}
+	return config.HostingServiceNone, true
+}
+
+// getJSON provides the status code and the JSON object in the body of the response for the given URL.
+// The body is empty if the response doesn't contain a JSON object.
+func getJSON(client *http.Client, url string) (int, map[string]interface{}, error) {
+	body := map[string]interface{}{}
+	response, err := client.Get(url) //nolint:noctx
+	if err != nil {
+		return 0, body, err
+	}
+	defer response.Body.Close()
+	content, err := io.ReadAll(io.LimitReader(response.Body, 1_000_000))
+	if err != nil {
+		return 0, body, err
+	}
+	_ = json.Unmarshal(content, &body)
+	return response.StatusCode, body, nil
+}
+
+// publicHosts provides the hostnames of the public instances of the supported code hosting services.
+func publicHosts() []string {
+	return []string{"bitbucket.org", "gitea.com", "github.com", "gitlab.com"}
+}
+
+// probeBaseURL provides the base URL of the web server at the given origin URL.
+func probeBaseURL(url *giturl.Parts) string {
+	if url.Scheme == "http" {
+		if url.Port != "" {
+			return "http://" + url.Host + ":" + url.Port
+		}
+		return "http://" + url.Host
+	}
+	if url.Scheme == "https" && url.Port != "" {
+		return "https://" + url.Host + ":" + url.Port
+	}
+	return "https://" + url.Host
+}
+
+// detectedConfig is a gitTownConfig whose hosting service Git Town detected by probing the origin server.
+type detectedConfig struct {
+	gitTownConfig
+	service config.HostingService
+}
+
+func (dc detectedConfig) HostingService() (config.HostingService, error) {
+	return dc.service, nil
+}
+
+// withDetectedHostingService provides the given configuration
+// with the code hosting service of the origin server,
+// if the user enabled detecting it and the origin isn't a public code hosting service.
+// It caches the result of the detection in the local Git configuration, including that the server is unknown.
+// It doesn't cache anything if the server doesn't answer, since it might be reachable later.
+func withDetectedHostingService(gitConfig gitTownConfig, log logFn) gitTownConfig {
+	hostingService, err := gitConfig.HostingService()
+	if err != nil || hostingService != config.HostingServiceAuto {
+		return gitConfig
+	}
+	url := gitConfig.OriginURL()
+	if url == nil || url.Host == "" || url.Scheme == "file" {
+		return gitConfig
+	}
+	for _, publicHost := range publicHosts() {
+		if url.Host == publicHost {
+			return gitConfig
+		}
+	}
+	detected, found := gitConfig.DetectedHostingService(url.Host)
+	if !found {
+		offline, err := gitConfig.IsOffline()
+		if err != nil || offline {
+			return gitConfig
+		}
+		var answered bool
+		detected, answered = DetectHostingService(probeBaseURL(url), &http.Client{Timeout: probeTimeout})
+		if log != nil {
+			log("Code hosting service at %s: %s\n", url.Host, describeDetectedService(detected, answered))
+		}
+		if answered {
+			_ = gitConfig.SetDetectedHostingService(url.Host, detected)
+		}
+	}
+	if detected == config.HostingServiceNone {
+		return gitConfig
+	}
+	return detectedConfig{gitTownConfig: gitConfig, service: detected}
+}
+
+func describeDetectedService(service config.HostingService, answered bool) string {
+	switch {
+	case !answered:
+		return "server unreachable"
+	case service == config.HostingServiceNone:
+		return "unknown"
+	}
+	return string(service)
+}
@@ -0,0 +1,175 @@
+package hosting_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/git-town/git-town/v8/src/config"
+	"github.com/git-town/git-town/v8/src/execute"
+	"github.com/git-town/git-town/v8/src/hosting"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeServer provides a local HTTP server that answers the given paths with the given status codes and bodies.
+// It answers all other paths with 404.
+func fakeServer(t *testing.T, responses map[string]fakeResponse) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		response, has := responses[request.URL.Path]
+		if !has {
+			writer.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(writer, `{"message":"404 Not Found"}`)
+			return
+		}
+		writer.WriteHeader(response.status)
+		fmt.Fprint(writer, response.body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+type fakeResponse struct {
+	status int
+	body   string
+}
+
+func TestDetectHostingService(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		responses map[string]fakeResponse
+		want      config.HostingService
+	}{
+		"GitHub Enterprise": {
+			responses: map[string]fakeResponse{"/api/v3/meta": {http.StatusOK, `{"verifiable_password_authentication":true,"installed_version":"3.9.0"}`}},
+			want:      config.HostingServiceGitHub,
+		},
+		"GitLab": {
+			responses: map[string]fakeResponse{"/api/v4/version": {http.StatusUnauthorized, `{"message":"401 Unauthorized"}`}},
+			want:      config.HostingServiceGitLab,
+		},
+		"Gitea": {
+			responses: map[string]fakeResponse{"/api/v1/version": {http.StatusOK, `{"version":"1.20.5"}`}},
+			want:      config.HostingServiceGitea,
+		},
+		"unknown server": {
+			responses: map[string]fakeResponse{"/api/v3/meta": {http.StatusOK, `<html>hello</html>`}},
+			want:      config.HostingServiceNone,
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			server := fakeServer(t, test.responses)
+			have, answered := hosting.DetectHostingService(server.URL, server.Client())
+			assert.True(t, answered)
+			assert.Equal(t, test.want, have)
+		})
+	}
+
+	t.Run("unreachable server", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		have, answered := hosting.DetectHostingService(server.URL, server.Client())
+		assert.False(t, answered)
+		assert.Equal(t, config.HostingServiceNone, have)
+	})
+}
+
+func TestNewConnectorDetection(t *testing.T) {
+	t.Parallel()
+
+	t.Run("detects and caches the hosting service of a self-hosted server", func(t *testing.T) {
+		t.Parallel()
+		server := fakeServer(t, map[string]fakeResponse{"/api/v4/version": {http.StatusUnauthorized, `{"message":"401 Unauthorized"}`}})
+		repoConfig := mockRepoConfig{
+			detected:       map[string]config.HostingService{},
+			hostingService: config.HostingServiceAuto,
+			originURL:      server.URL + "/git-town/git-town.git",
+		}
+		connector, err := hosting.NewConnector(repoConfig, nil, nil, &execute.NoStatistics{})
+		assert.NoError(t, err)
+		assert.NotNil(t, connector)
+		assert.Equal(t, "GitLab", connector.HostingServiceName())
+		assert.Equal(t, map[string]config.HostingService{"127.0.0.1": config.HostingServiceGitLab}, repoConfig.detected)
+		// uses the cached result without probing again
+		server.Close()
+		connector, err = hosting.NewConnector(repoConfig, nil, nil, &execute.NoStatistics{})
+		assert.NoError(t, err)
+		assert.Equal(t, "GitLab", connector.HostingServiceName())
+	})
+
+	t.Run("caches unknown servers", func(t *testing.T) {
+		t.Parallel()
+		server := fakeServer(t, map[string]fakeResponse{})
+		repoConfig := mockRepoConfig{
+			detected:       map[string]config.HostingService{},
+			hostingService: config.HostingServiceAuto,
+			originURL:      server.URL + "/git-town/git-town.git",
+		}
+		connector, err := hosting.NewConnector(repoConfig, nil, nil, &execute.NoStatistics{})
+		assert.NoError(t, err)
+		assert.Nil(t, connector)
+		assert.Equal(t, map[string]config.HostingService{"127.0.0.1": config.HostingServiceNone}, repoConfig.detected)
+	})
+
+	t.Run("doesn't cache unreachable servers", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		repoConfig := mockRepoConfig{
+			detected:       map[string]config.HostingService{},
+			hostingService: config.HostingServiceAuto,
+			originURL:      server.URL + "/git-town/git-town.git",
+		}
+		connector, err := hosting.NewConnector(repoConfig, nil, nil, &execute.NoStatistics{})
+		assert.NoError(t, err)
+		assert.Nil(t, connector)
+		assert.Empty(t, repoConfig.detected)
+	})
+
+	t.Run("doesn't probe unless enabled", func(t *testing.T) {
+		t.Parallel()
+		server := fakeServer(t, map[string]fakeResponse{"/api/v1/version": {http.StatusOK, `{"version":"1.20.5"}`}})
+		repoConfig := mockRepoConfig{
+			detected:  map[string]config.HostingService{},
+			originURL: server.URL + "/git-town/git-town.git",
+		}
+		connector, err := hosting.NewConnector(repoConfig, nil, nil, &execute.NoStatistics{})
+		assert.NoError(t, err)
+		assert.Nil(t, connector)
+		assert.Empty(t, repoConfig.detected)
+	})
+
+	t.Run("doesn't probe in offline mode", func(t *testing.T) {
+		t.Parallel()
+		server := fakeServer(t, map[string]fakeResponse{"/api/v1/version": {http.StatusOK, `{"version":"1.20.5"}`}})
+		repoConfig := mockRepoConfig{
+			detected:       map[string]config.HostingService{},
+			hostingService: config.HostingServiceAuto,
+			offline:        true,
+			originURL:      server.URL + "/git-town/git-town.git",
+		}
+		connector, err := hosting.NewConnector(repoConfig, nil, nil, &execute.NoStatistics{})
+		assert.NoError(t, err)
+		assert.Nil(t, connector)
+		assert.Empty(t, repoConfig.detected)
+	})
+
+	t.Run("doesn't probe when the hosting service is configured", func(t *testing.T) {
+		t.Parallel()
+		server := fakeServer(t, map[string]fakeResponse{"/api/v1/version": {http.StatusOK, `{"version":"1.20.5"}`}})
+		repoConfig := mockRepoConfig{
+			detected:       map[string]config.HostingService{},
+			hostingService: config.HostingServiceGitLab,
+			originURL:      server.URL + "/git-town/git-town.git",
+		}
+		connector, err := hosting.NewConnector(repoConfig, nil, nil, &execute.NoStatistics{})
+		assert.NoError(t, err)
+		assert.Equal(t, "GitLab", connector.HostingServiceName())
+		assert.Empty(t, repoConfig.detected)
+	})
+}
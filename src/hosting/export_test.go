@@ -0,0 +1,62 @@
+package hosting
+
+import (
+	"net/http"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/google/go-github/v50/github"
+	"github.com/xanzy/go-gitlab"
+)
+
+// This file gives the tests of this package access to the API clients of the connectors.
+
+// NewGiteaTestConnector provides a GiteaConnector for the repo "org/repo" that talks to the Gitea API at the given URL.
+func NewGiteaTestConnector(url string, httpClient *http.Client) *GiteaConnector {
+	return &GiteaConnector{
+		client:       gitea.NewClientWithHTTP(url, httpClient),
+		CommonConfig: testCommonConfig(),
+		log:          nil,
+	}
+}
+
+// NewGitHubTestConnector provides a GitHubConnector for the repo "org/repo" that talks to the GitHub API at the given URL.
+func NewGitHubTestConnector(url string, httpClient *http.Client) *GitHubConnector {
+	client, err := github.NewEnterpriseClient(url, url, httpClient)
+	if err != nil {
+		panic(err)
+	}
+	return &GitHubConnector{
+		client:       client,
+		CommonConfig: testCommonConfig(),
+		MainBranch:   "main",
+		log:          nil,
+	}
+}
+
+// NewGitLabTestConnector provides a GitLabConnector for the repo "org/repo" that talks to the GitLab API at the given URL.
+func NewGitLabTestConnector(url string, httpClient *http.Client) *GitLabConnector {
+	client, err := gitlab.NewOAuthClient("", gitlab.WithHTTPClient(httpClient), gitlab.WithBaseURL(url), gitlab.WithoutRetries())
+	if err != nil {
+		panic(err)
+	}
+	return &GitLabConnector{
+		client:       client,
+		GitLabConfig: GitLabConfig{testCommonConfig()},
+		log:          nil,
+	}
+}
+
+func testCommonConfig() CommonConfig {
+	return CommonConfig{
+		APIToken:     "",
+		Hostname:     "",
+		Organization: "org",
+		Repository:   "repo",
+	}
+}
+
+// NewTestHTTPClient provides the HTTP client that connectors use, with the given timeout.
+func NewTestHTTPClient(timeout time.Duration) *http.Client {
+	return newHTTPClient(timeout)
+}
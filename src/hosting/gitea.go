@@ -1,13 +1,12 @@
 package hosting
 
 import (
-	"context"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/git-town/git-town/v8/src/config"
-	"golang.org/x/oauth2"
 )
 
 type GiteaConnector struct {
@@ -24,7 +23,7 @@ func (c *GiteaConnector) FindProposal(branch, target string) (*Proposal, error)
 		State: gitea.StateOpen,
 	})
 	if err != nil {
-		return nil, err
+		return nil, apiCallError("Gitea", "list open pull requests", err)
 	}
 	pullRequests := FilterGiteaPullRequests(openPullRequests, c.Organization, branch, target)
 	if len(pullRequests) == 0 {
@@ -33,12 +32,52 @@ func (c *GiteaConnector) FindProposal(branch, target string) (*Proposal, error)
 	if len(pullRequests) > 1 {
 		return nil, fmt.Errorf("found %d pull requests for branch %q", len(pullRequests), branch)
 	}
-	pullRequest := pullRequests[0]
-	return &Proposal{
-		CanMergeWithAPI: pullRequest.Mergeable,
-		Number:          int(pullRequest.Index),
-		Target:          pullRequest.Base.Ref,
-		Title:           pullRequest.Title,
+	proposal := parseGiteaPullRequest(pullRequests[0])
+	return &proposal, nil
+}
+
+func (c *GiteaConnector) OpenProposals() (map[string]Proposal, error) {
+	openPullRequests, err := c.client.ListRepoPullRequests(c.Organization, c.Repository, gitea.ListPullRequestsOptions{
+		ListOptions: gitea.ListOptions{
+			PageSize: 50,
+		},
+		State: gitea.StateOpen,
+	})
+	if err != nil {
+		return nil, apiCallError("Gitea", "list open pull requests", err)
+	}
+	result := map[string]Proposal{}
+	headPrefix := c.Organization + "/"
+	for _, pullRequest := range openPullRequests {
+		// ignore pull requests from forks
+		if strings.HasPrefix(pullRequest.Head.Name, headPrefix) {
+			result[strings.TrimPrefix(pullRequest.Head.Name, headPrefix)] = parseGiteaPullRequest(pullRequest)
+		}
+	}
+	return result, nil
+}
+
+func (c *GiteaConnector) ProposalStatus(proposal Proposal) (ProposalStatus, error) {
+	combinedStatus, err := c.client.GetCombinedStatus(c.Organization, c.Repository, proposal.HeadSHA)
+	if err != nil {
+		return ProposalStatus{}, apiCallError("Gitea", fmt.Sprintf("load commit statuses of pull request #%d", proposal.Number), err)
+	}
+	reviews, err := c.client.ListPullReviews(c.Organization, c.Repository, int64(proposal.Number), gitea.ListPullReviewsOptions{
+		ListOptions: gitea.ListOptions{
+			PageSize: 50,
+		},
+	})
+	if err != nil {
+		return ProposalStatus{}, apiCallError("Gitea", fmt.Sprintf("list reviews of pull request #%d", proposal.Number), err)
+	}
+	// the branch protection of the target branch defines how many approvals the pull request needs
+	targetBranch, err := c.client.GetRepoBranch(c.Organization, c.Repository, proposal.Target)
+	if err != nil {
+		return ProposalStatus{}, apiCallError("Gitea", fmt.Sprintf("load branch %q", proposal.Target), err)
+	}
+	return ProposalStatus{
+		Checks: GiteaCheckStatus(combinedStatus),
+		Review: GiteaReviewStatus(reviews, targetBranch.RequiredApprovals),
 	}, nil
 }
 
@@ -50,6 +89,59 @@ func (c *GiteaConnector) HostingServiceName() string {
 	return "Gitea"
 }
 
+func (c *GiteaConnector) MoveProposal(proposal Proposal, head, target string) (int, error) {
+	if c.log != nil {
+		c.log("Gitea API: moving PR #%d to branch %q\n", proposal.Number, head)
+	}
+	closedPullRequests, err := c.client.ListRepoPullRequests(c.Organization, c.Repository, gitea.ListPullRequestsOptions{
+		ListOptions: gitea.ListOptions{
+			PageSize: 50,
+		},
+		State: gitea.StateClosed,
+	})
+	if err != nil {
+		return 0, apiCallError("Gitea", "list closed pull requests", err)
+	}
+	var newPullRequest *gitea.PullRequest
+	for _, closedPullRequest := range FilterGiteaPullRequests(closedPullRequests, c.Organization, head, target) {
+		if !closedPullRequest.HasMerged {
+			newPullRequest = closedPullRequest
+			break
+		}
+	}
+	if newPullRequest != nil {
+		open := gitea.StateOpen
+		_, err = c.client.EditPullRequest(c.Organization, c.Repository, newPullRequest.Index, gitea.EditPullRequestOption{
+			Title: newPullRequest.Title,
+			Body:  newPullRequest.Body,
+			State: &open,
+		})
+		if err != nil {
+			return 0, apiCallError("Gitea", fmt.Sprintf("reopen pull request #%d", newPullRequest.Index), err)
+		}
+	} else {
+		newPullRequest, err = c.client.CreatePullRequest(c.Organization, c.Repository, gitea.CreatePullRequestOption{
+			Head:  head,
+			Base:  target,
+			Title: proposal.Title,
+			Body:  proposal.Body,
+		})
+		if err != nil {
+			return 0, apiCallError("Gitea", fmt.Sprintf("create pull request for branch %q", head), err)
+		}
+	}
+	closed := gitea.StateClosed
+	_, err = c.client.EditPullRequest(c.Organization, c.Repository, int64(proposal.Number), gitea.EditPullRequestOption{
+		Title: proposal.Title,
+		Body:  proposal.Body,
+		State: &closed,
+	})
+	if err != nil {
+		return 0, apiCallError("Gitea", fmt.Sprintf("close pull request #%d", proposal.Number), err)
+	}
+	return int(newPullRequest.Index), nil
+}
+
 func (c *GiteaConnector) NewProposalURL(branch, parentBranch string) (string, error) {
 	toCompare := parentBranch + "..." + branch
 	return fmt.Sprintf("%s/compare/%s", c.RepositoryURL(), url.PathEscape(toCompare)), nil
@@ -71,11 +163,11 @@ func (c *GiteaConnector) SquashMergeProposal(number int, message string) (mergeS
 		Message: body,
 	})
 	if err != nil {
-		return "", err
+		return "", apiCallError("Gitea", fmt.Sprintf("merge pull request #%d", number), err)
 	}
 	pullRequest, err := c.client.GetPullRequest(c.Organization, c.Repository, int64(number))
 	if err != nil {
-		return "", err
+		return "", apiCallError("Gitea", fmt.Sprintf("load pull request #%d", number), err)
 	}
 	return *pullRequest.MergedCommitID, nil
 }
@@ -94,7 +186,7 @@ func (c *GiteaConnector) UpdateProposalTarget(number int, target string) error {
 
 // NewGiteaConfig provides Gitea configuration data if the current repo is hosted on Gitea,
 // otherwise nil.
-func NewGiteaConnector(gitConfig gitTownConfig, log logFn) (*GiteaConnector, error) {
+func NewGiteaConnector(gitConfig gitTownConfig, git gitCommands, log logFn) (*GiteaConnector, error) {
 	hostingService, err := gitConfig.HostingService()
 	if err != nil {
 		return nil, err
@@ -103,10 +195,13 @@ func NewGiteaConnector(gitConfig gitTownConfig, log logFn) (*GiteaConnector, err
 	if url == nil || (url.Host != "gitea.com" && hostingService != config.HostingServiceGitea) {
 		return nil, nil //nolint:nilnil
 	}
-	apiToken := gitConfig.GiteaToken()
+	apiTimeout, err := gitConfig.APITimeout()
+	if err != nil {
+		return nil, err
+	}
+	apiToken := FindAPIToken(config.HostingServiceGitea, url.Host, gitConfig, git).Value
 	hostname := url.Host
-	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: apiToken})
-	httpClient := oauth2.NewClient(context.Background(), tokenSource)
+	httpClient := newOAuthHTTPClient(apiToken, apiTimeout)
 	giteaClient := gitea.NewClientWithHTTP(fmt.Sprintf("https://%s", hostname), httpClient)
 	return &GiteaConnector{
 		client: giteaClient,
@@ -120,6 +215,75 @@ func NewGiteaConnector(gitConfig gitTownConfig, log logFn) (*GiteaConnector, err
 	}, nil
 }
 
+// GiteaCheckStatus provides the check status of a Gitea pull request whose latest commit has the given statuses.
+func GiteaCheckStatus(combinedStatus *gitea.CombinedStatus) CheckStatus {
+	if combinedStatus == nil || combinedStatus.TotalCount == 0 {
+		return CheckStatusNone
+	}
+	switch combinedStatus.State {
+	case gitea.StatusSuccess, gitea.StatusWarning:
+		return CheckStatusSuccess
+	case gitea.StatusPending:
+		return CheckStatusPending
+	case gitea.StatusError, gitea.StatusFailure:
+	}
+	return CheckStatusFailure
+}
+
+// GiteaReviewStatus provides the review status of a Gitea pull request with the given reviews,
+// listed from oldest to newest, whose target branch requires the given number of approving reviews.
+// Only the latest review of each reviewer counts.
+func GiteaReviewStatus(reviews []*gitea.PullReview, requiredApprovals int64) ReviewStatus {
+	latestReviews := map[int64]gitea.ReviewStateType{}
+	for _, review := range reviews {
+		if review.Reviewer == nil {
+			continue
+		}
+		switch review.State {
+		case gitea.ReviewStateApproved, gitea.ReviewStateRequestChanges, gitea.ReviewStateRequestReview:
+			latestReviews[review.Reviewer.ID] = review.State
+		case gitea.ReviewStateComment, gitea.ReviewStatePending, gitea.ReviewStateUnknown:
+		}
+	}
+	var approvals int64
+	for _, state := range latestReviews {
+		switch state {
+		case gitea.ReviewStateRequestChanges:
+			return ReviewStatusChangesRequested
+		case gitea.ReviewStateApproved:
+			approvals++
+		case gitea.ReviewStateComment, gitea.ReviewStatePending, gitea.ReviewStateRequestReview, gitea.ReviewStateUnknown:
+		}
+	}
+	switch {
+	case approvals < requiredApprovals:
+		return ReviewStatusRequired
+	case approvals > 0:
+		return ReviewStatusApproved
+	}
+	return ReviewStatusNone
+}
+
+// IsGiteaDraft indicates whether a Gitea pull request with the given title is a draft.
+// Gitea marks pull requests as work in progress through prefixes in their title.
+func IsGiteaDraft(title string) bool {
+	upperTitle := strings.ToUpper(title)
+	return strings.HasPrefix(upperTitle, "WIP:") || strings.HasPrefix(upperTitle, "[WIP]")
+}
+
+// parseGiteaPullRequest extracts standardized proposal data from the given Gitea pull request.
+func parseGiteaPullRequest(pullRequest *gitea.PullRequest) Proposal {
+	return Proposal{
+		CanMergeWithAPI: pullRequest.Mergeable,
+		Number:          int(pullRequest.Index),
+		Target:          pullRequest.Base.Ref,
+		Title:           pullRequest.Title,
+		Body:            pullRequest.Body,
+		Draft:           IsGiteaDraft(pullRequest.Title),
+		HeadSHA:         pullRequest.Head.Sha,
+	}
+}
+
 func FilterGiteaPullRequests(pullRequests []*gitea.PullRequest, organization, branch, target string) []*gitea.PullRequest {
 	result := []*gitea.PullRequest{}
 	headName := organization + "/" + branch
@@ -1,6 +1,7 @@
 package hosting_test
 
 import (
+	"net/http"
 	"testing"
 
 	"code.gitea.io/sdk/gitea"
@@ -24,7 +25,7 @@ func TestNewGiteaConnector(t *testing.T) {
 			hostingService: "gitea",
 			originURL:      "git@self-hosted-gitea.com:git-town/git-town.git",
 		}
-		connector, err := hosting.NewGiteaConnector(repoConfig, nil)
+		connector, err := hosting.NewGiteaConnector(repoConfig, nil, nil)
 		assert.Nil(t, err)
 		assert.NotNil(t, connector)
 		assert.Equal(t, "Gitea", connector.HostingServiceName())
@@ -37,7 +38,7 @@ func TestNewGiteaConnector(t *testing.T) {
 			originURL:      "git@my-ssh-identity.com:git-town/git-town.git",
 			originOverride: "gitea.com",
 		}
-		connector, err := hosting.NewGiteaConnector(repoConfig, nil)
+		connector, err := hosting.NewGiteaConnector(repoConfig, nil, nil)
 		assert.Nil(t, err)
 		assert.NotNil(t, connector)
 		assert.Equal(t, "Gitea", connector.HostingServiceName())
@@ -61,7 +62,7 @@ func TestGitea(t *testing.T) {
 		repoConfig := mockRepoConfig{
 			originURL: "git@gitea.com:git-town/git-town.git",
 		}
-		connector, err := hosting.NewGiteaConnector(repoConfig, nil)
+		connector, err := hosting.NewGiteaConnector(repoConfig, nil, nil)
 		assert.Nil(t, err)
 		have, err := connector.NewProposalURL("feature", "parent")
 		assert.Nil(t, err)
@@ -71,7 +72,7 @@ func TestGitea(t *testing.T) {
 		repoConfig := mockRepoConfig{
 			originURL: "git@gitea.com:git-town/git-town.git",
 		}
-		connector, err := hosting.NewGiteaConnector(repoConfig, nil)
+		connector, err := hosting.NewGiteaConnector(repoConfig, nil, nil)
 		assert.Nil(t, err)
 		have := connector.RepositoryURL()
 		assert.Equal(t, have, "https://gitea.com/git-town/git-town")
@@ -131,3 +132,146 @@ func TestFilterGiteaPullRequests(t *testing.T) {
 	have := hosting.FilterGiteaPullRequests(give, "organization", "branch", "target")
 	assert.Equal(t, want, have)
 }
+
+func TestGiteaCheckStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		give *gitea.CombinedStatus
+		want hosting.CheckStatus
+	}{
+		"no statuses": {
+			give: &gitea.CombinedStatus{State: gitea.StatusPending, TotalCount: 0}, //nolint:exhaustruct
+			want: hosting.CheckStatusNone,
+		},
+		"success": {
+			give: &gitea.CombinedStatus{State: gitea.StatusSuccess, TotalCount: 2}, //nolint:exhaustruct
+			want: hosting.CheckStatusSuccess,
+		},
+		"pending": {
+			give: &gitea.CombinedStatus{State: gitea.StatusPending, TotalCount: 1}, //nolint:exhaustruct
+			want: hosting.CheckStatusPending,
+		},
+		"error": {
+			give: &gitea.CombinedStatus{State: gitea.StatusError, TotalCount: 1}, //nolint:exhaustruct
+			want: hosting.CheckStatusFailure,
+		},
+	}
+	for name, test := range tests {
+		have := hosting.GiteaCheckStatus(test.give)
+		assert.Equal(t, test.want, have, name)
+	}
+}
+
+func TestGiteaReviewStatus(t *testing.T) {
+	t.Parallel()
+	review := func(reviewer int64, state gitea.ReviewStateType) *gitea.PullReview {
+		return &gitea.PullReview{Reviewer: &gitea.User{ID: reviewer}, State: state} //nolint:exhaustruct
+	}
+	tests := map[string]struct {
+		reviews           []*gitea.PullReview
+		requiredApprovals int64
+		want              hosting.ReviewStatus
+	}{
+		"no reviews": {
+			reviews:           []*gitea.PullReview{review(1, gitea.ReviewStateComment)},
+			requiredApprovals: 0,
+			want:              hosting.ReviewStatusNone,
+		},
+		"missing required approvals": {
+			reviews:           []*gitea.PullReview{review(1, gitea.ReviewStateRequestReview)},
+			requiredApprovals: 1,
+			want:              hosting.ReviewStatusRequired,
+		},
+		"requested review without required approvals": {
+			reviews:           []*gitea.PullReview{review(1, gitea.ReviewStateRequestReview)},
+			requiredApprovals: 0,
+			want:              hosting.ReviewStatusNone,
+		},
+		"approved": {
+			reviews:           []*gitea.PullReview{review(1, gitea.ReviewStateRequestReview), review(1, gitea.ReviewStateApproved)},
+			requiredApprovals: 1,
+			want:              hosting.ReviewStatusApproved,
+		},
+		"changes requested": {
+			reviews:           []*gitea.PullReview{review(1, gitea.ReviewStateApproved), review(2, gitea.ReviewStateRequestChanges)},
+			requiredApprovals: 0,
+			want:              hosting.ReviewStatusChangesRequested,
+		},
+	}
+	for name, test := range tests {
+		have := hosting.GiteaReviewStatus(test.reviews, test.requiredApprovals)
+		assert.Equal(t, test.want, have, name)
+	}
+}
+
+func TestIsGiteaDraft(t *testing.T) {
+	t.Parallel()
+	tests := map[string]bool{
+		"WIP: my feature":  true,
+		"[wip] my feature": true,
+		"my feature":       false,
+		"my WIP: feature":  false,
+	}
+	for give, want := range tests {
+		assert.Equal(t, want, hosting.IsGiteaDraft(give), give)
+	}
+}
+
+func TestGiteaMoveProposal(t *testing.T) {
+	t.Parallel()
+	proposal := hosting.Proposal{Number: 1, Title: "title", Body: "body"} //nolint:exhaustruct
+
+	t.Run("creates a new pull request", func(t *testing.T) {
+		t.Parallel()
+		server, requests := fakeAPI(t, map[string]fakeResponse{
+			"GET /api/v1/repos/org/repo/pulls":     {http.StatusOK, `[{"number":2,"head":{"label":"org/other"},"base":{"label":"main"}}]`},
+			"POST /api/v1/repos/org/repo/pulls":    {http.StatusCreated, `{"number":3}`},
+			"PATCH /api/v1/repos/org/repo/pulls/1": {http.StatusCreated, `{"number":1}`},
+		})
+		connector := hosting.NewGiteaTestConnector(server.URL, server.Client())
+		have, err := connector.MoveProposal(proposal, "new", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, have)
+		want := []string{
+			"GET /api/v1/repos/org/repo/pulls",
+			`POST /api/v1/repos/org/repo/pulls {"head":"new","base":"main","title":"title","body":"body","assignee":"","assignees":null,"milestone":0,"labels":null,"due_date":null}`,
+			`PATCH /api/v1/repos/org/repo/pulls/1 {"title":"title","body":"body","assignee":"","assignees":null,"milestone":0,"labels":null,"state":"closed","due_date":null}`,
+		}
+		assert.Equal(t, want, requests())
+	})
+
+	t.Run("reopens a closed pull request of the new branch", func(t *testing.T) {
+		t.Parallel()
+		server, requests := fakeAPI(t, map[string]fakeResponse{
+			"GET /api/v1/repos/org/repo/pulls":     {http.StatusOK, `[{"number":3,"title":"old title","head":{"label":"org/new"},"base":{"label":"main"}}]`},
+			"PATCH /api/v1/repos/org/repo/pulls/3": {http.StatusCreated, `{"number":3}`},
+			"PATCH /api/v1/repos/org/repo/pulls/1": {http.StatusCreated, `{"number":1}`},
+		})
+		connector := hosting.NewGiteaTestConnector(server.URL, server.Client())
+		have, err := connector.MoveProposal(proposal, "new", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, have)
+		want := []string{
+			"GET /api/v1/repos/org/repo/pulls",
+			`PATCH /api/v1/repos/org/repo/pulls/3 {"title":"old title","body":"","assignee":"","assignees":null,"milestone":0,"labels":null,"state":"open","due_date":null}`,
+			`PATCH /api/v1/repos/org/repo/pulls/1 {"title":"title","body":"body","assignee":"","assignees":null,"milestone":0,"labels":null,"state":"closed","due_date":null}`,
+		}
+		assert.Equal(t, want, requests())
+	})
+}
+
+func TestGiteaProposalStatus(t *testing.T) {
+	t.Parallel()
+	proposal := hosting.Proposal{Number: 1, Target: "main", HeadSHA: "abc"} //nolint:exhaustruct
+	server, _ := fakeAPI(t, map[string]fakeResponse{
+		// the Gitea client checks the server version before loading reviews
+		"GET /api/v1/version":                           {http.StatusOK, `{"version":"1.20.5"}`},
+		"GET /api/v1/repos/org/repo/commits/abc/status": {http.StatusOK, `{"state":"failure","total_count":1}`},
+		"GET /api/v1/repos/org/repo/pulls/1/reviews":    {http.StatusOK, `[{"user":{"id":1},"state":"APPROVED"}]`},
+		"GET /api/v1/repos/org/repo/branches/main":      {http.StatusOK, `{"name":"main","required_approvals":1}`},
+	})
+	connector := hosting.NewGiteaTestConnector(server.URL, server.Client())
+	have, err := connector.ProposalStatus(proposal)
+	assert.NoError(t, err)
+	assert.Equal(t, hosting.ProposalStatus{Checks: hosting.CheckStatusFailure, Review: hosting.ReviewStatusApproved}, have)
+}
@@ -3,13 +3,12 @@ package hosting
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
-	"os"
 	"strings"
 
 	"github.com/git-town/git-town/v8/src/config"
 	"github.com/google/go-github/v50/github"
-	"golang.org/x/oauth2"
 )
 
 // GitHubConnector provides standardized connectivity for the given repository (github.com/owner/repo)
@@ -28,7 +27,7 @@ func (c *GitHubConnector) FindProposal(branch, target string) (*Proposal, error)
 		State: "open",
 	})
 	if err != nil {
-		return nil, err
+		return nil, apiCallError("GitHub", "list open pull requests", err)
 	}
 	if len(pullRequests) == 0 {
 		return nil, nil //nolint:nilnil
@@ -40,6 +39,65 @@ func (c *GitHubConnector) FindProposal(branch, target string) (*Proposal, error)
 	return &proposal, nil
 }
 
+func (c *GitHubConnector) OpenProposals() (map[string]Proposal, error) {
+	pullRequests, _, err := c.client.PullRequests.List(context.Background(), c.Organization, c.Repository, &github.PullRequestListOptions{
+		State:       "open",
+		ListOptions: github.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return nil, apiCallError("GitHub", "list open pull requests", err)
+	}
+	result := map[string]Proposal{}
+	for _, pullRequest := range pullRequests {
+		// ignore pull requests from forks
+		if pullRequest.GetHead().GetLabel() == c.Organization+":"+pullRequest.GetHead().GetRef() {
+			result[pullRequest.GetHead().GetRef()] = parsePullRequest(pullRequest)
+		}
+	}
+	return result, nil
+}
+
+func (c *GitHubConnector) ProposalStatus(proposal Proposal) (ProposalStatus, error) {
+	ctx := context.Background()
+	checkRuns, _, err := c.client.Checks.ListCheckRunsForRef(ctx, c.Organization, c.Repository, proposal.HeadSHA, &github.ListCheckRunsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return ProposalStatus{}, apiCallError("GitHub", fmt.Sprintf("list check runs of pull request #%d", proposal.Number), err)
+	}
+	combinedStatus, _, err := c.client.Repositories.GetCombinedStatus(ctx, c.Organization, c.Repository, proposal.HeadSHA, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return ProposalStatus{}, apiCallError("GitHub", fmt.Sprintf("load commit statuses of pull request #%d", proposal.Number), err)
+	}
+	reviews, _, err := c.client.PullRequests.ListReviews(ctx, c.Organization, c.Repository, proposal.Number, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return ProposalStatus{}, apiCallError("GitHub", fmt.Sprintf("list reviews of pull request #%d", proposal.Number), err)
+	}
+	requiredApprovals, err := c.requiredApprovals(proposal.Target)
+	if err != nil {
+		return ProposalStatus{}, err
+	}
+	return ProposalStatus{
+		Checks: GitHubCheckStatus(checkRuns.CheckRuns, combinedStatus),
+		Review: GitHubReviewStatus(reviews, requiredApprovals),
+	}, nil
+}
+
+// requiredApprovals provides how many approving reviews the branch protection of the given branch requires.
+func (c *GitHubConnector) requiredApprovals(branch string) (int, error) {
+	enforcement, response, err := c.client.Repositories.GetPullRequestReviewEnforcement(context.Background(), c.Organization, c.Repository, branch)
+	if err != nil {
+		// GitHub answers 404 for unprotected branches
+		// and 403 to users who aren't allowed to see the branch protection.
+		// GitHub still enforces the required approvals when merging in the latter case.
+		if response != nil && (response.StatusCode == http.StatusNotFound || response.StatusCode == http.StatusForbidden) {
+			return 0, nil
+		}
+		return 0, apiCallError("GitHub", fmt.Sprintf("load the branch protection of branch %q", branch), err)
+	}
+	return enforcement.RequiredApprovingReviewCount, nil
+}
+
 func (c *GitHubConnector) DefaultProposalMessage(proposal Proposal) string {
 	return fmt.Sprintf("%s (#%d)", proposal.Title, proposal.Number)
 }
@@ -48,6 +106,55 @@ func (c *GitHubConnector) HostingServiceName() string {
 	return "GitHub"
 }
 
+func (c *GitHubConnector) MoveProposal(proposal Proposal, head, target string) (int, error) {
+	if c.log != nil {
+		c.log("GitHub API: moving PR #%d to branch %q\n", proposal.Number, head)
+	}
+	ctx := context.Background()
+	closedPullRequests, _, err := c.client.PullRequests.List(ctx, c.Organization, c.Repository, &github.PullRequestListOptions{
+		Head:  c.Organization + ":" + head,
+		Base:  target,
+		State: "closed",
+	})
+	if err != nil {
+		return 0, apiCallError("GitHub", "list closed pull requests", err)
+	}
+	newNumber := 0
+	for _, closedPullRequest := range closedPullRequests {
+		if closedPullRequest.MergedAt == nil {
+			newNumber = closedPullRequest.GetNumber()
+			break
+		}
+	}
+	if newNumber > 0 {
+		_, _, err = c.client.PullRequests.Edit(ctx, c.Organization, c.Repository, newNumber, &github.PullRequest{
+			State: github.String("open"),
+		})
+		if err != nil {
+			return 0, apiCallError("GitHub", fmt.Sprintf("reopen pull request #%d", newNumber), err)
+		}
+	} else {
+		var newPullRequest *github.PullRequest
+		newPullRequest, _, err = c.client.PullRequests.Create(ctx, c.Organization, c.Repository, &github.NewPullRequest{
+			Title: github.String(proposal.Title),
+			Head:  github.String(head),
+			Base:  github.String(target),
+			Body:  github.String(proposal.Body),
+		})
+		if err != nil {
+			return 0, apiCallError("GitHub", fmt.Sprintf("create pull request for branch %q", head), err)
+		}
+		newNumber = newPullRequest.GetNumber()
+	}
+	_, _, err = c.client.PullRequests.Edit(ctx, c.Organization, c.Repository, proposal.Number, &github.PullRequest{
+		State: github.String("closed"),
+	})
+	if err != nil {
+		return 0, apiCallError("GitHub", fmt.Sprintf("close pull request #%d", proposal.Number), err)
+	}
+	return newNumber, nil
+}
+
 func (c *GitHubConnector) NewProposalURL(branch, parentBranch string) (string, error) {
 	toCompare := branch
 	if parentBranch != c.MainBranch {
@@ -73,7 +180,10 @@ func (c *GitHubConnector) SquashMergeProposal(number int, message string) (merge
 		MergeMethod: "squash",
 		CommitTitle: title,
 	})
-	return result.GetSHA(), err
+	if err != nil {
+		return "", apiCallError("GitHub", fmt.Sprintf("merge pull request #%d", number), err)
+	}
+	return result.GetSHA(), nil
 }
 
 func (c *GitHubConnector) UpdateProposalTarget(number int, target string) error {
@@ -85,12 +195,15 @@ func (c *GitHubConnector) UpdateProposalTarget(number int, target string) error
 			Ref: &target,
 		},
 	})
-	return err
+	if err != nil {
+		return apiCallError("GitHub", fmt.Sprintf("update base branch of pull request #%d", number), err)
+	}
+	return nil
 }
 
 // NewGithubConnector provides a fully configured GithubConnector instance
 // if the current repo is hosted on Github, otherwise nil.
-func NewGithubConnector(gitConfig gitTownConfig, log logFn) (*GitHubConnector, error) {
+func NewGithubConnector(gitConfig gitTownConfig, git gitCommands, log logFn) (*GitHubConnector, error) {
 	hostingService, err := gitConfig.HostingService()
 	if err != nil {
 		return nil, err
@@ -99,11 +212,22 @@ func NewGithubConnector(gitConfig gitTownConfig, log logFn) (*GitHubConnector, e
 	if url == nil || (url.Host != "github.com" && hostingService != config.HostingServiceGitHub) {
 		return nil, nil //nolint:nilnil
 	}
-	apiToken := getGitHubAPIToken(gitConfig)
-	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: apiToken})
-	httpClient := oauth2.NewClient(context.Background(), tokenSource)
+	apiTimeout, err := gitConfig.APITimeout()
+	if err != nil {
+		return nil, err
+	}
+	apiToken := FindAPIToken(config.HostingServiceGitHub, url.Host, gitConfig, git).Value
+	httpClient := newOAuthHTTPClient(apiToken, apiTimeout)
+	client := github.NewClient(httpClient)
+	if url.Host != "github.com" {
+		// GitHub Enterprise servers provide their API under "/api/v3"
+		client, err = github.NewEnterpriseClient("https://"+url.Host+"/api/v3/", "https://"+url.Host+"/api/uploads/", httpClient)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &GitHubConnector{
-		client: github.NewClient(httpClient),
+		client: client,
 		CommonConfig: CommonConfig{
 			APIToken:     apiToken,
 			Hostname:     url.Host,
@@ -115,29 +239,75 @@ func NewGithubConnector(gitConfig gitTownConfig, log logFn) (*GitHubConnector, e
 	}, nil
 }
 
-// getGitHubApiToken returns the GitHub API token to use.
-// It first checks the GITHUB_TOKEN environment variable.
-// If that is not set, it checks the GITHUB_AUTH_TOKEN environment variable.
-// If that is not set, it checks the git config.
-func getGitHubAPIToken(gitConfig gitTownConfig) string {
-	apiToken := os.ExpandEnv("$GITHUB_TOKEN")
-	if apiToken == "" {
-		apiToken = os.ExpandEnv("$GITHUB_AUTH_TOKEN")
-	}
-	if apiToken == "" {
-		apiToken = gitConfig.GitHubToken()
-	}
-	return apiToken
-}
-
 // parsePullRequest extracts standardized proposal data from the given GitHub pull-request.
 func parsePullRequest(pullRequest *github.PullRequest) Proposal {
 	return Proposal{
 		Number:          pullRequest.GetNumber(),
 		Target:          pullRequest.Base.GetRef(),
 		Title:           pullRequest.GetTitle(),
+		Body:            pullRequest.GetBody(),
 		CanMergeWithAPI: pullRequest.GetMergeableState() == "clean",
+		Draft:           pullRequest.GetDraft(),
+		HeadSHA:         pullRequest.GetHead().GetSHA(),
+	}
+}
+
+// GitHubCheckStatus provides the combined status of the given GitHub check runs and commit statuses.
+func GitHubCheckStatus(checkRuns []*github.CheckRun, combinedStatus *github.CombinedStatus) CheckStatus {
+	statuses := []CheckStatus{}
+	for _, checkRun := range checkRuns {
+		if checkRun.GetStatus() != "completed" {
+			statuses = append(statuses, CheckStatusPending)
+			continue
+		}
+		switch checkRun.GetConclusion() {
+		case "failure", "cancelled", "timed_out", "action_required":
+			statuses = append(statuses, CheckStatusFailure)
+		default:
+			statuses = append(statuses, CheckStatusSuccess)
+		}
+	}
+	// GitHub reports the combined status of commits without statuses as pending
+	if combinedStatus != nil && combinedStatus.GetTotalCount() > 0 {
+		switch combinedStatus.GetState() {
+		case "success":
+			statuses = append(statuses, CheckStatusSuccess)
+		case "pending":
+			statuses = append(statuses, CheckStatusPending)
+		default:
+			statuses = append(statuses, CheckStatusFailure)
+		}
+	}
+	return combineCheckStatuses(statuses...)
+}
+
+// GitHubReviewStatus provides the review status of a GitHub pull request with the given reviews,
+// listed from oldest to newest, whose target branch requires the given number of approving reviews.
+// Only the latest review of each reviewer counts.
+func GitHubReviewStatus(reviews []*github.PullRequestReview, requiredApprovals int) ReviewStatus {
+	latestReviews := map[string]string{}
+	for _, review := range reviews {
+		switch review.GetState() {
+		case "APPROVED", "CHANGES_REQUESTED", "DISMISSED":
+			latestReviews[review.GetUser().GetLogin()] = review.GetState()
+		}
+	}
+	approvals := 0
+	for _, state := range latestReviews {
+		switch state {
+		case "CHANGES_REQUESTED":
+			return ReviewStatusChangesRequested
+		case "APPROVED":
+			approvals++
+		}
+	}
+	switch {
+	case approvals < requiredApprovals:
+		return ReviewStatusRequired
+	case approvals > 0:
+		return ReviewStatusApproved
 	}
+	return ReviewStatusNone
 }
 
 //nolint:nonamedreturns
@@ -1,10 +1,12 @@
 package hosting_test
 
 import (
+	"net/http"
 	"strings"
 	"testing"
 
 	"github.com/git-town/git-town/v8/src/hosting"
+	"github.com/google/go-github/v50/github"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,7 +17,7 @@ func TestNewGithubConnector(t *testing.T) {
 		repoConfig := mockRepoConfig{
 			originURL: "git@github.com:git-town/git-town.git",
 		}
-		connector, err := hosting.NewGithubConnector(repoConfig, nil)
+		connector, err := hosting.NewGithubConnector(repoConfig, nil, nil)
 		assert.Nil(t, err)
 		assert.NotNil(t, connector)
 		assert.Equal(t, "GitHub", connector.HostingServiceName())
@@ -28,7 +30,7 @@ func TestNewGithubConnector(t *testing.T) {
 			hostingService: "github",
 			originURL:      "git@self-hosted-github.com:git-town/git-town.git",
 		}
-		connector, err := hosting.NewGithubConnector(repoConfig, nil)
+		connector, err := hosting.NewGithubConnector(repoConfig, nil, nil)
 		assert.Nil(t, err)
 		assert.NotNil(t, connector)
 		assert.Equal(t, "GitHub", connector.HostingServiceName())
@@ -41,7 +43,7 @@ func TestNewGithubConnector(t *testing.T) {
 			originURL:      "git@my-ssh-identity.com:git-town/git-town.git",
 			originOverride: "github.com",
 		}
-		connector, err := hosting.NewGithubConnector(repoConfig, nil)
+		connector, err := hosting.NewGithubConnector(repoConfig, nil, nil)
 		assert.Nil(t, err)
 		assert.NotNil(t, connector)
 		assert.Equal(t, "GitHub", connector.HostingServiceName())
@@ -116,6 +118,98 @@ func TestGithubConnector(t *testing.T) {
 	})
 }
 
+func TestGitHubCheckStatus(t *testing.T) {
+	t.Parallel()
+	checkRun := func(status, conclusion string) *github.CheckRun {
+		return &github.CheckRun{Status: github.String(status), Conclusion: github.String(conclusion)} //nolint:exhaustruct
+	}
+	combinedStatus := func(state string, count int) *github.CombinedStatus {
+		return &github.CombinedStatus{State: github.String(state), TotalCount: github.Int(count)} //nolint:exhaustruct
+	}
+	tests := map[string]struct {
+		checkRuns      []*github.CheckRun
+		combinedStatus *github.CombinedStatus
+		want           hosting.CheckStatus
+	}{
+		"no checks": {
+			checkRuns:      []*github.CheckRun{},
+			combinedStatus: combinedStatus("pending", 0),
+			want:           hosting.CheckStatusNone,
+		},
+		"passing check runs": {
+			checkRuns:      []*github.CheckRun{checkRun("completed", "success"), checkRun("completed", "skipped")},
+			combinedStatus: combinedStatus("pending", 0),
+			want:           hosting.CheckStatusSuccess,
+		},
+		"running check run": {
+			checkRuns:      []*github.CheckRun{checkRun("completed", "success"), checkRun("in_progress", "")},
+			combinedStatus: combinedStatus("success", 1),
+			want:           hosting.CheckStatusPending,
+		},
+		"failing check run": {
+			checkRuns:      []*github.CheckRun{checkRun("completed", "timed_out"), checkRun("queued", "")},
+			combinedStatus: combinedStatus("success", 1),
+			want:           hosting.CheckStatusFailure,
+		},
+		"failing commit status": {
+			checkRuns:      []*github.CheckRun{checkRun("completed", "success")},
+			combinedStatus: combinedStatus("error", 2),
+			want:           hosting.CheckStatusFailure,
+		},
+	}
+	for name, test := range tests {
+		have := hosting.GitHubCheckStatus(test.checkRuns, test.combinedStatus)
+		assert.Equal(t, test.want, have, name)
+	}
+}
+
+func TestGitHubReviewStatus(t *testing.T) {
+	t.Parallel()
+	review := func(user, state string) *github.PullRequestReview {
+		return &github.PullRequestReview{User: &github.User{Login: github.String(user)}, State: github.String(state)} //nolint:exhaustruct
+	}
+	tests := map[string]struct {
+		reviews           []*github.PullRequestReview
+		requiredApprovals int
+		want              hosting.ReviewStatus
+	}{
+		"no reviews": {
+			reviews:           []*github.PullRequestReview{},
+			requiredApprovals: 0,
+			want:              hosting.ReviewStatusNone,
+		},
+		"missing required approvals": {
+			reviews:           []*github.PullRequestReview{review("alice", "COMMENTED")},
+			requiredApprovals: 1,
+			want:              hosting.ReviewStatusRequired,
+		},
+		"fewer approvals than required": {
+			reviews:           []*github.PullRequestReview{review("alice", "APPROVED"), review("alice", "APPROVED")},
+			requiredApprovals: 2,
+			want:              hosting.ReviewStatusRequired,
+		},
+		"approved": {
+			reviews:           []*github.PullRequestReview{review("alice", "APPROVED"), review("bob", "COMMENTED")},
+			requiredApprovals: 1,
+			want:              hosting.ReviewStatusApproved,
+		},
+		"changes requested": {
+			reviews:           []*github.PullRequestReview{review("alice", "APPROVED"), review("bob", "CHANGES_REQUESTED")},
+			requiredApprovals: 0,
+			want:              hosting.ReviewStatusChangesRequested,
+		},
+		"approved after requesting changes": {
+			reviews:           []*github.PullRequestReview{review("alice", "CHANGES_REQUESTED"), review("alice", "APPROVED")},
+			requiredApprovals: 0,
+			want:              hosting.ReviewStatusApproved,
+		},
+	}
+	for name, test := range tests {
+		have := hosting.GitHubReviewStatus(test.reviews, test.requiredApprovals)
+		assert.Equal(t, test.want, have, name)
+	}
+}
+
 func TestParseCommitMessage(t *testing.T) {
 	t.Parallel()
 	tests := map[string]struct {
@@ -149,3 +243,116 @@ func TestParseCommitMessage(t *testing.T) {
 		assert.Equal(t, want.body, haveBody, strings.ReplaceAll(give, "\n", "\\n"))
 	}
 }
+
+func TestGitHubMoveProposal(t *testing.T) {
+	t.Parallel()
+	proposal := hosting.Proposal{Number: 1, Title: "title", Body: "body"} //nolint:exhaustruct
+
+	t.Run("creates a new pull request", func(t *testing.T) {
+		t.Parallel()
+		server, requests := fakeAPI(t, map[string]fakeResponse{
+			"GET /api/v3/repos/org/repo/pulls":     {http.StatusOK, `[]`},
+			"POST /api/v3/repos/org/repo/pulls":    {http.StatusCreated, `{"number":2}`},
+			"PATCH /api/v3/repos/org/repo/pulls/1": {http.StatusOK, `{"number":1}`},
+		})
+		connector := hosting.NewGitHubTestConnector(server.URL, server.Client())
+		have, err := connector.MoveProposal(proposal, "new", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, have)
+		want := []string{
+			"GET /api/v3/repos/org/repo/pulls",
+			`POST /api/v3/repos/org/repo/pulls {"title":"title","head":"new","base":"main","body":"body"}`,
+			`PATCH /api/v3/repos/org/repo/pulls/1 {"state":"closed"}`,
+		}
+		assert.Equal(t, want, requests())
+	})
+
+	t.Run("reopens a closed pull request of the new branch", func(t *testing.T) {
+		t.Parallel()
+		server, requests := fakeAPI(t, map[string]fakeResponse{
+			"GET /api/v3/repos/org/repo/pulls":     {http.StatusOK, `[{"number":2,"merged_at":"2023-01-01T00:00:00Z"},{"number":3}]`},
+			"PATCH /api/v3/repos/org/repo/pulls/3": {http.StatusOK, `{"number":3}`},
+			"PATCH /api/v3/repos/org/repo/pulls/1": {http.StatusOK, `{"number":1}`},
+		})
+		connector := hosting.NewGitHubTestConnector(server.URL, server.Client())
+		have, err := connector.MoveProposal(proposal, "new", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, have)
+		want := []string{
+			"GET /api/v3/repos/org/repo/pulls",
+			`PATCH /api/v3/repos/org/repo/pulls/3 {"state":"open"}`,
+			`PATCH /api/v3/repos/org/repo/pulls/1 {"state":"closed"}`,
+		}
+		assert.Equal(t, want, requests())
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		t.Parallel()
+		server, _ := fakeAPI(t, map[string]fakeResponse{
+			"GET /api/v3/repos/org/repo/pulls": {http.StatusOK, `[]`},
+		})
+		connector := hosting.NewGitHubTestConnector(server.URL, server.Client())
+		_, err := connector.MoveProposal(proposal, "new", "main")
+		assert.ErrorContains(t, err, "create pull request for branch")
+	})
+}
+
+func TestGitHubProposalStatus(t *testing.T) {
+	t.Parallel()
+	proposal := hosting.Proposal{Number: 1, Target: "main", HeadSHA: "abc"} //nolint:exhaustruct
+
+	t.Run("required approvals from the branch protection", func(t *testing.T) {
+		t.Parallel()
+		server, _ := fakeAPI(t, map[string]fakeResponse{
+			"GET /api/v3/repos/org/repo/commits/abc/check-runs":                                 {http.StatusOK, `{"total_count":1,"check_runs":[{"status":"completed","conclusion":"success"}]}`},
+			"GET /api/v3/repos/org/repo/commits/abc/status":                                     {http.StatusOK, `{"state":"pending","total_count":0}`},
+			"GET /api/v3/repos/org/repo/pulls/1/reviews":                                        {http.StatusOK, `[{"user":{"login":"alice"},"state":"APPROVED"}]`},
+			"GET /api/v3/repos/org/repo/branches/main/protection/required_pull_request_reviews": {http.StatusOK, `{"required_approving_review_count":2}`},
+		})
+		connector := hosting.NewGitHubTestConnector(server.URL, server.Client())
+		have, err := connector.ProposalStatus(proposal)
+		assert.NoError(t, err)
+		assert.Equal(t, hosting.ProposalStatus{Checks: hosting.CheckStatusSuccess, Review: hosting.ReviewStatusRequired}, have)
+	})
+
+	t.Run("unprotected target branch", func(t *testing.T) {
+		t.Parallel()
+		server, _ := fakeAPI(t, map[string]fakeResponse{
+			"GET /api/v3/repos/org/repo/commits/abc/check-runs": {http.StatusOK, `{"total_count":0,"check_runs":[]}`},
+			"GET /api/v3/repos/org/repo/commits/abc/status":     {http.StatusOK, `{"state":"pending","total_count":0}`},
+			"GET /api/v3/repos/org/repo/pulls/1/reviews":        {http.StatusOK, `[]`},
+		})
+		connector := hosting.NewGitHubTestConnector(server.URL, server.Client())
+		have, err := connector.ProposalStatus(proposal)
+		assert.NoError(t, err)
+		assert.Equal(t, hosting.ProposalStatus{Checks: hosting.CheckStatusNone, Review: hosting.ReviewStatusNone}, have)
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		t.Parallel()
+		server, _ := fakeAPI(t, map[string]fakeResponse{
+			"GET /api/v3/repos/org/repo/commits/abc/check-runs": {http.StatusInternalServerError, `{"message":"boom"}`},
+		})
+		connector := hosting.NewGitHubTestConnector(server.URL, server.Client())
+		_, err := connector.ProposalStatus(proposal)
+		assert.ErrorContains(t, err, "list check runs of pull request #1")
+	})
+}
+
+func TestGitHubOpenProposals(t *testing.T) {
+	t.Parallel()
+	server, requests := fakeAPI(t, map[string]fakeResponse{
+		"GET /api/v3/repos/org/repo/pulls": {http.StatusOK, `[
+			{"number":1,"title":"one","head":{"label":"org:feature","ref":"feature","sha":"abc"},"base":{"ref":"main"}},
+			{"number":2,"title":"fork","head":{"label":"other:feature","ref":"feature","sha":"def"},"base":{"ref":"main"}}
+		]`},
+	})
+	connector := hosting.NewGitHubTestConnector(server.URL, server.Client())
+	have, err := connector.OpenProposals()
+	assert.NoError(t, err)
+	want := map[string]hosting.Proposal{
+		"feature": {Number: 1, Target: "main", Title: "one", Body: "", CanMergeWithAPI: false, Draft: false, HeadSHA: "abc"},
+	}
+	assert.Equal(t, want, have)
+	assert.Equal(t, []string{"GET /api/v3/repos/org/repo/pulls"}, requests())
+}
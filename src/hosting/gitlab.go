@@ -2,7 +2,6 @@ package hosting
 
 import (
 	"fmt"
-	"net/http"
 	"net/url"
 
 	"github.com/git-town/git-town/v8/src/config"
@@ -25,7 +24,7 @@ func (c *GitLabConnector) FindProposal(branch, target string) (*Proposal, error)
 	}
 	mergeRequests, _, err := c.client.MergeRequests.ListProjectMergeRequests(c.projectPath(), opts)
 	if err != nil {
-		return nil, err
+		return nil, apiCallError("GitLab", "list open merge requests", err)
 	}
 	if len(mergeRequests) == 0 {
 		return nil, nil //nolint:nilnil
@@ -37,6 +36,85 @@ func (c *GitLabConnector) FindProposal(branch, target string) (*Proposal, error)
 	return &proposal, nil
 }
 
+func (c *GitLabConnector) OpenProposals() (map[string]Proposal, error) {
+	opts := &gitlab.ListProjectMergeRequestsOptions{
+		State:       gitlab.String("opened"),
+		ListOptions: gitlab.ListOptions{PerPage: 100},
+	}
+	mergeRequests, _, err := c.client.MergeRequests.ListProjectMergeRequests(c.projectPath(), opts)
+	if err != nil {
+		return nil, apiCallError("GitLab", "list open merge requests", err)
+	}
+	result := map[string]Proposal{}
+	for _, mergeRequest := range mergeRequests {
+		// ignore merge requests from forks
+		if mergeRequest.SourceProjectID == mergeRequest.TargetProjectID {
+			result[mergeRequest.SourceBranch] = parseGitLabMergeRequest(mergeRequest)
+		}
+	}
+	return result, nil
+}
+
+func (c *GitLabConnector) ProposalStatus(proposal Proposal) (ProposalStatus, error) {
+	// only the API for individual merge requests provides their pipeline
+	mergeRequest, _, err := c.client.MergeRequests.GetMergeRequest(c.projectPath(), proposal.Number, &gitlab.GetMergeRequestsOptions{})
+	if err != nil {
+		return ProposalStatus{}, apiCallError("GitLab", fmt.Sprintf("load merge request !%d", proposal.Number), err)
+	}
+	// the approval rules of the project and the protected target branch define how many approvals are left
+	approvals, _, err := c.client.MergeRequestApprovals.GetConfiguration(c.projectPath(), proposal.Number)
+	if err != nil {
+		return ProposalStatus{}, apiCallError("GitLab", fmt.Sprintf("load approvals of merge request !%d", proposal.Number), err)
+	}
+	return ProposalStatus{
+		Checks: GitLabCheckStatus(mergeRequest.HeadPipeline),
+		Review: GitLabReviewStatus(approvals),
+	}, nil
+}
+
+func (c *GitLabConnector) MoveProposal(proposal Proposal, head, target string) (int, error) {
+	if c.log != nil {
+		c.log("GitLab API: Moving MR !%d to branch %q\n", proposal.Number, head)
+	}
+	closedMergeRequests, _, err := c.client.MergeRequests.ListProjectMergeRequests(c.projectPath(), &gitlab.ListProjectMergeRequestsOptions{
+		State:        gitlab.String("closed"),
+		SourceBranch: gitlab.String(head),
+		TargetBranch: gitlab.String(target),
+	})
+	if err != nil {
+		return 0, apiCallError("GitLab", "list closed merge requests", err)
+	}
+	var newNumber int
+	if len(closedMergeRequests) > 0 {
+		newNumber = closedMergeRequests[0].IID
+		_, _, err = c.client.MergeRequests.UpdateMergeRequest(c.projectPath(), newNumber, &gitlab.UpdateMergeRequestOptions{
+			StateEvent: gitlab.String("reopen"),
+		})
+		if err != nil {
+			return 0, apiCallError("GitLab", fmt.Sprintf("reopen merge request !%d", newNumber), err)
+		}
+	} else {
+		var newMergeRequest *gitlab.MergeRequest
+		newMergeRequest, _, err = c.client.MergeRequests.CreateMergeRequest(c.projectPath(), &gitlab.CreateMergeRequestOptions{
+			Title:        gitlab.String(proposal.Title),
+			Description:  gitlab.String(proposal.Body),
+			SourceBranch: gitlab.String(head),
+			TargetBranch: gitlab.String(target),
+		})
+		if err != nil {
+			return 0, apiCallError("GitLab", fmt.Sprintf("create merge request for branch %q", head), err)
+		}
+		newNumber = newMergeRequest.IID
+	}
+	_, _, err = c.client.MergeRequests.UpdateMergeRequest(c.projectPath(), proposal.Number, &gitlab.UpdateMergeRequestOptions{
+		StateEvent: gitlab.String("close"),
+	})
+	if err != nil {
+		return 0, apiCallError("GitLab", fmt.Sprintf("close merge request !%d", proposal.Number), err)
+	}
+	return newNumber, nil
+}
+
 //nolint:nonamedreturns  // return value isn't obvious from function name
 func (c *GitLabConnector) SquashMergeProposal(number int, message string) (mergeSHA string, err error) {
 	if number <= 0 {
@@ -53,7 +131,7 @@ func (c *GitLabConnector) SquashMergeProposal(number int, message string) (merge
 		ShouldRemoveSourceBranch: gitlab.Bool(false),
 	})
 	if err != nil {
-		return "", err
+		return "", apiCallError("GitLab", fmt.Sprintf("merge merge request !%d", number), err)
 	}
 	return result.SHA, nil
 }
@@ -65,12 +143,15 @@ func (c *GitLabConnector) UpdateProposalTarget(number int, target string) error
 	_, _, err := c.client.MergeRequests.UpdateMergeRequest(c.projectPath(), number, &gitlab.UpdateMergeRequestOptions{
 		TargetBranch: gitlab.String(target),
 	})
-	return err
+	if err != nil {
+		return apiCallError("GitLab", fmt.Sprintf("update target branch of merge request !%d", number), err)
+	}
+	return nil
 }
 
 // NewGitlabConfig provides GitLab configuration data if the current repo is hosted on GitLab,
 // otherwise nil.
-func NewGitlabConnector(gitConfig gitTownConfig, log logFn) (*GitLabConnector, error) {
+func NewGitlabConnector(gitConfig gitTownConfig, git gitCommands, log logFn) (*GitLabConnector, error) {
 	hostingService, err := gitConfig.HostingService()
 	if err != nil {
 		return nil, err
@@ -79,15 +160,20 @@ func NewGitlabConnector(gitConfig gitTownConfig, log logFn) (*GitLabConnector, e
 	if url == nil || (url.Host != "gitlab.com" && hostingService != config.HostingServiceGitLab) {
 		return nil, nil //nolint:nilnil
 	}
+	apiTimeout, err := gitConfig.APITimeout()
+	if err != nil {
+		return nil, err
+	}
 	gitlabConfig := GitLabConfig{CommonConfig{
-		APIToken:     gitConfig.GitLabToken(),
+		APIToken:     FindAPIToken(config.HostingServiceGitLab, url.Host, gitConfig, git).Value,
 		Hostname:     url.Host,
 		Organization: url.Org,
 		Repository:   url.Repo,
 	}}
 	clientOptFunc := gitlab.WithBaseURL(gitlabConfig.baseURL())
-	httpClient := gitlab.WithHTTPClient(&http.Client{})
-	client, err := gitlab.NewOAuthClient(gitlabConfig.APIToken, httpClient, clientOptFunc)
+	httpClient := gitlab.WithHTTPClient(newHTTPClient(apiTimeout))
+	// the shared HTTP client retries failed requests
+	client, err := gitlab.NewOAuthClient(gitlabConfig.APIToken, httpClient, clientOptFunc, gitlab.WithoutRetries())
 	if err != nil {
 		return nil, err
 	}
@@ -143,6 +229,39 @@ func parseGitLabMergeRequest(mergeRequest *gitlab.MergeRequest) Proposal {
 		Number:          mergeRequest.IID,
 		Target:          mergeRequest.TargetBranch,
 		Title:           mergeRequest.Title,
+		Body:            mergeRequest.Description,
 		CanMergeWithAPI: true,
+		Draft:           mergeRequest.Draft || mergeRequest.WorkInProgress,
+		HeadSHA:         mergeRequest.SHA,
+	}
+}
+
+// GitLabCheckStatus provides the check status of a GitLab merge request with the given head pipeline.
+func GitLabCheckStatus(pipeline *gitlab.Pipeline) CheckStatus {
+	if pipeline == nil {
+		return CheckStatusNone
+	}
+	switch pipeline.Status {
+	case "success":
+		return CheckStatusSuccess
+	case "failed", "canceled":
+		return CheckStatusFailure
+	case "skipped":
+		return CheckStatusNone
+	}
+	// the pipeline is created, pending, running, scheduled, or waits for resources or manual actions
+	return CheckStatusPending
+}
+
+// GitLabReviewStatus provides the review status of a GitLab merge request with the given approvals.
+func GitLabReviewStatus(approvals *gitlab.MergeRequestApprovals) ReviewStatus {
+	switch {
+	case approvals == nil:
+		return ReviewStatusNone
+	case approvals.ApprovalsLeft > 0:
+		return ReviewStatusRequired
+	case len(approvals.ApprovedBy) > 0:
+		return ReviewStatusApproved
 	}
+	return ReviewStatusNone
 }
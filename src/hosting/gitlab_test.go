@@ -1,10 +1,12 @@
 package hosting_test
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/git-town/git-town/v8/src/hosting"
 	"github.com/stretchr/testify/assert"
+	"github.com/xanzy/go-gitlab"
 )
 
 const (
@@ -24,7 +26,7 @@ func TestNewGitlabConnector(t *testing.T) {
 		repoConfig := mockRepoConfig{
 			originURL: "git@gitlab.com:gitlab-com/www-gitlab-com.git",
 		}
-		connector, err := hosting.NewGitlabConnector(repoConfig, nil)
+		connector, err := hosting.NewGitlabConnector(repoConfig, nil, nil)
 		assert.NoError(t, err)
 		assert.NotNil(t, connector)
 		assert.Equal(t, "GitLab", connector.HostingServiceName())
@@ -36,20 +38,31 @@ func TestNewGitlabConnector(t *testing.T) {
 		repoConfig := mockRepoConfig{
 			originURL: "git@gitlab.com:gitlab-org/quality/triage-ops.git",
 		}
-		connector, err := hosting.NewGitlabConnector(repoConfig, nil)
+		connector, err := hosting.NewGitlabConnector(repoConfig, nil, nil)
 		assert.NoError(t, err)
 		assert.NotNil(t, connector)
 		assert.Equal(t, "GitLab", connector.HostingServiceName())
 		assert.Equal(t, "https://gitlab.com/gitlab-org/quality/triage-ops", connector.RepositoryURL())
 	})
 
+	t.Run("SSH URL with port and nested subgroups", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			originURL: "ssh://git@gitlab.com:2222/group/sub/subsub/repo.git",
+		}
+		connector, err := hosting.NewGitlabConnector(repoConfig, nil, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, connector)
+		assert.Equal(t, "https://gitlab.com/group/sub/subsub/repo", connector.RepositoryURL())
+	})
+
 	t.Run("self-hosted GitLab server", func(t *testing.T) {
 		t.Parallel()
 		repoConfig := mockRepoConfig{
 			hostingService: "gitlab",
 			originURL:      "git@self-hosted-gitlab.com:git-town/git-town.git",
 		}
-		connector, err := hosting.NewGitlabConnector(repoConfig, nil)
+		connector, err := hosting.NewGitlabConnector(repoConfig, nil, nil)
 		assert.NoError(t, err)
 		assert.NotNil(t, connector)
 		assert.Equal(t, "GitLab", connector.HostingServiceName())
@@ -62,7 +75,7 @@ func TestNewGitlabConnector(t *testing.T) {
 			originURL:      "git@my-ssh-identity.com:git-town/git-town.git",
 			originOverride: "gitlab.com",
 		}
-		connector, err := hosting.NewGitlabConnector(repoConfig, nil)
+		connector, err := hosting.NewGitlabConnector(repoConfig, nil, nil)
 		assert.NoError(t, err)
 		assert.NotNil(t, connector)
 		assert.Equal(t, "GitLab", connector.HostingServiceName())
@@ -125,3 +138,120 @@ func TestGitlabConnector(t *testing.T) {
 		}
 	})
 }
+
+func TestGitLabCheckStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]hosting.CheckStatus{
+		"success":  hosting.CheckStatusSuccess,
+		"failed":   hosting.CheckStatusFailure,
+		"canceled": hosting.CheckStatusFailure,
+		"running":  hosting.CheckStatusPending,
+		"manual":   hosting.CheckStatusPending,
+		"skipped":  hosting.CheckStatusNone,
+	}
+	for give, want := range tests {
+		have := hosting.GitLabCheckStatus(&gitlab.Pipeline{Status: give}) //nolint:exhaustruct
+		assert.Equal(t, want, have, give)
+	}
+	assert.Equal(t, hosting.CheckStatusNone, hosting.GitLabCheckStatus(nil))
+}
+
+func TestGitLabReviewStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		give *gitlab.MergeRequestApprovals
+		want hosting.ReviewStatus
+	}{
+		"no approval rules": {
+			give: &gitlab.MergeRequestApprovals{}, //nolint:exhaustruct
+			want: hosting.ReviewStatusNone,
+		},
+		"missing approvals": {
+			give: &gitlab.MergeRequestApprovals{ApprovalsLeft: 1, ApprovedBy: []*gitlab.MergeRequestApproverUser{{}}}, //nolint:exhaustruct
+			want: hosting.ReviewStatusRequired,
+		},
+		"approved": {
+			give: &gitlab.MergeRequestApprovals{ApprovalsLeft: 0, ApprovedBy: []*gitlab.MergeRequestApproverUser{{}}}, //nolint:exhaustruct
+			want: hosting.ReviewStatusApproved,
+		},
+	}
+	for name, test := range tests {
+		have := hosting.GitLabReviewStatus(test.give)
+		assert.Equal(t, test.want, have, name)
+	}
+}
+
+func TestGitLabMoveProposal(t *testing.T) {
+	t.Parallel()
+	proposal := hosting.Proposal{Number: 1, Title: "title", Body: "body"} //nolint:exhaustruct
+
+	t.Run("creates a new merge request", func(t *testing.T) {
+		t.Parallel()
+		server, requests := fakeAPI(t, map[string]fakeResponse{
+			"GET /api/v4/projects/org/repo/merge_requests":   {http.StatusOK, `[]`},
+			"POST /api/v4/projects/org/repo/merge_requests":  {http.StatusCreated, `{"iid":2}`},
+			"PUT /api/v4/projects/org/repo/merge_requests/1": {http.StatusOK, `{"iid":1}`},
+		})
+		connector := hosting.NewGitLabTestConnector(server.URL, server.Client())
+		have, err := connector.MoveProposal(proposal, "new", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, have)
+		want := []string{
+			"GET /api/v4/", // the GitLab client determines the rate limit
+			"GET /api/v4/projects/org/repo/merge_requests",
+			`POST /api/v4/projects/org/repo/merge_requests {"title":"title","description":"body","source_branch":"new","target_branch":"main"}`,
+			`PUT /api/v4/projects/org/repo/merge_requests/1 {"state_event":"close"}`,
+		}
+		assert.Equal(t, want, requests())
+	})
+
+	t.Run("reopens a closed merge request of the new branch", func(t *testing.T) {
+		t.Parallel()
+		server, requests := fakeAPI(t, map[string]fakeResponse{
+			"GET /api/v4/projects/org/repo/merge_requests":   {http.StatusOK, `[{"iid":3}]`},
+			"PUT /api/v4/projects/org/repo/merge_requests/3": {http.StatusOK, `{"iid":3}`},
+			"PUT /api/v4/projects/org/repo/merge_requests/1": {http.StatusOK, `{"iid":1}`},
+		})
+		connector := hosting.NewGitLabTestConnector(server.URL, server.Client())
+		have, err := connector.MoveProposal(proposal, "new", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, have)
+		want := []string{
+			"GET /api/v4/", // the GitLab client determines the rate limit
+			"GET /api/v4/projects/org/repo/merge_requests",
+			`PUT /api/v4/projects/org/repo/merge_requests/3 {"state_event":"reopen"}`,
+			`PUT /api/v4/projects/org/repo/merge_requests/1 {"state_event":"close"}`,
+		}
+		assert.Equal(t, want, requests())
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		t.Parallel()
+		server, _ := fakeAPI(t, map[string]fakeResponse{
+			"GET /api/v4/projects/org/repo/merge_requests":  {http.StatusOK, `[]`},
+			"POST /api/v4/projects/org/repo/merge_requests": {http.StatusCreated, `{"iid":2}`},
+		})
+		connector := hosting.NewGitLabTestConnector(server.URL, server.Client())
+		_, err := connector.MoveProposal(proposal, "new", "main")
+		assert.ErrorContains(t, err, "close merge request !1")
+	})
+}
+
+func TestGitLabOpenProposals(t *testing.T) {
+	t.Parallel()
+	server, _ := fakeAPI(t, map[string]fakeResponse{
+		// the GitLab client loads this to detect rate limits
+		"GET /api/v4/": {http.StatusOK, `{}`},
+		"GET /api/v4/projects/org/repo/merge_requests": {http.StatusOK, `[
+			{"iid":1,"title":"one","source_branch":"feature","target_branch":"main","source_project_id":7,"target_project_id":7,"sha":"abc"},
+			{"iid":2,"title":"fork","source_branch":"feature","target_branch":"main","source_project_id":8,"target_project_id":7,"sha":"def"}
+		]`},
+	})
+	connector := hosting.NewGitLabTestConnector(server.URL, server.Client())
+	have, err := connector.OpenProposals()
+	assert.NoError(t, err)
+	want := map[string]hosting.Proposal{
+		"feature": {Number: 1, Target: "main", Title: "one", Body: "", CanMergeWithAPI: true, Draft: false, HeadSHA: "abc"},
+	}
+	assert.Equal(t, want, have)
+}
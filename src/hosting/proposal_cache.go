@@ -0,0 +1,111 @@
+package hosting
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ProposalCacheMaxAge is how long Git Town uses proposals from the ProposalCache before it looks them up again.
+const ProposalCacheMaxAge = 2 * time.Minute
+
+// ProposalCache stores the proposals that Git Town looked up recently on disk
+// so that repeated lookups, for example when switching branches, are fast.
+// It is safe for concurrent use.
+type ProposalCache struct {
+	entries map[string]cachedProposal
+	maxAge  time.Duration
+	mutex   sync.Mutex
+	path    string
+}
+
+// cachedProposal is a proposal in the ProposalCache.
+type cachedProposal struct {
+	// nil if the branch has no proposal
+	Proposal *Proposal `json:"proposal"`
+
+	// when Git Town looked up this proposal
+	Time time.Time `json:"time"`
+}
+
+// ProposalCachePath provides the path of the file that caches the proposals of the repository with the given URL.
+func ProposalCachePath(repositoryURL string) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256([]byte(repositoryURL))
+	return filepath.Join(cacheDir, "git-town", "proposals", hex.EncodeToString(hash[:8])+".json"), nil
+}
+
+// LoadProposalCache provides the ProposalCache stored in the file with the given path.
+// It ignores entries older than the given max age.
+// It provides an empty cache if the file doesn't exist or is unreadable.
+func LoadProposalCache(path string, maxAge time.Duration) *ProposalCache {
+	result := ProposalCache{
+		entries: map[string]cachedProposal{},
+		maxAge:  maxAge,
+		mutex:   sync.Mutex{},
+		path:    path,
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return &result
+	}
+	entries := map[string]cachedProposal{}
+	if json.Unmarshal(content, &entries) != nil {
+		return &result
+	}
+	for key, entry := range entries {
+		if time.Since(entry.Time) < maxAge {
+			result.entries[key] = entry
+		}
+	}
+	return &result
+}
+
+// Lookup provides the cached proposal for the given branch into the given target branch.
+// The boolean return value indicates whether the cache knows about this branch.
+// A known branch without proposal returns nil and true.
+func (pc *ProposalCache) Lookup(branch, target string) (*Proposal, bool) {
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+	entry, has := pc.entries[proposalCacheKey(branch, target)]
+	if !has || time.Since(entry.Time) >= pc.maxAge {
+		return nil, false
+	}
+	return entry.Proposal, true
+}
+
+// Save writes this cache to disk.
+func (pc *ProposalCache) Save() error {
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+	content, err := json.Marshal(pc.entries)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(pc.path), 0o700)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(pc.path, content, 0o600)
+}
+
+// Store remembers the given proposal for the given branch into the given target branch.
+// The given proposal is nil if the branch has no proposal.
+func (pc *ProposalCache) Store(branch, target string, proposal *Proposal) {
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+	pc.entries[proposalCacheKey(branch, target)] = cachedProposal{Proposal: proposal, Time: time.Now()}
+}
+
+// proposalCacheKey provides the key under which the ProposalCache stores the proposal for the given branch into the given target branch.
+// Git doesn't allow colons in branch names.
+func proposalCacheKey(branch, target string) string {
+	return branch + ":" + target
+}
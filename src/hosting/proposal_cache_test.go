@@ -0,0 +1,56 @@
+package hosting_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/git-town/git-town/v8/src/hosting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProposalCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stores proposals on disk", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "proposals", "cache.json")
+		cache := hosting.LoadProposalCache(path, time.Minute)
+		_, found := cache.Lookup("feature", "main")
+		assert.False(t, found)
+		proposal := hosting.Proposal{Number: 12, Target: "main", Title: "my feature", Draft: true} //nolint:exhaustruct
+		cache.Store("feature", "main", &proposal)
+		cache.Store("other", "main", nil)
+		assert.NoError(t, cache.Save())
+		loaded := hosting.LoadProposalCache(path, time.Minute)
+		have, found := loaded.Lookup("feature", "main")
+		assert.True(t, found)
+		assert.Equal(t, &proposal, have)
+		have, found = loaded.Lookup("other", "main")
+		assert.True(t, found)
+		assert.Nil(t, have)
+		_, found = loaded.Lookup("feature", "other-target")
+		assert.False(t, found)
+	})
+
+	t.Run("ignores expired entries", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "cache.json")
+		cache := hosting.LoadProposalCache(path, time.Minute)
+		cache.Store("feature", "main", nil)
+		assert.NoError(t, cache.Save())
+		loaded := hosting.LoadProposalCache(path, time.Nanosecond)
+		_, found := loaded.Lookup("feature", "main")
+		assert.False(t, found)
+	})
+
+	t.Run("ignores unreadable cache files", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "cache.json")
+		assert.NoError(t, os.WriteFile(path, []byte("zonk"), 0o600))
+		cache := hosting.LoadProposalCache(path, time.Minute)
+		_, found := cache.Lookup("feature", "main")
+		assert.False(t, found)
+	})
+}
@@ -0,0 +1,97 @@
+package hosting
+
+import (
+	"fmt"
+)
+
+// ProposalStatus describes whether the checks and reviews of a proposal allow shipping it.
+type ProposalStatus struct {
+	// the combined result of the CI checks for the latest commit of the proposal
+	Checks CheckStatus
+
+	// whether the proposal is approved or still needs reviews
+	Review ReviewStatus
+}
+
+// CheckStatus describes the combined result of the CI checks for the latest commit of a proposal.
+type CheckStatus string
+
+const (
+	// CheckStatusNone indicates that no checks ran for the proposal.
+	CheckStatusNone CheckStatus = ""
+	// CheckStatusPending indicates that some checks haven't finished yet and none failed so far.
+	CheckStatusPending CheckStatus = "pending"
+	// CheckStatusSuccess indicates that all checks passed.
+	CheckStatusSuccess CheckStatus = "success"
+	// CheckStatusFailure indicates that at least one check failed.
+	CheckStatusFailure CheckStatus = "failure"
+)
+
+// combineCheckStatuses provides the overall status of the given check statuses.
+// A single failing check makes the whole proposal fail,
+// a single pending check makes it pending.
+func combineCheckStatuses(statuses ...CheckStatus) CheckStatus {
+	result := CheckStatusNone
+	for _, status := range statuses {
+		switch status {
+		case CheckStatusFailure:
+			return CheckStatusFailure
+		case CheckStatusPending:
+			result = CheckStatusPending
+		case CheckStatusSuccess:
+			if result == CheckStatusNone {
+				result = CheckStatusSuccess
+			}
+		case CheckStatusNone:
+		}
+	}
+	return result
+}
+
+// ReviewStatus describes where a proposal stands regarding code reviews.
+type ReviewStatus string
+
+const (
+	// ReviewStatusNone indicates that the proposal has no reviews and doesn't require any.
+	ReviewStatusNone ReviewStatus = ""
+	// ReviewStatusRequired indicates that the proposal has fewer approving reviews than the target branch requires.
+	ReviewStatusRequired ReviewStatus = "review required"
+	// ReviewStatusApproved indicates that reviewers approved the proposal.
+	ReviewStatusApproved ReviewStatus = "approved"
+	// ReviewStatusChangesRequested indicates that reviewers requested changes to the proposal.
+	ReviewStatusChangesRequested ReviewStatus = "changes requested"
+)
+
+// ShipBlockers provides the reasons why the given proposal with the given status isn't ready to ship.
+// Returns an empty list if it is ready.
+func ShipBlockers(proposal Proposal, status ProposalStatus) []string {
+	result := []string{}
+	if proposal.Draft {
+		result = append(result, "it is a draft")
+	}
+	switch status.Checks {
+	case CheckStatusFailure:
+		result = append(result, "its checks failed")
+	case CheckStatusPending:
+		result = append(result, "its checks are still running")
+	case CheckStatusNone, CheckStatusSuccess:
+	}
+	switch status.Review {
+	case ReviewStatusChangesRequested:
+		result = append(result, "reviewers requested changes")
+	case ReviewStatusRequired:
+		result = append(result, "it doesn't have the required approvals")
+	case ReviewStatusNone, ReviewStatusApproved:
+	}
+	return result
+}
+
+// ProposalSummary provides a short human-readable description of the given proposal,
+// for example "#12 draft".
+func ProposalSummary(proposal Proposal) string {
+	result := fmt.Sprintf("#%d", proposal.Number)
+	if proposal.Draft {
+		result += " draft"
+	}
+	return result
+}
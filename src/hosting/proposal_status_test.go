@@ -0,0 +1,58 @@
+package hosting_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v8/src/hosting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShipBlockers(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		proposal hosting.Proposal
+		status   hosting.ProposalStatus
+		want     []string
+	}{
+		"ready": {
+			proposal: hosting.Proposal{}, //nolint:exhaustruct
+			status:   hosting.ProposalStatus{Checks: hosting.CheckStatusSuccess, Review: hosting.ReviewStatusApproved},
+			want:     []string{},
+		},
+		"no checks and reviews": {
+			proposal: hosting.Proposal{}, //nolint:exhaustruct
+			status:   hosting.ProposalStatus{Checks: hosting.CheckStatusNone, Review: hosting.ReviewStatusNone},
+			want:     []string{},
+		},
+		"draft with failing checks": {
+			proposal: hosting.Proposal{Draft: true}, //nolint:exhaustruct
+			status:   hosting.ProposalStatus{Checks: hosting.CheckStatusFailure, Review: hosting.ReviewStatusNone},
+			want:     []string{"it is a draft", "its checks failed"},
+		},
+		"running checks and missing approvals": {
+			proposal: hosting.Proposal{}, //nolint:exhaustruct
+			status:   hosting.ProposalStatus{Checks: hosting.CheckStatusPending, Review: hosting.ReviewStatusRequired},
+			want:     []string{"its checks are still running", "it doesn't have the required approvals"},
+		},
+		"changes requested": {
+			proposal: hosting.Proposal{}, //nolint:exhaustruct
+			status:   hosting.ProposalStatus{Checks: hosting.CheckStatusNone, Review: hosting.ReviewStatusChangesRequested},
+			want:     []string{"reviewers requested changes"},
+		},
+	}
+	for name, test := range tests {
+		have := hosting.ShipBlockers(test.proposal, test.status)
+		assert.Equal(t, test.want, have, name)
+	}
+}
+
+func TestProposalSummary(t *testing.T) {
+	t.Parallel()
+	tests := map[string]hosting.Proposal{
+		"#1":       {Number: 1},              //nolint:exhaustruct
+		"#3 draft": {Number: 3, Draft: true}, //nolint:exhaustruct
+	}
+	for want, give := range tests {
+		assert.Equal(t, want, hosting.ProposalSummary(give))
+	}
+}
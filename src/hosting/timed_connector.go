@@ -0,0 +1,49 @@
+package hosting
+
+import "time"
+
+// Statistics records how long API calls take.
+type Statistics interface {
+	RegisterAPICall(name string, start time.Time, duration time.Duration)
+}
+
+// TimedConnector is a Connector that registers the duration of the API calls of the Connector it wraps.
+type TimedConnector struct {
+	Connector
+	Stats Statistics
+}
+
+func (tc TimedConnector) FindProposal(branch, target string) (*Proposal, error) {
+	defer tc.register("FindProposal", time.Now())
+	return tc.Connector.FindProposal(branch, target)
+}
+
+func (tc TimedConnector) MoveProposal(proposal Proposal, head, target string) (int, error) {
+	defer tc.register("MoveProposal", time.Now())
+	return tc.Connector.MoveProposal(proposal, head, target)
+}
+
+func (tc TimedConnector) OpenProposals() (map[string]Proposal, error) {
+	defer tc.register("OpenProposals", time.Now())
+	return tc.Connector.OpenProposals()
+}
+
+func (tc TimedConnector) ProposalStatus(proposal Proposal) (ProposalStatus, error) {
+	defer tc.register("ProposalStatus", time.Now())
+	return tc.Connector.ProposalStatus(proposal)
+}
+
+func (tc TimedConnector) SquashMergeProposal(number int, message string) (string, error) {
+	defer tc.register("SquashMergeProposal", time.Now())
+	return tc.Connector.SquashMergeProposal(number, message)
+}
+
+func (tc TimedConnector) UpdateProposalTarget(number int, target string) error {
+	defer tc.register("UpdateProposalTarget", time.Now())
+	return tc.Connector.UpdateProposalTarget(number, target)
+}
+
+// register registers the API call with the given name that started at the given time and ends now.
+func (tc TimedConnector) register(name string, start time.Time) {
+	tc.Stats.RegisterAPICall(tc.HostingServiceName()+" "+name, start, time.Since(start))
+}
@@ -0,0 +1,245 @@
+package hosting
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// RetryTransport is an http.RoundTripper that retries requests that failed for temporary reasons.
+// It retries:
+// - requests that exceeded a rate limit, including GitHub's secondary rate limits
+// - requests with safe methods like GET that failed with a server error or a network error
+// It doesn't retry other requests because the server might have processed them.
+// It waits for the duration in the "Retry-After" header of the response if there is one,
+// otherwise it backs off exponentially.
+type RetryTransport struct {
+	// the transport that performs the requests
+	Base http.RoundTripper
+
+	// how often to retry a failed request
+	MaxRetries int
+
+	// how long each attempt to perform a request may take, zero means no limit.
+	// This limits the attempts rather than the whole request
+	// so that waiting for a rate limit to expire doesn't time out the request.
+	AttemptTimeout time.Duration
+
+	// how long to wait before the first retry, doubles for each further retry
+	InitialBackoff time.Duration
+
+	// the longest time to wait before a retry,
+	// doesn't retry requests that the server asks to retry later than this
+	MaxWait time.Duration
+
+	// waits for the given duration, can be replaced in tests
+	Sleep func(ctx context.Context, duration time.Duration) error
+}
+
+// NewRetryTransport provides a RetryTransport with the default settings.
+func NewRetryTransport() *RetryTransport {
+	return &RetryTransport{
+		Base:           http.DefaultTransport,
+		MaxRetries:     3,
+		AttemptTimeout: 0,
+		InitialBackoff: time.Second,
+		MaxWait:        time.Minute,
+		Sleep:          sleep,
+	}
+}
+
+func (rt *RetryTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	attemptRequest := request
+	for attempt := 0; ; attempt++ {
+		response, err := rt.attempt(attemptRequest)
+		wait, shouldRetry := rt.retryWait(request, response, err, attempt)
+		if !shouldRetry {
+			return response, err
+		}
+		nextRequest, cloneErr := cloneRequest(request)
+		if cloneErr != nil {
+			return response, err
+		}
+		if response != nil {
+			_, _ = io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+		err = rt.Sleep(request.Context(), wait)
+		if err != nil {
+			return nil, err
+		}
+		attemptRequest = nextRequest
+	}
+}
+
+// attempt performs the given request once, within the attempt timeout.
+func (rt *RetryTransport) attempt(request *http.Request) (*http.Response, error) {
+	if rt.AttemptTimeout == 0 {
+		return rt.Base.RoundTrip(request)
+	}
+	ctx, cancel := context.WithTimeout(request.Context(), rt.AttemptTimeout)
+	response, err := rt.Base.RoundTrip(request.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return response, err
+	}
+	// the timeout also applies to reading the body of the response
+	response.Body = &cancelOnClose{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+// cancelOnClose is a response body that cancels the context of its request when closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (coc *cancelOnClose) Close() error {
+	err := coc.ReadCloser.Close()
+	coc.cancel()
+	return err
+}
+
+// retryWait indicates whether to retry the given request after the given attempt failed with the given response or error,
+// and how long to wait before retrying.
+func (rt *RetryTransport) retryWait(request *http.Request, response *http.Response, err error, attempt int) (time.Duration, bool) {
+	if attempt >= rt.MaxRetries || request.Context().Err() != nil {
+		return 0, false
+	}
+	backoff := rt.InitialBackoff << attempt
+	if err != nil {
+		return backoff, isSafeMethod(request.Method)
+	}
+	var wait time.Duration
+	switch {
+	case isRateLimited(response):
+		wait = rateLimitWait(response, backoff)
+	case response.StatusCode >= 500 && isSafeMethod(request.Method):
+		wait = retryAfter(response, backoff)
+	default:
+		return 0, false
+	}
+	return wait, wait <= rt.MaxWait
+}
+
+// cloneRequest provides a copy of the given request that can be sent again.
+func cloneRequest(request *http.Request) (*http.Request, error) {
+	result := request.Clone(request.Context())
+	if request.Body == nil || request.Body == http.NoBody {
+		return result, nil
+	}
+	if request.GetBody == nil {
+		return nil, fmt.Errorf("cannot resend the body of request %s %s", request.Method, request.URL)
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	result.Body = body
+	return result, nil
+}
+
+// isRateLimited indicates whether the given response rejects a request because it exceeded a rate limit.
+// GitHub signals exceeded secondary rate limits through 403 responses.
+func isRateLimited(response *http.Response) bool {
+	if response.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if response.StatusCode != http.StatusForbidden {
+		return false
+	}
+	if response.Header.Get("Retry-After") != "" || response.Header.Get("X-RateLimit-Remaining") == "0" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(peekBody(response)), "secondary rate limit")
+}
+
+// rateLimitWait provides how long to wait until the rate limit that the given response reports expires.
+func rateLimitWait(response *http.Response, backoff time.Duration) time.Duration {
+	if response.Header.Get("Retry-After") == "" && response.Header.Get("X-RateLimit-Remaining") == "0" {
+		reset, err := strconv.ParseInt(response.Header.Get("X-RateLimit-Reset"), 10, 64)
+		if err == nil {
+			return maxDuration(time.Until(time.Unix(reset, 0)), 0)
+		}
+	}
+	return retryAfter(response, backoff)
+}
+
+// retryAfter provides the duration in the "Retry-After" header of the given response,
+// or the given default duration if the response doesn't contain this header.
+func retryAfter(response *http.Response, defaultDuration time.Duration) time.Duration {
+	header := response.Header.Get("Retry-After")
+	if header == "" {
+		return defaultDuration
+	}
+	seconds, err := strconv.Atoi(header)
+	if err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	date, err := http.ParseTime(header)
+	if err == nil {
+		return maxDuration(time.Until(date), 0)
+	}
+	return defaultDuration
+}
+
+// peekBody provides the body of the given response without consuming it.
+func peekBody(response *http.Response) string {
+	content, err := io.ReadAll(io.LimitReader(response.Body, 100_000))
+	response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(content))
+	if err != nil {
+		return ""
+	}
+	return string(content)
+}
+
+// isSafeMethod indicates whether requests with the given HTTP method don't change data on the server.
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// sleep waits for the given duration unless the given context ends earlier.
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// newHTTPClient provides the HTTP client through which connectors talk to the API of code hosting services.
+// It retries failed requests and times out attempts that take longer than the given timeout.
+func newHTTPClient(timeout time.Duration) *http.Client {
+	transport := NewRetryTransport()
+	transport.AttemptTimeout = timeout
+	return &http.Client{Transport: transport}
+}
+
+// newOAuthHTTPClient provides an HTTP client like newHTTPClient that authenticates requests with the given token.
+func newOAuthHTTPClient(apiToken string, timeout time.Duration) *http.Client {
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, newHTTPClient(timeout))
+	return oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: apiToken}))
+}
+
+// apiCallError describes that the API call with the given description to the given code hosting service failed with the given error.
+func apiCallError(service, call string, err error) error {
+	return fmt.Errorf("%s API call %q failed: %w", service, call, err)
+}
@@ -0,0 +1,198 @@
+package hosting_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/git-town/git-town/v8/src/hosting"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyServer provides a local HTTP server that answers requests with the given responses in order.
+// It answers all further requests with 200 OK.
+// The returned function provides how many requests the server received.
+func flakyServer(t *testing.T, responses []flakyResponse) (*httptest.Server, func() int) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		count := int(requests.Add(1))
+		if count > len(responses) {
+			fmt.Fprint(writer, "ok")
+			return
+		}
+		response := responses[count-1]
+		time.Sleep(response.delay)
+		for key, value := range response.header {
+			writer.Header().Set(key, value)
+		}
+		writer.WriteHeader(response.status)
+		fmt.Fprint(writer, response.body)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() int { return int(requests.Load()) }
+}
+
+type flakyResponse struct {
+	status int
+	header map[string]string
+	body   string
+	delay  time.Duration
+}
+
+// testTransport provides a RetryTransport that records how long it waits instead of waiting.
+func testTransport(waits *[]time.Duration) *hosting.RetryTransport {
+	transport := hosting.NewRetryTransport()
+	transport.Sleep = func(ctx context.Context, duration time.Duration) error {
+		*waits = append(*waits, duration)
+		return nil
+	}
+	return transport
+}
+
+func TestRetryTransport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("retries server errors with exponential backoff", func(t *testing.T) {
+		t.Parallel()
+		server, requests := flakyServer(t, []flakyResponse{
+			{status: http.StatusBadGateway},
+			{status: http.StatusServiceUnavailable},
+		})
+		waits := []time.Duration{}
+		client := &http.Client{Transport: testTransport(&waits)}
+		response, err := client.Get(server.URL) //nolint:noctx
+		assert.NoError(t, err)
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 3, requests())
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, waits)
+	})
+
+	t.Run("gives up after the maximum number of retries", func(t *testing.T) {
+		t.Parallel()
+		server, requests := flakyServer(t, []flakyResponse{
+			{status: http.StatusInternalServerError},
+			{status: http.StatusInternalServerError},
+			{status: http.StatusInternalServerError},
+			{status: http.StatusInternalServerError},
+		})
+		waits := []time.Duration{}
+		client := &http.Client{Transport: testTransport(&waits)}
+		response, err := client.Get(server.URL) //nolint:noctx
+		assert.NoError(t, err)
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+		assert.Equal(t, 4, requests())
+	})
+
+	t.Run("doesn't retry server errors for requests that change data", func(t *testing.T) {
+		t.Parallel()
+		server, requests := flakyServer(t, []flakyResponse{{status: http.StatusBadGateway}})
+		waits := []time.Duration{}
+		client := &http.Client{Transport: testTransport(&waits)}
+		response, err := client.Post(server.URL, "application/json", strings.NewReader("{}")) //nolint:noctx
+		assert.NoError(t, err)
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusBadGateway, response.StatusCode)
+		assert.Equal(t, 1, requests())
+	})
+
+	t.Run("retries rate-limited requests that change data after the time in Retry-After", func(t *testing.T) {
+		t.Parallel()
+		server, requests := flakyServer(t, []flakyResponse{
+			{status: http.StatusForbidden, header: map[string]string{"Retry-After": "7"}, body: `{"message":"You have exceeded a secondary rate limit."}`},
+		})
+		waits := []time.Duration{}
+		client := &http.Client{Transport: testTransport(&waits)}
+		response, err := client.Post(server.URL, "application/json", strings.NewReader("{}")) //nolint:noctx
+		assert.NoError(t, err)
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 2, requests())
+		assert.Equal(t, []time.Duration{7 * time.Second}, waits)
+	})
+
+	t.Run("recognizes secondary rate limits by their message", func(t *testing.T) {
+		t.Parallel()
+		server, requests := flakyServer(t, []flakyResponse{
+			{status: http.StatusForbidden, body: `{"message":"You have exceeded a secondary rate limit."}`},
+		})
+		waits := []time.Duration{}
+		client := &http.Client{Transport: testTransport(&waits)}
+		response, err := client.Get(server.URL) //nolint:noctx
+		assert.NoError(t, err)
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 2, requests())
+		assert.Equal(t, []time.Duration{time.Second}, waits)
+	})
+
+	t.Run("doesn't retry other forbidden requests", func(t *testing.T) {
+		t.Parallel()
+		server, requests := flakyServer(t, []flakyResponse{
+			{status: http.StatusForbidden, body: `{"message":"Resource not accessible by integration"}`},
+		})
+		waits := []time.Duration{}
+		client := &http.Client{Transport: testTransport(&waits)}
+		response, err := client.Get(server.URL) //nolint:noctx
+		assert.NoError(t, err)
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusForbidden, response.StatusCode)
+		assert.Equal(t, 1, requests())
+		assert.Empty(t, waits)
+	})
+
+	t.Run("doesn't wait longer than the maximum wait time", func(t *testing.T) {
+		t.Parallel()
+		server, requests := flakyServer(t, []flakyResponse{
+			{status: http.StatusTooManyRequests, header: map[string]string{"Retry-After": "3600"}},
+		})
+		waits := []time.Duration{}
+		client := &http.Client{Transport: testTransport(&waits)}
+		response, err := client.Get(server.URL) //nolint:noctx
+		assert.NoError(t, err)
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+		assert.Equal(t, 1, requests())
+		assert.Empty(t, waits)
+	})
+
+	t.Run("waits for rate limits that expire later than the timeout", func(t *testing.T) {
+		t.Parallel()
+		server, requests := flakyServer(t, []flakyResponse{
+			{status: http.StatusTooManyRequests, header: map[string]string{"Retry-After": "1"}},
+		})
+		client := hosting.NewTestHTTPClient(200 * time.Millisecond)
+		response, err := client.Get(server.URL) //nolint:noctx
+		assert.NoError(t, err)
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 2, requests())
+	})
+
+	t.Run("retries attempts that time out", func(t *testing.T) {
+		t.Parallel()
+		server, requests := flakyServer(t, []flakyResponse{
+			{status: http.StatusOK, delay: time.Second},
+		})
+		waits := []time.Duration{}
+		transport := testTransport(&waits)
+		transport.AttemptTimeout = 200 * time.Millisecond
+		client := &http.Client{Transport: transport}
+		response, err := client.Get(server.URL) //nolint:noctx
+		assert.NoError(t, err)
+		defer response.Body.Close()
+		body, err := io.ReadAll(response.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", string(body))
+		assert.Equal(t, 2, requests())
+		assert.Equal(t, []time.Duration{time.Second}, waits)
+	})
+}
@@ -0,0 +1,192 @@
+// Package offlinequeue records the network operations that Git Town skipped while offline
+// so that it can perform them once it is online again.
+package offlinequeue
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/persistence"
+)
+
+// OperationType describes the kind of a deferred network operation.
+type OperationType string
+
+const (
+	// CreateTrackingBranch pushes a local branch that doesn't exist at origin yet.
+	CreateTrackingBranch OperationType = "create-tracking-branch"
+	// PushBranch pushes a branch to its tracking branch.
+	PushBranch OperationType = "push-branch"
+	// UpdateProposalTarget changes the target branch of the proposal for a branch.
+	UpdateProposalTarget OperationType = "update-proposal-target"
+)
+
+// Operation is a network operation that Git Town skipped while offline.
+type Operation struct {
+	Type OperationType `json:"type"`
+
+	// the branch to push, or whose proposal to update
+	Branch string `json:"branch"`
+
+	// whether pushing the branch requires a force-push
+	ForceWithLease bool `exhaustruct:"optional" json:"forceWithLease,omitempty"`
+
+	// the target branch of the proposal before it changed
+	OldTarget string `exhaustruct:"optional" json:"oldTarget,omitempty"`
+
+	// the target branch that the proposal should have
+	NewTarget string `exhaustruct:"optional" json:"newTarget,omitempty"`
+}
+
+func (op Operation) String() string {
+	switch op.Type {
+	case CreateTrackingBranch:
+		return fmt.Sprintf("create the tracking branch for %q", op.Branch)
+	case PushBranch:
+		if op.ForceWithLease {
+			return fmt.Sprintf("force-push branch %q", op.Branch)
+		}
+		return fmt.Sprintf("push branch %q", op.Branch)
+	case UpdateProposalTarget:
+		return fmt.Sprintf("change the target of the proposal for %q from %q to %q", op.Branch, op.OldTarget, op.NewTarget)
+	}
+	return fmt.Sprintf("unknown operation %q for %q", op.Type, op.Branch)
+}
+
+// Queue contains the network operations that Git Town skipped while offline, in the order they happened.
+type Queue struct {
+	Operations []Operation `json:"operations"`
+}
+
+// Add appends the given operation to this queue.
+// It merges the given operation into an already queued operation of the same type for the same branch.
+func (q *Queue) Add(op Operation) {
+	for i, queued := range q.Operations {
+		if queued.Branch != op.Branch || queued.Type != op.Type {
+			continue
+		}
+		switch op.Type {
+		case CreateTrackingBranch:
+		case PushBranch:
+			q.Operations[i].ForceWithLease = queued.ForceWithLease || op.ForceWithLease
+		case UpdateProposalTarget:
+			// the proposal still has the target it had before Git Town went offline
+			q.Operations[i].NewTarget = op.NewTarget
+		}
+		return
+	}
+	// creating the tracking branch pushes all commits of the branch
+	if op.Type == PushBranch && q.Contains(Operation{Type: CreateTrackingBranch, Branch: op.Branch}) {
+		return
+	}
+	q.Operations = append(q.Operations, op)
+}
+
+// Contains indicates whether this queue contains an operation of the same type for the same branch as the given operation.
+func (q *Queue) Contains(op Operation) bool {
+	for _, queued := range q.Operations {
+		if queued.Branch == op.Branch && queued.Type == op.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmpty indicates whether this queue contains no operations.
+func (q *Queue) IsEmpty() bool {
+	return len(q.Operations) == 0
+}
+
+// Lookup provides the queued operation of the same type for the same branch as the given operation,
+// and whether this queue contains such an operation.
+func (q *Queue) Lookup(op Operation) (Operation, bool) {
+	for _, queued := range q.Operations {
+		if queued.Branch == op.Branch && queued.Type == op.Type {
+			return queued, true
+		}
+	}
+	return Operation{Type: "", Branch: ""}, false
+}
+
+// Remove removes the operation of the same type for the same branch as the given operation from this queue.
+func (q *Queue) Remove(op Operation) {
+	result := make([]Operation, 0, len(q.Operations))
+	for _, queued := range q.Operations {
+		if queued.Branch != op.Branch || queued.Type != op.Type {
+			result = append(result, queued)
+		}
+	}
+	q.Operations = result
+}
+
+// Replace replaces the queued operation of the same type for the same branch as the given operation
+// with the given operation.
+func (q *Queue) Replace(op Operation) {
+	for i, queued := range q.Operations {
+		if queued.Branch == op.Branch && queued.Type == op.Type {
+			q.Operations[i] = op
+		}
+	}
+}
+
+// FilePath provides the path of the file that stores the queue for the Git repository of the given backend.
+func FilePath(backend *git.BackendCommands) (string, error) {
+	return persistence.FilePath("offline-queue", backend)
+}
+
+// Load provides the queue stored in the file with the given path.
+// Provides an empty queue if the file doesn't exist.
+func Load(path string) (Queue, error) {
+	result := Queue{Operations: []Operation{}}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return result, nil
+		}
+		return result, fmt.Errorf("cannot read file %q: %w", path, err)
+	}
+	err = json.Unmarshal(content, &result)
+	if err != nil {
+		return result, fmt.Errorf("cannot parse content of file %q: %w", path, err)
+	}
+	return result, nil
+}
+
+// Save stores the given queue in the file with the given path.
+// Removes the file if the queue is empty.
+func Save(queue Queue, path string) error {
+	if queue.IsEmpty() {
+		err := os.Remove(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("cannot delete file %q: %w", path, err)
+		}
+		return nil
+	}
+	content, err := json.MarshalIndent(queue, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot encode the offline queue: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(path, content, 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot write file %q: %w", path, err)
+	}
+	return nil
+}
+
+// Update applies the given change to the queue stored in the file with the given path.
+func Update(path string, change func(*Queue)) error {
+	queue, err := Load(path)
+	if err != nil {
+		return err
+	}
+	change(&queue)
+	return Save(queue, path)
+}
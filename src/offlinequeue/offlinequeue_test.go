@@ -0,0 +1,132 @@
+package offlinequeue_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/git-town/git-town/v8/src/offlinequeue"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueue(t *testing.T) {
+	t.Parallel()
+
+	t.Run(".Add()", func(t *testing.T) {
+		t.Parallel()
+		t.Run("different branches", func(t *testing.T) {
+			t.Parallel()
+			queue := offlinequeue.Queue{Operations: []offlinequeue.Operation{}}
+			queue.Add(offlinequeue.Operation{Type: offlinequeue.PushBranch, Branch: "branch1"})
+			queue.Add(offlinequeue.Operation{Type: offlinequeue.PushBranch, Branch: "branch2"})
+			want := []offlinequeue.Operation{
+				{Type: offlinequeue.PushBranch, Branch: "branch1"},
+				{Type: offlinequeue.PushBranch, Branch: "branch2"},
+			}
+			assert.Equal(t, want, queue.Operations)
+		})
+		t.Run("merges pushes of the same branch", func(t *testing.T) {
+			t.Parallel()
+			queue := offlinequeue.Queue{Operations: []offlinequeue.Operation{}}
+			queue.Add(offlinequeue.Operation{Type: offlinequeue.PushBranch, Branch: "branch", ForceWithLease: true})
+			queue.Add(offlinequeue.Operation{Type: offlinequeue.PushBranch, Branch: "branch"})
+			want := []offlinequeue.Operation{
+				{Type: offlinequeue.PushBranch, Branch: "branch", ForceWithLease: true},
+			}
+			assert.Equal(t, want, queue.Operations)
+		})
+		t.Run("doesn't push branches whose tracking branch it creates", func(t *testing.T) {
+			t.Parallel()
+			queue := offlinequeue.Queue{Operations: []offlinequeue.Operation{}}
+			queue.Add(offlinequeue.Operation{Type: offlinequeue.CreateTrackingBranch, Branch: "branch"})
+			queue.Add(offlinequeue.Operation{Type: offlinequeue.PushBranch, Branch: "branch"})
+			want := []offlinequeue.Operation{
+				{Type: offlinequeue.CreateTrackingBranch, Branch: "branch"},
+			}
+			assert.Equal(t, want, queue.Operations)
+		})
+		t.Run("keeps the original target of proposals", func(t *testing.T) {
+			t.Parallel()
+			queue := offlinequeue.Queue{Operations: []offlinequeue.Operation{}}
+			queue.Add(offlinequeue.Operation{Type: offlinequeue.UpdateProposalTarget, Branch: "branch", OldTarget: "parent1", NewTarget: "parent2"})
+			queue.Add(offlinequeue.Operation{Type: offlinequeue.UpdateProposalTarget, Branch: "branch", OldTarget: "parent2", NewTarget: "main"})
+			want := []offlinequeue.Operation{
+				{Type: offlinequeue.UpdateProposalTarget, Branch: "branch", OldTarget: "parent1", NewTarget: "main"},
+			}
+			assert.Equal(t, want, queue.Operations)
+		})
+	})
+
+	t.Run(".Lookup()", func(t *testing.T) {
+		t.Parallel()
+		queue := offlinequeue.Queue{Operations: []offlinequeue.Operation{
+			{Type: offlinequeue.PushBranch, Branch: "branch", ForceWithLease: true},
+		}}
+		have, found := queue.Lookup(offlinequeue.Operation{Type: offlinequeue.PushBranch, Branch: "branch"})
+		assert.True(t, found)
+		assert.Equal(t, offlinequeue.Operation{Type: offlinequeue.PushBranch, Branch: "branch", ForceWithLease: true}, have)
+		_, found = queue.Lookup(offlinequeue.Operation{Type: offlinequeue.CreateTrackingBranch, Branch: "branch"})
+		assert.False(t, found)
+	})
+
+	t.Run(".Remove()", func(t *testing.T) {
+		t.Parallel()
+		queue := offlinequeue.Queue{Operations: []offlinequeue.Operation{
+			{Type: offlinequeue.CreateTrackingBranch, Branch: "branch1"},
+			{Type: offlinequeue.PushBranch, Branch: "branch2", ForceWithLease: true},
+		}}
+		queue.Remove(offlinequeue.Operation{Type: offlinequeue.PushBranch, Branch: "branch2"})
+		want := []offlinequeue.Operation{
+			{Type: offlinequeue.CreateTrackingBranch, Branch: "branch1"},
+		}
+		assert.Equal(t, want, queue.Operations)
+	})
+
+	t.Run(".Replace()", func(t *testing.T) {
+		t.Parallel()
+		queue := offlinequeue.Queue{Operations: []offlinequeue.Operation{
+			{Type: offlinequeue.PushBranch, Branch: "branch1", ForceWithLease: true},
+			{Type: offlinequeue.PushBranch, Branch: "branch2"},
+		}}
+		queue.Replace(offlinequeue.Operation{Type: offlinequeue.PushBranch, Branch: "branch1"})
+		want := []offlinequeue.Operation{
+			{Type: offlinequeue.PushBranch, Branch: "branch1"},
+			{Type: offlinequeue.PushBranch, Branch: "branch2"},
+		}
+		assert.Equal(t, want, queue.Operations)
+	})
+}
+
+func TestPersistence(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+		queue, err := offlinequeue.Load(filepath.Join(t.TempDir(), "queue.json"))
+		assert.NoError(t, err)
+		assert.True(t, queue.IsEmpty())
+	})
+
+	t.Run("save and load", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "dir", "queue.json")
+		err := offlinequeue.Update(path, func(queue *offlinequeue.Queue) {
+			queue.Add(offlinequeue.Operation{Type: offlinequeue.PushBranch, Branch: "branch"})
+		})
+		assert.NoError(t, err)
+		queue, err := offlinequeue.Load(path)
+		assert.NoError(t, err)
+		assert.Equal(t, []offlinequeue.Operation{{Type: offlinequeue.PushBranch, Branch: "branch"}}, queue.Operations)
+	})
+
+	t.Run("saving an empty queue removes the file", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "queue.json")
+		err := offlinequeue.Save(offlinequeue.Queue{Operations: []offlinequeue.Operation{{Type: offlinequeue.PushBranch, Branch: "branch"}}}, path)
+		assert.NoError(t, err)
+		err = offlinequeue.Save(offlinequeue.Queue{Operations: []offlinequeue.Operation{}}, path)
+		assert.NoError(t, err)
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
@@ -0,0 +1,31 @@
+// Package parallel runs independent jobs concurrently with a bounded number of workers.
+package parallel
+
+import "sync"
+
+// Run executes the given jobs concurrently, with at most maxWorkers of them running at the same time.
+// After each finished job it calls the given progress function with the number of finished jobs.
+// The progress function doesn't need to be thread-safe.
+// Returns the errors of the jobs in the order of the given jobs.
+func Run(maxWorkers int, jobs []func() error, progress func(finished int)) []error {
+	errs := make([]error, len(jobs))
+	slots := make(chan struct{}, maxWorkers)
+	var progressMutex sync.Mutex
+	finished := 0
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(len(jobs))
+	for i, job := range jobs {
+		slots <- struct{}{}
+		go func(i int, job func() error) {
+			defer waitGroup.Done()
+			errs[i] = job()
+			<-slots
+			progressMutex.Lock()
+			finished++
+			progress(finished)
+			progressMutex.Unlock()
+		}(i, job)
+	}
+	waitGroup.Wait()
+	return errs
+}
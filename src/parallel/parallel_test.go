@@ -0,0 +1,61 @@
+package parallel_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/git-town/git-town/v8/src/parallel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("runs all jobs and provides their errors in order", func(t *testing.T) {
+		t.Parallel()
+		err2 := errors.New("job 2 failed")
+		jobs := []func() error{
+			func() error { return nil },
+			func() error { return err2 },
+			func() error { return nil },
+		}
+		progress := []int{}
+		have := parallel.Run(2, jobs, func(finished int) { progress = append(progress, finished) })
+		assert.Equal(t, []error{nil, err2, nil}, have)
+		assert.Equal(t, []int{1, 2, 3}, progress)
+	})
+
+	t.Run("runs at most the given number of jobs at the same time", func(t *testing.T) {
+		t.Parallel()
+		var mutex sync.Mutex
+		running := 0
+		maxRunning := 0
+		jobs := make([]func() error, 10)
+		for i := range jobs {
+			jobs[i] = func() error {
+				mutex.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				mutex.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				mutex.Lock()
+				running--
+				mutex.Unlock()
+				return nil
+			}
+		}
+		parallel.Run(3, jobs, func(int) {})
+		assert.LessOrEqual(t, maxRunning, 3)
+		assert.Greater(t, maxRunning, 1)
+	})
+
+	t.Run("no jobs", func(t *testing.T) {
+		t.Parallel()
+		have := parallel.Run(2, []func() error{}, func(int) {})
+		assert.Equal(t, []error{}, have)
+	})
+}
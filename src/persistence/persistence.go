@@ -0,0 +1,37 @@
+// Package persistence determines where Git Town stores data about individual Git repositories on disk.
+package persistence
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/git-town/git-town/v8/src/git"
+)
+
+// FilePath provides the path of the file in the given subdirectory of the Git Town configuration directory
+// that stores data about the Git repository of the given backend.
+func FilePath(subdir string, backend *git.BackendCommands) (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	repoDir, err := backend.RootDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "git-town", subdir, SanitizePath(repoDir)+".json"), nil
+}
+
+func SanitizePath(dir string) string {
+	replaceCharacterRE := regexp.MustCompile("[[:^alnum:]]")
+	sanitized := replaceCharacterRE.ReplaceAllString(dir, "-")
+	sanitized = strings.ToLower(sanitized)
+	replaceDoubleMinusRE := regexp.MustCompile("--+") // two or more dashes
+	sanitized = replaceDoubleMinusRE.ReplaceAllString(sanitized, "-")
+	for strings.HasPrefix(sanitized, "-") {
+		sanitized = sanitized[1:]
+	}
+	return sanitized
+}
@@ -0,0 +1,298 @@
+// Package preflight predicts the merge conflicts that executing a step list would cause,
+// before Git Town touches the working tree.
+package preflight
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/runstate"
+	"github.com/git-town/git-town/v8/src/steps"
+	"github.com/git-town/git-town/v8/src/stringslice"
+)
+
+// Operation describes how a branch receives the changes of another branch.
+type Operation string
+
+const (
+	Merge       Operation = "merge"
+	Rebase      Operation = "rebase"
+	SquashMerge Operation = "squash-merge"
+)
+
+// Conflict is a merge or rebase that the user would have to resolve manually.
+type Conflict struct {
+	// the branch that receives the changes
+	Branch string
+	// the branch whose changes it receives
+	Other     string
+	Operation Operation
+	// the files that would contain merge conflicts
+	Files []string
+}
+
+func (c Conflict) String() string {
+	files := strings.Join(c.Files, ", ")
+	switch c.Operation {
+	case Merge:
+		return fmt.Sprintf("merging %q into %q conflicts in %s", c.Other, c.Branch, files)
+	case Rebase:
+		return fmt.Sprintf("rebasing %q onto %q conflicts in %s", c.Branch, c.Other, files)
+	case SquashMerge:
+		return fmt.Sprintf("squash-merging %q into %q conflicts in %s", c.Other, c.Branch, files)
+	}
+	return fmt.Sprintf("%s of %q and %q conflicts in %s", c.Operation, c.Branch, c.Other, files)
+}
+
+// Branches provides the names of the branches that the given conflicts happen on.
+func Branches(conflicts []Conflict) []string {
+	result := []string{}
+	for _, conflict := range conflicts {
+		result = append(result, conflict.Branch)
+	}
+	return result
+}
+
+// Report describes the given conflicts to the user.
+// It points out that the predictions for rebases are approximate
+// because the simulation doesn't replay the individual commits of rebased branches.
+func Report(conflicts []Conflict) string {
+	lines := []string{"Git Town predicts these merge conflicts:"}
+	hasRebases := false
+	for _, conflict := range conflicts {
+		lines = append(lines, "- "+conflict.String())
+		hasRebases = hasRebases || conflict.Operation == Rebase
+	}
+	if hasRebases {
+		lines = append(lines, "Predictions for rebases are approximate, rebasing can also conflict in individual commits.")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// IsSupported indicates whether the installed Git version can predict merge conflicts.
+func IsSupported(backend *git.BackendCommands) (bool, error) {
+	major, minor, err := backend.Version()
+	if err != nil {
+		return false, err
+	}
+	// "git merge-tree --write-tree" exists since Git 2.38
+	return major > 2 || (major == 2 && minor >= 38), nil
+}
+
+// PredictConflicts simulates the merges and rebases in the given step list
+// in the object database, without changing the working tree or any branches,
+// and provides the conflicts they would cause.
+// It assumes that Git Town skips the branches that conflict.
+// Rebases are simulated as merges of the rebased branch,
+// so they predict the conflicts between the final states of the branches.
+// Provides no conflicts in dry-run mode, for step lists without merges or rebases,
+// and if the installed Git version cannot simulate merges.
+func PredictConflicts(list runstate.StepList, initialBranch string, backend *git.BackendCommands) ([]Conflict, error) {
+	if backend.Config.DryRun || !hasMerges(list) {
+		return []Conflict{}, nil
+	}
+	isSupported, err := IsSupported(backend)
+	if err != nil || !isSupported {
+		return []Conflict{}, err
+	}
+	sim := simulation{
+		backend:     backend,
+		branchStart: nil,
+		conflicts:   []Conflict{},
+		current:     initialBranch,
+		heads:       map[string]*simulatedCommit{},
+		mergeBases:  map[[2]string]string{},
+		skipping:    false,
+	}
+	for _, step := range list.List {
+		err = sim.apply(step)
+		if err != nil {
+			return []Conflict{}, err
+		}
+	}
+	return sim.conflicts, nil
+}
+
+// hasMerges indicates whether the given step list merges or rebases branches.
+func hasMerges(list runstate.StepList) bool {
+	for _, step := range list.List {
+		switch step.(type) {
+		case *steps.MergeStep, *steps.RebaseBranchStep, *steps.SquashMergeStep:
+			return true
+		}
+	}
+	return false
+}
+
+// simulation tracks the state of the branches while simulating a step list.
+type simulation struct {
+	backend *git.BackendCommands
+	// the simulated commit of the current branch before the simulation changed it
+	branchStart *simulatedCommit
+	conflicts   []Conflict
+	// the branch that the simulated step list has checked out
+	current string
+	// the simulated commits of the branches that the simulation has looked at
+	heads map[string]*simulatedCommit
+	// the merge bases that the simulation has determined, by the SHAs of the merged commits
+	mergeBases map[[2]string]string
+	// whether the current branch conflicts and the remaining steps for it don't matter
+	skipping bool
+}
+
+// simulatedCommit is a commit that a branch points to during the simulation.
+// Commits that the simulation creates get written to the object database
+// only when a later step needs them.
+type simulatedCommit struct {
+	sha     string // empty until the commit exists in the object database
+	tree    string
+	parents []string
+}
+
+// apply simulates the given step.
+func (s *simulation) apply(step steps.Step) error {
+	switch step := step.(type) {
+	case *steps.CheckoutStep:
+		s.current = step.Branch
+		s.branchStart = nil
+		s.skipping = false
+	case *steps.CreateTrackingBranchStep:
+		return s.push(step.Branch)
+	case *steps.MergeStep:
+		return s.merge(step.Branch, Merge)
+	case *steps.PushBranchStep:
+		return s.push(step.Branch)
+	case *steps.RebaseBranchStep:
+		return s.merge(step.Branch, Rebase)
+	case *steps.SquashMergeStep:
+		return s.merge(step.Branch, SquashMerge)
+	}
+	return nil
+}
+
+// commit provides the simulated commit of the given branch.
+func (s *simulation) commit(branch string) (*simulatedCommit, error) {
+	if commit, has := s.heads[branch]; has {
+		return commit, nil
+	}
+	sha, err := s.backend.ShaForBranch(branch)
+	if err != nil {
+		// checking out a branch that exists only at origin creates it from its tracking branch
+		trackingSha, trackingErr := s.backend.ShaForBranch(s.backend.TrackingBranch(branch))
+		if trackingErr != nil {
+			return nil, err
+		}
+		sha = trackingSha
+	}
+	commit := &simulatedCommit{sha: sha, tree: "", parents: []string{}}
+	s.heads[branch] = commit
+	return commit, nil
+}
+
+// head provides the SHA of the simulated commit of the given branch.
+func (s *simulation) head(branch string) (string, error) {
+	commit, err := s.commit(branch)
+	if err != nil {
+		return "", err
+	}
+	if commit.sha == "" {
+		commit.sha, err = s.backend.CommitTree(commit.tree, commit.parents...)
+		if err != nil {
+			return "", err
+		}
+	}
+	return commit.sha, nil
+}
+
+// merge simulates that the current branch receives the changes of the given branch.
+func (s *simulation) merge(other string, operation Operation) error {
+	// the outcome of syncing conflicting branches depends on how the user resolves the conflicts
+	if s.skipping || stringslice.Contains(Branches(s.conflicts), other) {
+		return nil
+	}
+	current, err := s.head(s.current)
+	if err != nil {
+		return err
+	}
+	if s.branchStart == nil {
+		s.branchStart = s.heads[s.current]
+	}
+	incoming, err := s.head(other)
+	if err != nil {
+		return err
+	}
+	if current == incoming {
+		return nil
+	}
+	mergeBase, err := s.mergeBase(current, incoming)
+	if err != nil {
+		return err
+	}
+	switch {
+	case mergeBase == incoming:
+		// the current branch already contains all changes of the other branch
+		return nil
+	case mergeBase == current && operation == SquashMerge:
+		s.heads[s.current] = &simulatedCommit{sha: "", tree: incoming + "^{tree}", parents: []string{current}}
+		return nil
+	case mergeBase == current:
+		// fast-forward
+		s.heads[s.current] = &simulatedCommit{sha: incoming, tree: "", parents: []string{}}
+		return nil
+	}
+	result, err := s.backend.MergeTree(current, incoming)
+	if err != nil {
+		return err
+	}
+	if len(result.ConflictingFiles) > 0 {
+		s.conflicts = append(s.conflicts, Conflict{
+			Branch:    s.current,
+			Other:     other,
+			Operation: operation,
+			Files:     result.ConflictingFiles,
+		})
+		// the branch doesn't get synced
+		s.heads[s.current] = s.branchStart
+		s.skipping = true
+		return nil
+	}
+	var parents []string
+	switch operation {
+	case Merge:
+		parents = []string{current, incoming}
+	case Rebase:
+		parents = []string{incoming}
+	case SquashMerge:
+		parents = []string{current}
+	}
+	s.heads[s.current] = &simulatedCommit{sha: "", tree: result.Tree, parents: parents}
+	return nil
+}
+
+// mergeBase provides the merge base of the given commits.
+func (s *simulation) mergeBase(commit, otherCommit string) (string, error) {
+	key := [2]string{commit, otherCommit}
+	if otherCommit < commit {
+		key = [2]string{otherCommit, commit}
+	}
+	if mergeBase, has := s.mergeBases[key]; has {
+		return mergeBase, nil
+	}
+	mergeBase, err := s.backend.MergeBase(commit, otherCommit)
+	if err != nil {
+		return "", err
+	}
+	s.mergeBases[key] = mergeBase
+	return mergeBase, nil
+}
+
+// push simulates pushing the given branch to its tracking branch.
+func (s *simulation) push(branch string) error {
+	commit, err := s.commit(branch)
+	if err != nil {
+		return err
+	}
+	s.heads[s.backend.TrackingBranch(branch)] = commit
+	return nil
+}
@@ -0,0 +1,136 @@
+package preflight_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v8/src/preflight"
+	"github.com/git-town/git-town/v8/src/runstate"
+	"github.com/git-town/git-town/v8/src/steps"
+	"github.com/git-town/git-town/v8/test/git"
+	"github.com/git-town/git-town/v8/test/testruntime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredictConflicts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no conflicts", func(t *testing.T) {
+		t.Parallel()
+		runtime := testruntime.Create(t)
+		assert.NoError(t, runtime.CreateBranch("feature", "initial"))
+		assert.NoError(t, runtime.CreateCommit(git.Commit{Branch: "feature", FileName: "file1", FileContent: "feature content", Message: "feature commit"}))
+		assert.NoError(t, runtime.CreateCommit(git.Commit{Branch: "initial", FileName: "file2", FileContent: "main content", Message: "main commit"}))
+		list := runstate.StepList{List: []steps.Step{
+			&steps.CheckoutStep{Branch: "feature"},
+			&steps.MergeStep{Branch: "initial"},
+		}}
+		have, err := preflight.PredictConflicts(list, "initial", &runtime.Backend)
+		assert.NoError(t, err)
+		assert.Equal(t, []preflight.Conflict{}, have)
+	})
+
+	t.Run("conflicting merge", func(t *testing.T) {
+		t.Parallel()
+		runtime := testruntime.Create(t)
+		assert.NoError(t, runtime.CreateBranch("feature", "initial"))
+		assert.NoError(t, runtime.CreateCommit(git.Commit{Branch: "feature", FileName: "file", FileContent: "feature content", Message: "feature commit"}))
+		assert.NoError(t, runtime.CreateCommit(git.Commit{Branch: "initial", FileName: "file", FileContent: "main content", Message: "main commit"}))
+		list := runstate.StepList{List: []steps.Step{
+			&steps.CheckoutStep{Branch: "feature"},
+			&steps.MergeStep{Branch: "initial"},
+		}}
+		have, err := preflight.PredictConflicts(list, "initial", &runtime.Backend)
+		assert.NoError(t, err)
+		want := []preflight.Conflict{
+			{Branch: "feature", Other: "initial", Operation: preflight.Merge, Files: []string{"file"}},
+		}
+		assert.Equal(t, want, have)
+		assert.Equal(t, `merging "initial" into "feature" conflicts in file`, have[0].String())
+		currentBranch, err := runtime.CurrentBranch()
+		assert.NoError(t, err)
+		assert.Equal(t, "initial", currentBranch)
+	})
+
+	t.Run("conflict caused by an earlier step", func(t *testing.T) {
+		t.Parallel()
+		runtime := testruntime.Create(t)
+		assert.NoError(t, runtime.CreateBranch("parent", "initial"))
+		assert.NoError(t, runtime.CreateBranch("child", "parent"))
+		assert.NoError(t, runtime.CreateCommit(git.Commit{Branch: "child", FileName: "file", FileContent: "child content", Message: "child commit"}))
+		assert.NoError(t, runtime.CreateCommit(git.Commit{Branch: "initial", FileName: "file", FileContent: "main content", Message: "main commit"}))
+		list := runstate.StepList{List: []steps.Step{
+			&steps.CheckoutStep{Branch: "parent"},
+			&steps.MergeStep{Branch: "initial"},
+			&steps.CheckoutStep{Branch: "child"},
+			&steps.RebaseBranchStep{Branch: "parent"},
+		}}
+		have, err := preflight.PredictConflicts(list, "initial", &runtime.Backend)
+		assert.NoError(t, err)
+		want := []preflight.Conflict{
+			{Branch: "child", Other: "parent", Operation: preflight.Rebase, Files: []string{"file"}},
+		}
+		assert.Equal(t, want, have)
+		parentSha, err := runtime.ShaForBranch("parent")
+		assert.NoError(t, err)
+		initialSha, err := runtime.ShaForBranch("initial")
+		assert.NoError(t, err)
+		assert.NotEqual(t, initialSha, parentSha)
+	})
+
+	t.Run("descendants of conflicting branches", func(t *testing.T) {
+		t.Parallel()
+		runtime := testruntime.Create(t)
+		assert.NoError(t, runtime.CreateBranch("parent", "initial"))
+		assert.NoError(t, runtime.CreateCommit(git.Commit{Branch: "parent", FileName: "file", FileContent: "parent content", Message: "parent commit"}))
+		assert.NoError(t, runtime.CreateBranch("child", "parent"))
+		assert.NoError(t, runtime.CreateCommit(git.Commit{Branch: "child", FileName: "file", FileContent: "child content", Message: "child commit"}))
+		assert.NoError(t, runtime.CreateCommit(git.Commit{Branch: "initial", FileName: "file", FileContent: "main content", Message: "main commit"}))
+		list := runstate.StepList{List: []steps.Step{
+			&steps.CheckoutStep{Branch: "parent"},
+			&steps.MergeStep{Branch: "initial"},
+			&steps.CheckoutStep{Branch: "child"},
+			&steps.MergeStep{Branch: "parent"},
+		}}
+		have, err := preflight.PredictConflicts(list, "initial", &runtime.Backend)
+		assert.NoError(t, err)
+		want := []preflight.Conflict{
+			{Branch: "parent", Other: "initial", Operation: preflight.Merge, Files: []string{"file"}},
+		}
+		assert.Equal(t, want, have)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		t.Parallel()
+		runtime := testruntime.Create(t)
+		assert.NoError(t, runtime.CreateBranch("feature", "initial"))
+		assert.NoError(t, runtime.CreateCommit(git.Commit{Branch: "feature", FileName: "file", FileContent: "feature content", Message: "feature commit"}))
+		assert.NoError(t, runtime.CreateCommit(git.Commit{Branch: "initial", FileName: "file", FileContent: "main content", Message: "main commit"}))
+		runtime.Config.DryRun = true
+		list := runstate.StepList{List: []steps.Step{
+			&steps.CheckoutStep{Branch: "feature"},
+			&steps.MergeStep{Branch: "initial"},
+		}}
+		have, err := preflight.PredictConflicts(list, "initial", &runtime.Backend)
+		assert.NoError(t, err)
+		assert.Equal(t, []preflight.Conflict{}, have)
+	})
+}
+
+func TestReport(t *testing.T) {
+	t.Parallel()
+	conflicts := []preflight.Conflict{
+		{Branch: "alpha", Other: "main", Operation: preflight.Merge, Files: []string{"file1", "file2"}},
+		{Branch: "beta", Other: "alpha", Operation: preflight.Rebase, Files: []string{"file3"}},
+		{Branch: "main", Other: "gamma", Operation: preflight.SquashMerge, Files: []string{"file4"}},
+	}
+	want := `Git Town predicts these merge conflicts:
+- merging "main" into "alpha" conflicts in file1, file2
+- rebasing "beta" onto "alpha" conflicts in file3
+- squash-merging "gamma" into "main" conflicts in file4
+Predictions for rebases are approximate, rebasing can also conflict in individual commits.`
+	assert.Equal(t, want, preflight.Report(conflicts))
+	assert.Equal(t, []string{"alpha", "beta", "main"}, preflight.Branches(conflicts))
+	want = `Git Town predicts these merge conflicts:
+- merging "main" into "alpha" conflicts in file1, file2`
+	assert.Equal(t, want, preflight.Report(conflicts[:1]))
+}
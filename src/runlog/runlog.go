@@ -0,0 +1,229 @@
+// Package runlog records what Git Town does in a log file,
+// so that users can find out later what happened during earlier runs of Git Town.
+//
+// The log is a file in the Git Town configuration directory that contains one JSON-encoded Entry per line.
+// Each run of Git Town appends the entries for its command-line arguments, configuration,
+// executed shell commands, API calls, and final runstate to it.
+// Once the log file gets too large, Git Town rotates it.
+package runlog
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// the types of log entries
+const (
+	APICallEntry  = "api call"
+	CommandEntry  = "command"
+	RunStateEntry = "runstate"
+	StartEntry    = "start"
+)
+
+const (
+	// fileName is the name of the current log file.
+	// Rotated log files have a numeric suffix like ".1".
+	fileName = "git-town.log"
+	// maxFileSize is the size in bytes beyond which Git Town rotates the log file.
+	maxFileSize = 1024 * 1024
+	// maxRotatedFiles is how many rotated log files Git Town keeps in addition to the current one.
+	maxRotatedFiles = 4
+)
+
+// Entry is a single event recorded in the log.
+type Entry struct {
+	Run      string            `json:"run"`  // ID of the Git Town run that recorded this entry
+	Type     string            `json:"type"` // one of the entry type constants above
+	Time     time.Time         `json:"time"`
+	Argv     []string          `json:"argv,omitempty"`     // for start entries: the command-line arguments of Git Town
+	Dir      string            `json:"dir,omitempty"`      // for start entries: the directory in which Git Town ran
+	Config   map[string]string `json:"config,omitempty"`   // for start entries: the Git Town configuration
+	Name     string            `json:"name,omitempty"`     // for command and API call entries: what ran
+	ExitCode *int              `json:"exitCode,omitempty"` // for command entries: the exit code of the command
+	Duration time.Duration     `json:"duration,omitempty"` // for command and API call entries: how long they took
+	RunState json.RawMessage   `json:"runstate,omitempty"` // for runstate entries: the final runstate
+}
+
+// Log appends entries to the log file.
+// A nil Log doesn't log anything.
+// Logging is best-effort: failing to write an entry doesn't fail the Git Town command that creates it.
+type Log struct {
+	mutex sync.Mutex // API calls and parallel commands can get logged concurrently
+	path  string
+	runID string
+}
+
+// Dir provides the directory that contains the log files.
+func Dir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "git-town", "log"), nil
+}
+
+// Open provides a Log that writes into the log files in the given directory.
+// It rotates the log files if the current one has become too large.
+func Open(dir string) (*Log, error) {
+	err := os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create the log directory %q: %w", dir, err)
+	}
+	err = rotate(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Log{
+		mutex: sync.Mutex{},
+		path:  filepath.Join(dir, fileName),
+		runID: newRunID(),
+	}, nil
+}
+
+// newRunID provides a new ID for a run of Git Town.
+// IDs start with the time of the run so that they are meaningful to humans.
+// The random suffix distinguishes runs that start at the same time.
+func newRunID() string {
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("%s-%x", time.Now().Format("20060102-150405"), suffix)
+}
+
+// LogAPICall records the given call to the API of a code hosting service.
+func (l *Log) LogAPICall(name string, start time.Time, duration time.Duration) {
+	l.write(Entry{Type: APICallEntry, Time: start, Name: name, Duration: duration}) //nolint:exhaustruct
+}
+
+// LogCommand records the given shell command.
+func (l *Log) LogCommand(command string, exitCode int, start time.Time, duration time.Duration) {
+	l.write(Entry{Type: CommandEntry, Time: start, Name: command, ExitCode: &exitCode, Duration: duration}) //nolint:exhaustruct
+}
+
+// LogRunState records the given runstate at the end of a Git Town command.
+func (l *Log) LogRunState(runState any) {
+	if l == nil {
+		return
+	}
+	content, err := json.Marshal(runState)
+	if err != nil {
+		return
+	}
+	l.write(Entry{Type: RunStateEntry, Time: time.Now(), RunState: content}) //nolint:exhaustruct
+}
+
+// LogStart records the beginning of a Git Town run.
+func (l *Log) LogStart(argv []string, dir string, config map[string]string) {
+	l.write(Entry{Type: StartEntry, Time: time.Now(), Argv: argv, Dir: dir, Config: config}) //nolint:exhaustruct
+}
+
+func (l *Log) write(entry Entry) {
+	if l == nil {
+		return
+	}
+	entry.Run = l.runID
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	_, _ = file.Write(append(content, '\n'))
+}
+
+// rotate moves the current log file in the given directory out of the way if it is too large.
+func rotate(dir string) error {
+	current := filepath.Join(dir, fileName)
+	info, err := os.Stat(current)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("cannot check the log file %q: %w", current, err)
+	}
+	if info.Size() < maxFileSize {
+		return nil
+	}
+	for number := maxRotatedFiles; number > 0; number-- {
+		err = os.Rename(filePath(dir, number-1), filePath(dir, number))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("cannot rotate the log files in %q: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// filePath provides the path of the log file with the given number.
+// The current log file has number 0, older ones have higher numbers.
+func filePath(dir string, number int) string {
+	if number == 0 {
+		return filepath.Join(dir, fileName)
+	}
+	return filepath.Join(dir, fmt.Sprintf("%s.%d", fileName, number))
+}
+
+// Run contains the log entries of a single run of Git Town.
+type Run struct {
+	ID      string
+	Entries []Entry
+}
+
+// Start provides the start entry of this run.
+func (r Run) Start() *Entry {
+	for e := range r.Entries {
+		if r.Entries[e].Type == StartEntry {
+			return &r.Entries[e]
+		}
+	}
+	return nil
+}
+
+// Read provides the runs recorded in the log files in the given directory, oldest first.
+// It ignores lines that it cannot parse.
+func Read(dir string) ([]Run, error) {
+	result := []Run{}
+	runIndexes := map[string]int{}
+	for number := maxRotatedFiles; number >= 0; number-- {
+		path := filePath(dir, number)
+		file, err := os.Open(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return result, fmt.Errorf("cannot read the log file %q: %w", path, err)
+		}
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 64*1024), maxFileSize)
+		for scanner.Scan() {
+			var entry Entry
+			err = json.Unmarshal(scanner.Bytes(), &entry)
+			if err != nil {
+				continue
+			}
+			index, exists := runIndexes[entry.Run]
+			if !exists {
+				index = len(result)
+				runIndexes[entry.Run] = index
+				result = append(result, Run{ID: entry.Run, Entries: []Entry{}})
+			}
+			result[index].Entries = append(result[index].Entries, entry)
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return result, fmt.Errorf("cannot read the log file %q: %w", path, err)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,79 @@
+package runlog_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/git-town/git-town/v8/src/runlog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLog(t *testing.T) {
+	t.Parallel()
+
+	t.Run("records the entries of a run", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		log, err := runlog.Open(dir)
+		assert.NoError(t, err)
+		log.LogStart([]string{"git-town", "sync"}, "/repo", map[string]string{"git-town.main-branch-name": "main"})
+		log.LogCommand("git fetch --prune --tags", 0, time.Now(), time.Millisecond)
+		log.LogCommand("git rebase main", 1, time.Now(), time.Millisecond)
+		log.LogAPICall("GitHub FindProposal", time.Now(), time.Millisecond)
+		log.LogRunState(map[string]string{"Command": "sync"})
+		runs, err := runlog.Read(dir)
+		assert.NoError(t, err)
+		assert.Len(t, runs, 1)
+		entries := runs[0].Entries
+		assert.Len(t, entries, 5)
+		start := runs[0].Start()
+		assert.NotNil(t, start)
+		assert.Equal(t, []string{"git-town", "sync"}, start.Argv)
+		assert.Equal(t, "/repo", start.Dir)
+		assert.Equal(t, "main", start.Config["git-town.main-branch-name"])
+		assert.Equal(t, runlog.CommandEntry, entries[2].Type)
+		assert.Equal(t, "git rebase main", entries[2].Name)
+		assert.Equal(t, 1, *entries[2].ExitCode)
+		assert.Equal(t, runlog.APICallEntry, entries[3].Type)
+		assert.Equal(t, runlog.RunStateEntry, entries[4].Type)
+		assert.JSONEq(t, `{"Command":"sync"}`, string(entries[4].RunState))
+	})
+
+	t.Run("nil log", func(t *testing.T) {
+		t.Parallel()
+		var log *runlog.Log
+		log.LogStart([]string{"git-town"}, "/repo", map[string]string{})
+		log.LogCommand("git status", 0, time.Now(), time.Millisecond)
+		log.LogAPICall("GitHub FindProposal", time.Now(), time.Millisecond)
+		log.LogRunState(map[string]string{})
+	})
+
+	t.Run("rotates large log files", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		log, err := runlog.Open(dir)
+		assert.NoError(t, err)
+		log.LogStart([]string{"git-town", "hack", "old"}, "/repo", map[string]string{})
+		file, err := os.OpenFile(filepath.Join(dir, "git-town.log"), os.O_APPEND|os.O_WRONLY, 0o600)
+		assert.NoError(t, err)
+		_, err = file.WriteString(strings.Repeat("unparsable\n", 100_000))
+		assert.NoError(t, err)
+		assert.NoError(t, file.Close())
+		log, err = runlog.Open(dir)
+		assert.NoError(t, err)
+		log.LogStart([]string{"git-town", "hack", "new"}, "/repo", map[string]string{})
+		_, err = os.Stat(filepath.Join(dir, "git-town.log.1"))
+		assert.NoError(t, err)
+		info, err := os.Stat(filepath.Join(dir, "git-town.log"))
+		assert.NoError(t, err)
+		assert.Less(t, info.Size(), int64(1000))
+		runs, err := runlog.Read(dir)
+		assert.NoError(t, err)
+		assert.Len(t, runs, 2)
+		assert.Equal(t, "old", runs[0].Start().Argv[2])
+		assert.Equal(t, "new", runs[1].Start().Argv[2])
+	})
+}
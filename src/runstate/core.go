@@ -40,7 +40,7 @@ func New(command string, stepList StepList) *RunState {
 
 // AddPushBranchStepAfterCurrentBranchSteps inserts a PushBranchStep
 // after all the steps for the current branch.
-func (runState *RunState) AddPushBranchStepAfterCurrentBranchSteps(backend *git.BackendCommands) error {
+func (runState *RunState) AddPushBranchStepAfterCurrentBranchSteps(forceWithLease bool, backend *git.BackendCommands) error {
 	popped := StepList{}
 	for {
 		step := runState.RunStepList.Peek()
@@ -51,7 +51,7 @@ func (runState *RunState) AddPushBranchStepAfterCurrentBranchSteps(backend *git.
 			if err != nil {
 				return err
 			}
-			runState.RunStepList.Prepend(&steps.PushBranchStep{Branch: currentBranch})
+			runState.RunStepList.Prepend(&steps.PushBranchStep{Branch: currentBranch, ForceWithLease: forceWithLease})
 			runState.RunStepList.PrependList(popped)
 			break
 		}
@@ -2,10 +2,13 @@ package runstate
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/git-town/git-town/v8/src/cli"
 	"github.com/git-town/git-town/v8/src/git"
 	"github.com/git-town/git-town/v8/src/hosting"
+	"github.com/git-town/git-town/v8/src/steps"
 )
 
 // Execute runs the commands in the given runstate.
@@ -27,6 +30,7 @@ func Execute(runState *RunState, run *git.ProdRunner, connector hosting.Connecto
 					return fmt.Errorf("cannot save run state: %w", err)
 				}
 			}
+			run.Log.LogRunState(runState)
 			fmt.Println()
 			run.Stats.PrintAnalysis()
 			return nil
@@ -36,13 +40,16 @@ func Execute(runState *RunState, run *git.ProdRunner, connector hosting.Connecto
 			continue
 		}
 		if typeName(step) == "*PushBranchAfterCurrentBranchSteps" {
-			err := runState.AddPushBranchStepAfterCurrentBranchSteps(&run.Backend)
+			pushStep := step.(*steps.PushBranchAfterCurrentBranchSteps) //nolint:forcetypeassert
+			err := runState.AddPushBranchStepAfterCurrentBranchSteps(pushStep.ForceWithLease, &run.Backend)
 			if err != nil {
 				return err
 			}
 			continue
 		}
+		start := time.Now()
 		runErr := step.Run(run, connector)
+		run.Stats.RegisterStep(strings.TrimPrefix(typeName(step), "*"), start, time.Since(start))
 		if runErr != nil {
 			runState.AbortStepList.Append(step.CreateAbortStep())
 			if step.ShouldAutomaticallyAbortOnError() {
@@ -70,10 +77,14 @@ func Execute(runState *RunState, run *git.ProdRunner, connector hosting.Connecto
 			if runState.Command == "sync" && !(rebasing && run.Config.IsMainBranch(currentBranch)) {
 				runState.UnfinishedDetails.CanSkip = true
 			}
+			if runState.Command == "walk" {
+				runState.UnfinishedDetails.CanSkip = true
+			}
 			err = Save(runState, &run.Backend)
 			if err != nil {
 				return fmt.Errorf("cannot save run state: %w", err)
 			}
+			run.Log.LogRunState(runState)
 			message := runErr.Error() + `
 
 To abort, run "git-town abort".
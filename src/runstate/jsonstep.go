@@ -46,10 +46,20 @@ func determineStep(stepType string) steps.Step {
 		return &steps.AbortMergeStep{}
 	case "*AbortRebaseStep":
 		return &steps.AbortRebaseStep{}
+	case "*AddToContributionBranchesStep":
+		return &steps.AddToContributionBranchesStep{}
+	case "*AddToObservedBranchesStep":
+		return &steps.AddToObservedBranchesStep{}
+	case "*AddToParkedBranchesStep":
+		return &steps.AddToParkedBranchesStep{}
 	case "*AddToPerennialBranchesStep":
 		return &steps.AddToPerennialBranchesStep{}
+	case "*AddToPrototypeBranchesStep":
+		return &steps.AddToPrototypeBranchesStep{}
 	case "*CheckoutStep":
 		return &steps.CheckoutStep{}
+	case "*CompressBranchStep":
+		return &steps.CompressBranchStep{}
 	case "*ConnectorMergeProposalStep":
 		return &steps.ConnectorMergeProposalStep{}
 	case "*ContinueMergeStep":
@@ -70,6 +80,8 @@ func determineStep(stepType string) steps.Step {
 		return &steps.DeleteOriginBranchStep{}
 	case "*DeleteParentBranchStep":
 		return &steps.DeleteParentBranchStep{}
+	case "*DequeueOfflineOperationStep":
+		return &steps.DequeueOfflineOperationStep{}
 	case "*DiscardOpenChangesStep":
 		return &steps.DiscardOpenChangesStep{}
 	case "*EmptyStep":
@@ -80,6 +92,8 @@ func determineStep(stepType string) steps.Step {
 		return &steps.FetchUpstreamStep{}
 	case "*MergeStep":
 		return &steps.MergeStep{}
+	case "*MoveProposalStep":
+		return &steps.MoveProposalStep{}
 	case "*PreserveCheckoutHistoryStep":
 		return &steps.PreserveCheckoutHistoryStep{}
 	case "*PullBranchStep":
@@ -90,16 +104,34 @@ func determineStep(stepType string) steps.Step {
 		return &steps.PushBranchStep{}
 	case "*PushTagsStep":
 		return &steps.PushTagsStep{}
+	case "*QueueOfflineOperationStep":
+		return &steps.QueueOfflineOperationStep{}
 	case "*RebaseBranchStep":
 		return &steps.RebaseBranchStep{}
+	case "*RebaseOntoStep":
+		return &steps.RebaseOntoStep{}
+	case "*RemoveFromContributionBranchesStep":
+		return &steps.RemoveFromContributionBranchesStep{}
+	case "*RemoveFromObservedBranchesStep":
+		return &steps.RemoveFromObservedBranchesStep{}
+	case "*RemoveFromParkedBranchesStep":
+		return &steps.RemoveFromParkedBranchesStep{}
 	case "*RemoveFromPerennialBranchesStep":
 		return &steps.RemoveFromPerennialBranchesStep{}
+	case "*RemoveFromPrototypeBranchesStep":
+		return &steps.RemoveFromPrototypeBranchesStep{}
 	case "*ResetToShaStep":
 		return &steps.ResetToShaStep{}
+	case "*RestoreOfflineOperationStep":
+		return &steps.RestoreOfflineOperationStep{}
 	case "*RestoreOpenChangesStep":
 		return &steps.RestoreOpenChangesStep{}
 	case "*RevertCommitStep":
 		return &steps.RevertCommitStep{}
+	case "*RunCommandStep":
+		return &steps.RunCommandStep{}
+	case "*RunHookStep":
+		return &steps.RunHookStep{}
 	case "*SetParentStep":
 		return &steps.SetParentStep{}
 	case "*SquashMergeStep":
@@ -108,6 +140,8 @@ func determineStep(stepType string) steps.Step {
 		return &steps.SkipCurrentBranchSteps{}
 	case "*StashOpenChangesStep":
 		return &steps.StashOpenChangesStep{}
+	case "*UpdateProposalTargetStep":
+		return &steps.UpdateProposalTargetStep{}
 	}
 	return nil
 }
@@ -5,10 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
-	"strings"
 
 	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/persistence"
 )
 
 // Load loads the run state for the given Git repo from disk. Can return nil if there is no saved runstate.
@@ -79,30 +78,5 @@ func Save(runState *RunState, backend *git.BackendCommands) error {
 }
 
 func PersistenceFilePath(backend *git.BackendCommands) (string, error) {
-	configDir, err := os.UserConfigDir()
-	if err != nil {
-		return "", err
-	}
-	persistenceDir := filepath.Join(configDir, "git-town", "runstate")
-	repoDir, err := backend.RootDirectory()
-	if err != nil {
-		return "", err
-	}
-	filename := SanitizePath(repoDir)
-	if err != nil {
-		return "", err
-	}
-	return filepath.Join(persistenceDir, filename+".json"), nil
-}
-
-func SanitizePath(dir string) string {
-	replaceCharacterRE := regexp.MustCompile("[[:^alnum:]]")
-	sanitized := replaceCharacterRE.ReplaceAllString(dir, "-")
-	sanitized = strings.ToLower(sanitized)
-	replaceDoubleMinusRE := regexp.MustCompile("--+") // two or more dashes
-	sanitized = replaceDoubleMinusRE.ReplaceAllString(sanitized, "-")
-	for strings.HasPrefix(sanitized, "-") {
-		sanitized = sanitized[1:]
-	}
-	return sanitized
+	return persistence.FilePath("runstate", backend)
 }
@@ -1,20 +1,29 @@
 package runstate_test
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/git-town/git-town/v8/src/runstate"
+	"github.com/git-town/git-town/v8/test/testruntime"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestSanitizePath(t *testing.T) {
+func TestPersistenceFilePath(t *testing.T) {
 	t.Parallel()
-	tests := map[string]string{
-		"/home/user/development/git-town":        "home-user-development-git-town",
-		"c:\\Users\\user\\development\\git-town": "c-users-user-development-git-town",
-	}
-	for give, want := range tests {
-		have := runstate.SanitizePath(give)
-		assert.Equal(t, want, have)
-	}
+	t.Run("worktrees have separate run states", func(t *testing.T) {
+		t.Parallel()
+		repo := testruntime.Create(t)
+		err := repo.CreateBranch("feature", "initial")
+		assert.NoError(t, err)
+		worktreeDir := filepath.Join(t.TempDir(), "worktree")
+		err = repo.AddWorktree(worktreeDir, "feature")
+		assert.NoError(t, err)
+		worktree := testruntime.New(worktreeDir, repo.HomeDir, repo.BinDir)
+		repoPath, err := runstate.PersistenceFilePath(&repo.Backend)
+		assert.NoError(t, err)
+		worktreePath, err := runstate.PersistenceFilePath(&worktree.Backend)
+		assert.NoError(t, err)
+		assert.NotEqual(t, repoPath, worktreePath)
+	})
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hooks"
 	"github.com/git-town/git-town/v8/src/steps"
 )
 
@@ -24,11 +25,36 @@ func (stepList *StepList) Append(step steps.Step) {
 	stepList.List = append(stepList.List, step)
 }
 
+// AppendHook adds a step that runs the hook with the given name to the end of this StepList
+// if the user has defined this hook.
+func (stepList *StepList) AppendHook(hook string, context hooks.Context, run *git.ProdRunner) error {
+	exists, err := hooks.Exists(hook, run)
+	if err != nil {
+		return err
+	}
+	if exists {
+		stepList.Append(&steps.RunHookStep{Hook: hook, Context: context})
+	}
+	return nil
+}
+
 // AppendList adds all elements of the given StepList to the end of this StepList.
 func (stepList *StepList) AppendList(otherList StepList) {
 	stepList.List = append(stepList.List, otherList.List...)
 }
 
+// HasProposalSteps indicates whether this StepList contains steps that change proposals
+// and therefore need a connector to the code hosting service.
+func (stepList *StepList) HasProposalSteps() bool {
+	for _, step := range stepList.List {
+		switch step.(type) {
+		case *steps.ConnectorMergeProposalStep, *steps.CreateProposalStep, *steps.MoveProposalStep, *steps.UpdateProposalTargetStep:
+			return true
+		}
+	}
+	return false
+}
+
 // IsEmpty returns whether or not this StepList has any elements.
 func (stepList *StepList) IsEmpty() bool {
 	return len(stepList.List) == 0
@@ -3,6 +3,7 @@ package runstate
 import (
 	"github.com/git-town/git-town/v8/src/failure"
 	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hooks"
 	"github.com/git-town/git-town/v8/src/steps"
 )
 
@@ -25,6 +26,10 @@ func (slb *StepListBuilder) AddE(step steps.Step, err error) {
 	}
 }
 
+func (slb *StepListBuilder) AddHook(hook string, context hooks.Context, run *git.ProdRunner) {
+	slb.Check(slb.StepList.AppendHook(hook, context, run))
+}
+
 func (slb *StepListBuilder) Wrap(options WrapOptions, backend *git.BackendCommands, mainBranch string) {
 	slb.Check(slb.StepList.Wrap(options, backend, mainBranch))
 }
@@ -0,0 +1,20 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
+)
+
+// AddToContributionBranchesStep adds the branch with the given name as a contribution branch.
+type AddToContributionBranchesStep struct {
+	EmptyStep
+	Branch string
+}
+
+func (step *AddToContributionBranchesStep) CreateUndoStep(backend *git.BackendCommands) (Step, error) {
+	return &RemoveFromContributionBranchesStep{Branch: step.Branch}, nil
+}
+
+func (step *AddToContributionBranchesStep) Run(run *git.ProdRunner, connector hosting.Connector) error {
+	return run.Config.AddToContributionBranches(step.Branch)
+}
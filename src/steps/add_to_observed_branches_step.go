@@ -0,0 +1,20 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
+)
+
+// AddToObservedBranchesStep adds the branch with the given name as an observed branch.
+type AddToObservedBranchesStep struct {
+	EmptyStep
+	Branch string
+}
+
+func (step *AddToObservedBranchesStep) CreateUndoStep(backend *git.BackendCommands) (Step, error) {
+	return &RemoveFromObservedBranchesStep{Branch: step.Branch}, nil
+}
+
+func (step *AddToObservedBranchesStep) Run(run *git.ProdRunner, connector hosting.Connector) error {
+	return run.Config.AddToObservedBranches(step.Branch)
+}
@@ -0,0 +1,20 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
+)
+
+// AddToParkedBranchesStep adds the branch with the given name as a parked branch.
+type AddToParkedBranchesStep struct {
+	EmptyStep
+	Branch string
+}
+
+func (step *AddToParkedBranchesStep) CreateUndoStep(backend *git.BackendCommands) (Step, error) {
+	return &RemoveFromParkedBranchesStep{Branch: step.Branch}, nil
+}
+
+func (step *AddToParkedBranchesStep) Run(run *git.ProdRunner, connector hosting.Connector) error {
+	return run.Config.AddToParkedBranches(step.Branch)
+}
@@ -0,0 +1,20 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
+)
+
+// AddToPrototypeBranchesStep adds the branch with the given name as a prototype branch.
+type AddToPrototypeBranchesStep struct {
+	EmptyStep
+	Branch string
+}
+
+func (step *AddToPrototypeBranchesStep) CreateUndoStep(backend *git.BackendCommands) (Step, error) {
+	return &RemoveFromPrototypeBranchesStep{Branch: step.Branch}, nil
+}
+
+func (step *AddToPrototypeBranchesStep) Run(run *git.ProdRunner, connector hosting.Connector) error {
+	return run.Config.AddToPrototypeBranches(step.Branch)
+}
@@ -0,0 +1,46 @@
+package steps
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
+)
+
+// CompressBranchStep replaces the commits of the current branch
+// that come after the given base with a single commit with the given message.
+type CompressBranchStep struct {
+	EmptyStep
+	Base        string
+	Message     string
+	previousSha string
+}
+
+func (step *CompressBranchStep) CreateAbortStep() Step {
+	return &ResetToShaStep{Hard: true, Sha: step.previousSha}
+}
+
+func (step *CompressBranchStep) CreateAutomaticAbortError() error {
+	return fmt.Errorf("aborted because commit exited with error")
+}
+
+func (step *CompressBranchStep) CreateUndoStep(backend *git.BackendCommands) (Step, error) {
+	return &ResetToShaStep{Hard: true, Sha: step.previousSha}, nil
+}
+
+func (step *CompressBranchStep) Run(run *git.ProdRunner, connector hosting.Connector) error {
+	var err error
+	step.previousSha, err = run.Backend.CurrentSha()
+	if err != nil {
+		return err
+	}
+	err = run.Frontend.ResetSoftToSha(step.Base)
+	if err != nil {
+		return err
+	}
+	return run.Frontend.Commit(step.Message, "")
+}
+
+func (step *CompressBranchStep) ShouldAutomaticallyAbortOnError() bool {
+	return true
+}
@@ -8,17 +8,26 @@ import (
 
 // CreateTrackingBranchStep pushes the current branch up to origin
 // and marks it as tracking the current branch.
+// Prototype branches don't get pushed.
 type CreateTrackingBranchStep struct {
 	EmptyStep
 	Branch     string
 	NoPushHook bool
+	skipped    bool
 }
 
 func (step *CreateTrackingBranchStep) CreateUndoStep(backend *git.BackendCommands) (Step, error) {
+	if step.skipped {
+		return &EmptyStep{}, nil
+	}
 	return &DeleteOriginBranchStep{Branch: step.Branch}, nil
 }
 
 func (step *CreateTrackingBranchStep) Run(run *git.ProdRunner, connector hosting.Connector) error {
+	if run.Config.IsPrototypeBranch(step.Branch) {
+		step.skipped = true
+		return nil
+	}
 	return run.Frontend.PushBranch(git.PushArgs{
 		Branch:     step.Branch,
 		NoPushHook: step.NoPushHook,
@@ -0,0 +1,31 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
+	"github.com/git-town/git-town/v8/src/offlinequeue"
+)
+
+// DequeueOfflineOperationStep removes the given network operation from the queue of operations
+// that Git Town skipped while offline.
+type DequeueOfflineOperationStep struct {
+	EmptyStep
+	Operation offlinequeue.Operation
+}
+
+func (step *DequeueOfflineOperationStep) CreateUndoStep(backend *git.BackendCommands) (Step, error) {
+	return &QueueOfflineOperationStep{Operation: step.Operation}, nil
+}
+
+func (step *DequeueOfflineOperationStep) Run(run *git.ProdRunner, connector hosting.Connector) error {
+	if run.Config.DryRun {
+		return nil
+	}
+	path, err := offlinequeue.FilePath(&run.Backend)
+	if err != nil {
+		return err
+	}
+	return offlinequeue.Update(path, func(queue *offlinequeue.Queue) {
+		queue.Remove(step.Operation)
+	})
+}
@@ -0,0 +1,54 @@
+package steps
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
+)
+
+// MoveProposalStep makes the given proposal propose the given new head branch into the given new target branch.
+type MoveProposalStep struct {
+	EmptyStep
+	Proposal     hosting.Proposal
+	ExistingHead string
+	NewHead      string
+	NewTarget    string
+	// the number of the proposal for the new head branch
+	newNumber int
+}
+
+func (step *MoveProposalStep) Run(run *git.ProdRunner, connector hosting.Connector) error {
+	var err error
+	step.newNumber, err = connector.MoveProposal(step.Proposal, step.NewHead, step.NewTarget)
+	return err
+}
+
+func (step *MoveProposalStep) CreateAbortStep() Step {
+	return &step.EmptyStep
+}
+
+func (step *MoveProposalStep) CreateUndoStep(backend *git.BackendCommands) (Step, error) {
+	return &MoveProposalStep{
+		Proposal: hosting.Proposal{
+			Number:          step.newNumber,
+			Target:          step.NewTarget,
+			Title:           step.Proposal.Title,
+			Body:            step.Proposal.Body,
+			CanMergeWithAPI: step.Proposal.CanMergeWithAPI,
+			Draft:           step.Proposal.Draft,
+			HeadSHA:         step.Proposal.HeadSHA,
+		},
+		ExistingHead: step.NewHead,
+		NewHead:      step.ExistingHead,
+		NewTarget:    step.Proposal.Target,
+	}, nil
+}
+
+func (step *MoveProposalStep) ShouldAutomaticallyAbortOnError() bool {
+	return true
+}
+
+func (step *MoveProposalStep) CreateAutomaticAbortError() error {
+	return fmt.Errorf("cannot move proposal %d to branch %q via the API", step.Proposal.Number, step.NewHead)
+}
@@ -4,4 +4,5 @@ package steps
 // to push the branch after other steps have been undone.
 type PushBranchAfterCurrentBranchSteps struct {
 	EmptyStep
+	ForceWithLease bool
 }
@@ -18,7 +18,7 @@ type PushBranchStep struct {
 
 func (step *PushBranchStep) CreateUndoStep(backend *git.BackendCommands) (Step, error) {
 	if step.Undoable {
-		return &PushBranchAfterCurrentBranchSteps{}, nil
+		return &PushBranchAfterCurrentBranchSteps{ForceWithLease: step.ForceWithLease}, nil
 	}
 	return &SkipCurrentBranchSteps{}, nil
 }
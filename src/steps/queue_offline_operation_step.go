@@ -0,0 +1,58 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
+	"github.com/git-town/git-town/v8/src/offlinequeue"
+)
+
+// QueueOfflineOperationStep records the given network operation that Git Town skips while offline,
+// so that "git town sync" can perform it once Git Town is online again.
+// It doesn't record pushes of branches that are in sync with their tracking branch.
+type QueueOfflineOperationStep struct {
+	EmptyStep
+	Operation offlinequeue.Operation
+	// whether this step added the operation to the queue
+	added bool
+	// the queued operation that this step merged the operation into
+	merged *offlinequeue.Operation
+}
+
+func (step *QueueOfflineOperationStep) CreateUndoStep(backend *git.BackendCommands) (Step, error) {
+	switch {
+	case step.merged != nil:
+		return &RestoreOfflineOperationStep{Operation: *step.merged}, nil
+	case step.added:
+		return &DequeueOfflineOperationStep{Operation: step.Operation}, nil
+	}
+	return &EmptyStep{}, nil
+}
+
+func (step *QueueOfflineOperationStep) Run(run *git.ProdRunner, connector hosting.Connector) error {
+	if run.Config.DryRun {
+		return nil
+	}
+	if step.Operation.Type == offlinequeue.PushBranch {
+		shouldPush, err := run.Backend.ShouldPushBranch(step.Operation.Branch)
+		if err != nil {
+			return err
+		}
+		if !shouldPush {
+			return nil
+		}
+	}
+	path, err := offlinequeue.FilePath(&run.Backend)
+	if err != nil {
+		return err
+	}
+	return offlinequeue.Update(path, func(queue *offlinequeue.Queue) {
+		queued, isQueued := queue.Lookup(step.Operation)
+		queue.Add(step.Operation)
+		if isQueued {
+			step.merged = &queued
+		} else {
+			// the queue doesn't record pushes of branches whose tracking branch it creates
+			step.added = queue.Contains(step.Operation)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
+)
+
+// RebaseOntoStep moves the commits of the current branch
+// that come after the given base commit onto the given branch.
+type RebaseOntoStep struct {
+	EmptyStep
+	Base        string
+	Onto        string
+	previousSha string
+}
+
+func (step *RebaseOntoStep) CreateAbortStep() Step {
+	return &AbortRebaseStep{}
+}
+
+func (step *RebaseOntoStep) CreateContinueStep() Step {
+	return &ContinueRebaseStep{}
+}
+
+func (step *RebaseOntoStep) CreateUndoStep(backend *git.BackendCommands) (Step, error) {
+	return &ResetToShaStep{Hard: true, Sha: step.previousSha}, nil
+}
+
+func (step *RebaseOntoStep) Run(run *git.ProdRunner, connector hosting.Connector) error {
+	var err error
+	step.previousSha, err = run.Backend.CurrentSha()
+	if err != nil {
+		return err
+	}
+	err = run.Frontend.RebaseOnto(step.Onto, step.Base)
+	if err != nil {
+		run.Config.CurrentBranchCache.Invalidate()
+	}
+	return err
+}
@@ -0,0 +1,20 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
+)
+
+// RemoveFromContributionBranchesStep removes the branch with the given name as a contribution branch.
+type RemoveFromContributionBranchesStep struct {
+	EmptyStep
+	Branch string
+}
+
+func (step *RemoveFromContributionBranchesStep) CreateUndoStep(backend *git.BackendCommands) (Step, error) {
+	return &AddToContributionBranchesStep{Branch: step.Branch}, nil
+}
+
+func (step *RemoveFromContributionBranchesStep) Run(run *git.ProdRunner, connector hosting.Connector) error {
+	return run.Config.RemoveFromContributionBranches(step.Branch)
+}
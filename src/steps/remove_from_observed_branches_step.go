@@ -0,0 +1,20 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
+)
+
+// RemoveFromObservedBranchesStep removes the branch with the given name as an observed branch.
+type RemoveFromObservedBranchesStep struct {
+	EmptyStep
+	Branch string
+}
+
+func (step *RemoveFromObservedBranchesStep) CreateUndoStep(backend *git.BackendCommands) (Step, error) {
+	return &AddToObservedBranchesStep{Branch: step.Branch}, nil
+}
+
+func (step *RemoveFromObservedBranchesStep) Run(run *git.ProdRunner, connector hosting.Connector) error {
+	return run.Config.RemoveFromObservedBranches(step.Branch)
+}
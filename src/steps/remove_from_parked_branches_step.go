@@ -0,0 +1,20 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
+)
+
+// RemoveFromParkedBranchesStep removes the branch with the given name as a parked branch.
+type RemoveFromParkedBranchesStep struct {
+	EmptyStep
+	Branch string
+}
+
+func (step *RemoveFromParkedBranchesStep) CreateUndoStep(backend *git.BackendCommands) (Step, error) {
+	return &AddToParkedBranchesStep{Branch: step.Branch}, nil
+}
+
+func (step *RemoveFromParkedBranchesStep) Run(run *git.ProdRunner, connector hosting.Connector) error {
+	return run.Config.RemoveFromParkedBranches(step.Branch)
+}
@@ -0,0 +1,20 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
+)
+
+// RemoveFromPrototypeBranchesStep removes the branch with the given name as a prototype branch.
+type RemoveFromPrototypeBranchesStep struct {
+	EmptyStep
+	Branch string
+}
+
+func (step *RemoveFromPrototypeBranchesStep) CreateUndoStep(backend *git.BackendCommands) (Step, error) {
+	return &AddToPrototypeBranchesStep{Branch: step.Branch}, nil
+}
+
+func (step *RemoveFromPrototypeBranchesStep) Run(run *git.ProdRunner, connector hosting.Connector) error {
+	return run.Config.RemoveFromPrototypeBranches(step.Branch)
+}
@@ -0,0 +1,27 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
+	"github.com/git-town/git-town/v8/src/offlinequeue"
+)
+
+// RestoreOfflineOperationStep restores the given queued network operation
+// after a QueueOfflineOperationStep merged another operation into it.
+type RestoreOfflineOperationStep struct {
+	EmptyStep
+	Operation offlinequeue.Operation
+}
+
+func (step *RestoreOfflineOperationStep) Run(run *git.ProdRunner, connector hosting.Connector) error {
+	if run.Config.DryRun {
+		return nil
+	}
+	path, err := offlinequeue.FilePath(&run.Backend)
+	if err != nil {
+		return err
+	}
+	return offlinequeue.Update(path, func(queue *offlinequeue.Queue) {
+		queue.Replace(step.Operation)
+	})
+}
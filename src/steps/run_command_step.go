@@ -0,0 +1,55 @@
+package steps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hosting"
+)
+
+// RunCommandStep runs the given shell command in the current branch.
+type RunCommandStep struct {
+	EmptyStep
+	Command        []string
+	hasOpenChanges bool // whether the command left uncommitted changes
+	previousSha    string
+}
+
+// CreateAbortStep discards the uncommitted changes that the command left,
+// so that they don't get carried over to the next branch.
+func (step *RunCommandStep) CreateAbortStep() Step {
+	if step.hasOpenChanges {
+		return &DiscardOpenChangesStep{}
+	}
+	return &EmptyStep{}
+}
+
+func (step *RunCommandStep) CreateContinueStep() Step {
+	return &RunCommandStep{Command: step.Command}
+}
+
+// CreateUndoStep removes the commits that the command created.
+func (step *RunCommandStep) CreateUndoStep(backend *git.BackendCommands) (Step, error) {
+	return &ResetToShaStep{Hard: true, Sha: step.previousSha}, nil
+}
+
+func (step *RunCommandStep) Run(run *git.ProdRunner, connector hosting.Connector) error {
+	var err error
+	step.previousSha, err = run.Backend.CurrentSha()
+	if err != nil {
+		return err
+	}
+	err = run.Frontend.Run(step.Command[0], step.Command[1:]...)
+	if err != nil {
+		return fmt.Errorf("command %q failed: %w", strings.Join(step.Command, " "), err)
+	}
+	step.hasOpenChanges, err = run.Backend.HasOpenChanges()
+	if err != nil {
+		return err
+	}
+	if step.hasOpenChanges {
+		return fmt.Errorf("command %q left uncommitted changes, please commit or discard them", strings.Join(step.Command, " "))
+	}
+	return nil
+}
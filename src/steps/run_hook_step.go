@@ -0,0 +1,25 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v8/src/cli"
+	"github.com/git-town/git-town/v8/src/git"
+	"github.com/git-town/git-town/v8/src/hooks"
+	"github.com/git-town/git-town/v8/src/hosting"
+)
+
+// RunHookStep runs the user-defined hook with the given name.
+// Git Town runs hooks in this step after it has made all changes,
+// so a failing hook only prints a warning instead of stopping the command.
+type RunHookStep struct {
+	EmptyStep
+	Hook    string
+	Context hooks.Context
+}
+
+func (step *RunHookStep) Run(run *git.ProdRunner, connector hosting.Connector) error {
+	err := hooks.Run(step.Hook, step.Context, run)
+	if err != nil {
+		cli.PrintWarning(err.Error())
+	}
+	return nil
+}
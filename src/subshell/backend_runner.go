@@ -1,10 +1,13 @@
 package subshell
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/acarl005/stripansi"
 	"github.com/fatih/color"
@@ -21,30 +24,58 @@ type BackendRunner struct {
 }
 
 func (r BackendRunner) Query(executable string, args ...string) (string, error) {
-	output, err := r.execute(executable, args...)
+	output, err := r.execute("", []string{}, 0, executable, args...)
+	return strings.TrimSpace(stripansi.Strip(string(output))), err
+}
+
+// QueryWithInput runs the given command with the given input on STDIN
+// and the given additional environment variables and provides its output.
+func (r BackendRunner) QueryWithInput(input string, env []string, executable string, args ...string) (string, error) {
+	output, err := r.execute(input, env, 0, executable, args...)
+	return strings.TrimSpace(stripansi.Strip(string(output))), err
+}
+
+// QueryWithTimeout runs the given command with the given additional environment variables and provides its output.
+// It kills the command if it runs longer than the given timeout.
+func (r BackendRunner) QueryWithTimeout(timeout time.Duration, env []string, executable string, args ...string) (string, error) {
+	output, err := r.execute("", env, timeout, executable, args...)
 	return strings.TrimSpace(stripansi.Strip(string(output))), err
 }
 
 func (r BackendRunner) Run(executable string, args ...string) error {
-	_, err := r.execute(executable, args...)
+	_, err := r.execute("", []string{}, 0, executable, args...)
 	return err
 }
 
-func (r BackendRunner) execute(executable string, args ...string) ([]byte, error) {
-	r.Stats.RegisterRun()
+// execute runs the given command, killing it after the given timeout unless the timeout is zero.
+func (r BackendRunner) execute(input string, env []string, timeout time.Duration, executable string, args ...string) ([]byte, error) {
 	if r.Verbose {
 		printHeader(executable, args...)
 	}
-	subProcess := exec.Command(executable, args...) // #nosec
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	subProcess := exec.CommandContext(ctx, executable, args...) // #nosec
 	if r.Dir != nil {
 		subProcess.Dir = *r.Dir
 	}
+	if len(env) > 0 {
+		subProcess.Env = append(os.Environ(), env...)
+	}
+	if input != "" {
+		subProcess.Stdin = strings.NewReader(input)
+	}
+	start := time.Now()
 	outputBytes, err := subProcess.CombinedOutput()
+	r.Stats.RegisterCommand(FormatCommand("", true, executable, args...), ExitCode(err), start, time.Since(start))
 	if err != nil {
 		err = ErrorDetails(executable, args, err, outputBytes)
 	}
 	if r.Verbose && len(outputBytes) > 0 {
-		os.Stdout.Write(outputBytes)
+		os.Stdout.Write(redactPasswords(outputBytes))
 	}
 	return outputBytes, err
 }
@@ -75,6 +106,18 @@ OUTPUT END
 ----------------------------------------`, executable, strings.Join(args, " "), err, string(output))
 }
 
+// redactPasswords removes the passwords that commands like "git credential fill" print
+// from the given command output.
+func redactPasswords(output []byte) []byte {
+	lines := bytes.Split(output, []byte("\n"))
+	for l, line := range lines {
+		if bytes.HasPrefix(line, []byte("password=")) {
+			lines[l] = []byte("password=(redacted)")
+		}
+	}
+	return bytes.Join(lines, []byte("\n"))
+}
+
 func printHeader(cmd string, args ...string) {
 	text := "\n(debug) " + cmd + " " + strings.Join(args, " ")
 	_, err := color.New(color.Bold).Println(text)
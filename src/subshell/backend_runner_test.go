@@ -2,6 +2,7 @@ package subshell_test
 
 import (
 	"errors"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -54,6 +55,23 @@ OUTPUT END
 		})
 	})
 
+	t.Run("QueryWithInput", func(t *testing.T) {
+		t.Parallel()
+		t.Run("verbose output does not contain passwords", func(t *testing.T) {
+			tmpDir := t.TempDir()
+			runner := subshell.BackendRunner{Dir: &tmpDir, Verbose: true, Stats: &execute.NoStatistics{}}
+			var output string
+			printed := captureStdout(t, func() {
+				var err error
+				output, err = runner.QueryWithInput("host=git.example.com\npassword=secret\n", []string{}, "cat")
+				assert.NoError(t, err)
+			})
+			assert.Equal(t, "host=git.example.com\npassword=secret", output)
+			assert.Contains(t, printed, "host=git.example.com\npassword=(redacted)")
+			assert.NotContains(t, printed, "secret")
+		})
+	})
+
 	t.Run(".RunMany()", func(t *testing.T) {
 		t.Parallel()
 		tmpDir := t.TempDir()
@@ -70,3 +88,18 @@ OUTPUT END
 		assert.Equal(t, "second", entries[1].Name())
 	})
 }
+
+// captureStdout provides what the given function prints to STDOUT.
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	assert.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = writer
+	f()
+	os.Stdout = stdout
+	assert.NoError(t, writer.Close())
+	printed, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	return string(printed)
+}
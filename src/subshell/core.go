@@ -1,6 +1,25 @@
 // Package subshell provides facilities to execute CLI commands in subshells.
 package subshell
 
+import (
+	"errors"
+	"os/exec"
+	"time"
+)
+
 type Statistics interface {
-	RegisterRun()
+	RegisterCommand(command string, exitCode int, start time.Time, duration time.Duration)
+}
+
+// ExitCode provides the exit code of a command that ended with the given error.
+// It returns -1 if the command didn't run to completion.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
 }
@@ -20,6 +20,11 @@ func (r *FrontendDryRunner) Run(executable string, args ...string) error {
 	return nil
 }
 
+// RunWithEnv prints the given command as if it ran with the given environment variables.
+func (r *FrontendDryRunner) RunWithEnv(env []string, executable string, args ...string) error {
+	return r.Run(executable, args...)
+}
+
 // RunMany runs all given commands in current directory.
 // Commands are provided as a list of argv-style strings.
 // Failed commands abort immediately with the encountered error.
@@ -32,3 +37,8 @@ func (r *FrontendDryRunner) RunMany(commands [][]string) error {
 	}
 	return nil
 }
+
+// RunParallel prints the given commands as if they ran in parallel.
+func (r *FrontendDryRunner) RunParallel(commands [][]string) error {
+	return r.RunMany(commands)
+}
@@ -6,11 +6,17 @@ import (
 	"os/exec"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/git-town/git-town/v8/src/cache"
+	"github.com/git-town/git-town/v8/src/cli"
+	"github.com/git-town/git-town/v8/src/parallel"
 )
 
+// maxParallelCommands is the maximum number of commands that RunParallel runs at the same time.
+const maxParallelCommands = 4
+
 // FrontendRunner executes frontend shell commands.
 type FrontendRunner struct {
 	CurrentBranch   *cache.String
@@ -20,7 +26,13 @@ type FrontendRunner struct {
 
 // Run runs the given command in this ShellRunner's directory.
 func (r *FrontendRunner) Run(cmd string, args ...string) error {
-	r.Stats.RegisterRun()
+	return r.RunWithEnv([]string{}, cmd, args...)
+}
+
+// RunWithEnv runs the given command in this ShellRunner's directory
+// with the given environment variables in addition to the environment of Git Town.
+// Environment variables are provided as "KEY=value" strings.
+func (r *FrontendRunner) RunWithEnv(env []string, cmd string, args ...string) error {
 	var branchName string
 	if r.OmitBranchNames {
 		branchName = ""
@@ -39,7 +51,13 @@ func (r *FrontendRunner) Run(cmd string, args ...string) error {
 	subProcess.Stderr = os.Stderr
 	subProcess.Stdin = os.Stdin
 	subProcess.Stdout = os.Stdout
-	return subProcess.Run()
+	if len(env) > 0 {
+		subProcess.Env = append(os.Environ(), env...)
+	}
+	start := time.Now()
+	err := subProcess.Run()
+	r.Stats.RegisterCommand(FormatCommand("", true, cmd, args...), ExitCode(err), start, time.Since(start))
+	return err
 }
 
 // RunMany runs all given commands in current directory.
@@ -55,6 +73,54 @@ func (r *FrontendRunner) RunMany(commands [][]string) error {
 	return nil
 }
 
+// RunParallel runs the given commands concurrently.
+// To keep the output of the commands from getting interleaved,
+// it buffers their output and prints it in the order of the given commands once they are all done.
+// Commands that run in parallel cannot receive user input,
+// so Git fails instead of prompting for credentials.
+// A single command runs normally and can receive user input.
+func (r *FrontendRunner) RunParallel(commands [][]string) error {
+	if len(commands) == 1 {
+		return r.Run(commands[0][0], commands[0][1:]...)
+	}
+	var branchName string
+	if !r.OmitBranchNames {
+		branchName = r.CurrentBranch.Value()
+	}
+	outputs := make([][]byte, len(commands))
+	starts := make([]time.Time, len(commands))
+	durations := make([]time.Duration, len(commands))
+	jobs := make([]func() error, len(commands))
+	for c, argv := range commands {
+		c, argv := c, argv
+		jobs[c] = func() error {
+			var err error
+			starts[c] = time.Now()
+			subProcess := exec.Command(argv[0], argv[1:]...) // #nosec
+			subProcess.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+			outputs[c], err = subProcess.CombinedOutput()
+			durations[c] = time.Since(starts[c])
+			return err
+		}
+	}
+	progress := cli.NewProgress(fmt.Sprintf("Running %d commands in parallel", len(commands)), len(commands))
+	errs := parallel.Run(maxParallelCommands, jobs, progress.Update)
+	progress.Done()
+	for c, argv := range commands {
+		r.Stats.RegisterCommand(FormatCommand("", true, argv[0], argv[1:]...), ExitCode(errs[c]), starts[c], durations[c])
+		PrintCommand(branchName, r.OmitBranchNames, argv[0], argv[1:]...)
+		_, _ = os.Stdout.Write(outputs[c])
+	}
+	for c, err := range errs {
+		if err != nil {
+			return fmt.Errorf(`error running command %q: %w
+Git cannot ask for credentials while Git Town runs commands in parallel.
+If this remote needs credentials, please store them in a Git credential helper or an SSH agent`, commands[c], err)
+		}
+	}
+	return nil
+}
+
 // PrintCommand prints the given command-line operation on the console.
 func PrintCommand(branch string, omitBranch bool, cmd string, args ...string) {
 	header := FormatCommand(branch, omitBranch, cmd, args...)
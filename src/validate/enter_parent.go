@@ -15,7 +15,7 @@ func EnterParent(branch, defaultParent string, backend *git.BackendCommands) (st
 	}
 	filteredChoices := filterOutSelfAndDescendants(branch, choices, backend.Config)
 	return dialog.Select(dialog.SelectArgs{
-		Options: append([]string{perennialBranchOption}, filteredChoices...),
+		Options: append([]string{PerennialBranchOption}, filteredChoices...),
 		Message: fmt.Sprintf(parentBranchPromptTemplate, branch),
 		Default: defaultParent,
 	})
@@ -32,7 +32,7 @@ func filterOutSelfAndDescendants(branch string, choices []string, config *git.Re
 	return result
 }
 
-const (
-	parentBranchPromptTemplate = "Please specify the parent branch of %q:"
-	perennialBranchOption      = "<none> (perennial branch)"
-)
+const parentBranchPromptTemplate = "Please specify the parent branch of %q:"
+
+// PerennialBranchOption is the choice in the parent dialog that makes the branch a perennial branch.
+const PerennialBranchOption = "<none> (perennial branch)"
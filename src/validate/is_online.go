@@ -3,17 +3,25 @@ package validate
 import (
 	"errors"
 
+	"github.com/git-town/git-town/v8/src/config"
 	"github.com/git-town/git-town/v8/src/git"
 )
 
 // IsOnline verifies that the given Git repository is online.
-func IsOnline(config *git.RepoConfig) error {
-	isOffline, err := config.IsOffline()
+// In automatic offline mode, it verifies that origin is reachable.
+func IsOnline(repoConfig *git.RepoConfig) error {
+	mode, err := repoConfig.OfflineMode()
 	if err != nil {
 		return err
 	}
-	if isOffline {
+	switch mode {
+	case config.OfflineModeOn:
 		return errors.New("this command requires an active internet connection")
+	case config.OfflineModeAuto:
+		if !repoConfig.IsOriginReachable() {
+			return errors.New("cannot reach origin. This command requires an active internet connection")
+		}
+	case config.OfflineModeOff:
 	}
 	return nil
 }
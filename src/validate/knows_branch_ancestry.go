@@ -26,6 +26,10 @@ func KnowsBranchAncestry(branch, defaultBranch string, backend *git.BackendComma
 	if backend.Config.IsMainBranch(branch) || backend.Config.IsPerennialBranch(branch) || backend.Config.HasParentBranch(branch) {
 		return nil
 	}
+	if backend.Config.IsObservedBranch(branch) || backend.Config.IsContributionBranch(branch) {
+		// Git Town doesn't sync observed and contribution branches with their parent
+		return nil
+	}
 	for {
 		parent := backend.Config.ParentBranch(currentBranch)
 		if parent == "" { //nolint:nestif
@@ -37,7 +41,7 @@ func KnowsBranchAncestry(branch, defaultBranch string, backend *git.BackendComma
 			if err != nil {
 				return
 			}
-			if parent == perennialBranchOption {
+			if parent == PerennialBranchOption {
 				err = backend.Config.AddToPerennialBranches(currentBranch)
 				if err != nil {
 					return
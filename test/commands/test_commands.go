@@ -41,6 +41,11 @@ func (r *TestCommands) AddSubmodule(url string) error {
 	return err
 }
 
+// AddWorktree checks out the given branch in a new worktree at the given path.
+func (r *TestCommands) AddWorktree(path, branch string) error {
+	return r.Run("git", "worktree", "add", path, branch)
+}
+
 // BranchHierarchyTable provides the currently configured branch hierarchy information as a DataTable.
 func (r *TestCommands) BranchHierarchyTable() datatable.DataTable {
 	result := datatable.DataTable{}
@@ -130,6 +135,21 @@ func (r *TestCommands) CreateFile(name, content string) error {
 	return nil
 }
 
+// CreateHomeFile creates a file with the given name and content in the home directory of this repository's user.
+func (r *TestCommands) CreateHomeFile(name, content string) error {
+	filePath := filepath.Join(r.HomeDir, name)
+	folderPath := filepath.Dir(filePath)
+	err := os.MkdirAll(folderPath, 0o700)
+	if err != nil {
+		return fmt.Errorf("cannot create folder %q: %w", folderPath, err)
+	}
+	err = os.WriteFile(filePath, []byte(content), 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot create file %q: %w", name, err)
+	}
+	return nil
+}
+
 // CreatePerennialBranches creates perennial branches with the given names in this repository.
 func (r *TestCommands) CreatePerennialBranches(names ...string) error {
 	for _, name := range names {
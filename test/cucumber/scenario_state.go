@@ -8,12 +8,16 @@ import (
 	"github.com/cucumber/messages-go/v10"
 	"github.com/git-town/git-town/v8/src/stringslice"
 	"github.com/git-town/git-town/v8/test/datatable"
+	"github.com/git-town/git-town/v8/test/fakegithub"
 	"github.com/git-town/git-town/v8/test/fixture"
 	"github.com/git-town/git-town/v8/test/helpers"
 )
 
 // ScenarioState constains the state that is shared by all steps within a scenario.
 type ScenarioState struct {
+	// the fake GitHub API of the origin repo, nil if the scenario doesn't use one
+	fakeGitHub *fakegithub.Server
+
 	// the Fixture used in the current scenario
 	fixture fixture.Fixture
 
@@ -26,6 +30,9 @@ type ScenarioState struct {
 	// initialCommits describes the commits in this Git environment before the WHEN steps ran.
 	initialCommits *messages.PickleStepArgument_PickleTable
 
+	// initialCommitSHAs contains the SHAs of the commits that the GIVEN steps created, by commit message
+	initialCommitSHAs map[string]string
+
 	// initialBranchHierarchy describes the branch hierarchy before the WHEN steps ran.
 	initialBranchHierarchy datatable.DataTable
 
@@ -50,10 +57,12 @@ type ScenarioState struct {
 
 // Reset restores the null value of this ScenarioState.
 func (state *ScenarioState) Reset(gitEnv fixture.Fixture) {
+	state.fakeGitHub = nil
 	state.fixture = gitEnv
 	state.initialLocalBranches = []string{"main"}
 	state.initialRemoteBranches = []string{"main"}
 	state.initialCommits = nil
+	state.initialCommitSHAs = map[string]string{}
 	state.initialBranchHierarchy = datatable.DataTable{Cells: [][]string{{"BRANCH", "PARENT"}}}
 	state.initialCurrentBranch = ""
 	state.runOutput = ""
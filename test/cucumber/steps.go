@@ -17,6 +17,7 @@ import (
 	"github.com/git-town/git-town/v8/src/config"
 	"github.com/git-town/git-town/v8/src/stringslice"
 	"github.com/git-town/git-town/v8/test/datatable"
+	"github.com/git-town/git-town/v8/test/fakegithub"
 	"github.com/git-town/git-town/v8/test/fixture"
 	"github.com/git-town/git-town/v8/test/git"
 	"github.com/git-town/git-town/v8/test/helpers"
@@ -75,6 +76,9 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		if e != nil {
 			fmt.Printf("failed scenario, investigate state in %q\n", state.fixture.Dir)
 		}
+		if state.fakeGitHub != nil {
+			state.fakeGitHub.Close()
+		}
 		if state.runExitCode != 0 && !state.runExitCodeChecked {
 			cli.PrintError(fmt.Errorf("%s - scenario %q doesn't document exit code %d", scenario.GetUri(), scenario.GetName(), state.runExitCode))
 			os.Exit(1)
@@ -187,6 +191,10 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return state.fixture.DevRepo.CreateFile(name, content)
 	})
 
+	suite.Step(`^an executable "([^"]+)" hook:$`, func(name string, content *messages.PickleStepArgument_PickleDocString) error {
+		return state.fixture.DevRepo.CreateFile(filepath.Join(".git", "git-town", "hooks", name), content.Content)
+	})
+
 	suite.Step(`^an upstream repo$`, func() error {
 		return state.fixture.AddUpstream()
 	})
@@ -202,6 +210,17 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^file "([^"]+)" contains "([^"]+)"$`, func(name, text string) error {
+		content, err := state.fixture.DevRepo.FileContent(name)
+		if err != nil {
+			return fmt.Errorf("cannot read file %q: %w", name, err)
+		}
+		if !strings.Contains(content, text) {
+			return fmt.Errorf("file %q does not contain %q:\n\n%s", name, text, content)
+		}
+		return nil
+	})
+
 	suite.Step(`^file "([^"]*)" still has content "([^"]*)"$`, func(file, expectedContent string) error {
 		actualContent, err := state.fixture.DevRepo.FileContent(file)
 		if err != nil {
@@ -213,6 +232,10 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^branch "([^"]+)" is checked out in another worktree$`, func(branch string) error {
+		return state.fixture.DevRepo.AddWorktree(filepath.Join(state.fixture.Dir, "worktree-"+branch), branch)
+	})
+
 	suite.Step(`^Git has version "([^"]*)"$`, func(version string) error {
 		err := state.fixture.DevRepo.MockGit(version)
 		return err
@@ -339,6 +362,17 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^it prints once:$`, func(expected *messages.PickleStepArgument_PickleDocString) error {
+		if state.runExitCode != 0 {
+			return fmt.Errorf("unexpected exit code %d", state.runExitCode)
+		}
+		count := strings.Count(stripansi.Strip(state.runOutput), expected.Content)
+		if count != 1 {
+			return fmt.Errorf("expected to find the text once but found it %d times:\n\nEXPECTED:\n\n%q\n\nACTUAL:\n\n%q", count, expected.Content, state.runOutput)
+		}
+		return nil
+	})
+
 	suite.Step(`^it prints no output$`, func() error {
 		output := state.runOutput
 		if output != "" {
@@ -385,6 +419,7 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		expanded, err := dataTable.Expand(
 			&state.fixture.DevRepo,
 			state.fixture.OriginRepo,
+			state.initialCommitSHAs,
 		)
 		if err != nil {
 			return err
@@ -416,6 +451,18 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^my GitHub CLI configuration:$`, func(content *messages.PickleStepArgument_PickleDocString) error {
+		return state.fixture.DevRepo.CreateHomeFile(filepath.Join(".config", "gh", "hosts.yml"), content.Content)
+	})
+
+	suite.Step(`^my GitLab CLI configuration:$`, func(content *messages.PickleStepArgument_PickleDocString) error {
+		return state.fixture.DevRepo.CreateHomeFile(filepath.Join(".config", "glab-cli", "config.yml"), content.Content)
+	})
+
+	suite.Step(`^my SSH configuration:$`, func(content *messages.PickleStepArgument_PickleDocString) error {
+		return state.fixture.DevRepo.CreateHomeFile(filepath.Join(".ssh", "config"), content.Content)
+	})
+
 	suite.Step(`^my repo does not have an origin$`, func() error {
 		err := state.fixture.DevRepo.RemoveRemote(config.OriginRemote)
 		if err != nil {
@@ -500,6 +547,14 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return state.fixture.DevRepo.Config.SetOffline(true)
 	})
 
+	suite.Step(`^automatic offline mode is enabled$`, func() error {
+		return state.fixture.DevRepo.Config.SetOfflineMode(config.OfflineModeAuto)
+	})
+
+	suite.Step(`^origin is unreachable$`, func() error {
+		return state.fixture.DevRepo.Run("git", "remote", "set-url", config.OriginRemote, filepath.Join(state.fixture.Dir, "unreachable"))
+	})
+
 	suite.Step(`^origin deletes the "([^"]*)" branch$`, func(name string) error {
 		state.initialRemoteBranches = stringslice.Remove(state.initialRemoteBranches, name)
 		return state.fixture.OriginRepo.RemoveBranch(name)
@@ -514,6 +569,11 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return err
 	})
 
+	suite.Step(`^global Git setting "([^"]+)" is "([^"]*)"$`, func(name, value string) error {
+		_, err := state.fixture.DevRepo.Config.SetGlobalConfigValue(name, value)
+		return err
+	})
+
 	suite.Step(`^global setting "([^"]*)" is "([^"]*)"$`, func(name, value string) error {
 		_, err := state.fixture.DevRepo.Config.SetGlobalConfigValue("git-town."+name, value)
 		return err
@@ -600,6 +660,12 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		if err != nil {
 			return fmt.Errorf("cannot create commits: %w", err)
 		}
+		for _, commit := range commits {
+			sha, err := state.fixture.DevRepo.ShaForCommit(commit.Message)
+			if err == nil {
+				state.initialCommitSHAs[commit.Message] = sha
+			}
+		}
 		// restore the initial branch
 		if state.initialCurrentBranch == "" {
 			return state.fixture.DevRepo.CheckoutBranch("main")
@@ -848,11 +914,46 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^the origin is a GitHub server with the pull requests$`, func(table *messages.PickleStepArgument_PickleTable) error {
+		pullRequests, err := fakegithub.FromGherkin(table)
+		if err != nil {
+			return err
+		}
+		state.fakeGitHub = fakegithub.Start(pullRequests)
+		certFile := filepath.Join(state.fixture.Dir, "github.pem")
+		err = state.fakeGitHub.WriteCertificate(certFile)
+		if err != nil {
+			return err
+		}
+		state.fixture.DevRepo.SetTestEnv("SSL_CERT_FILE", certFile)
+		state.fixture.DevRepo.SetTestOrigin("https://github.example.com/org/repo.git")
+		return state.fixture.DevRepo.RunMany([][]string{
+			{"git", "config", config.CodeHostingDriverKey, "github"},
+			{"git", "config", config.CodeHostingOriginHostnameKey, state.fakeGitHub.Host()},
+			{"git", "config", config.GithubTokenKey, "token"},
+		})
+	})
+
 	suite.Step(`^the origin is "([^"]*)"$`, func(origin string) error {
 		state.fixture.DevRepo.SetTestOrigin(origin)
 		return nil
 	})
 
+	suite.Step(`^the parent branch of "([^"]+)" is "([^"]+)"$`, func(branch, parentBranch string) error {
+		return state.fixture.DevRepo.Config.SetParent(branch, parentBranch)
+	})
+
+	suite.Step(`^the pull requests are now$`, func(table *messages.PickleStepArgument_PickleTable) error {
+		have := fakegithub.Table(state.fakeGitHub.PullRequests())
+		diff, errCount := have.EqualGherkin(table)
+		if errCount > 0 {
+			fmt.Printf("\nERROR! Found %d differences in the pull requests\n\n", errCount)
+			fmt.Println(diff)
+			return fmt.Errorf("mismatching pull requests found, see diff above")
+		}
+		return nil
+	})
+
 	suite.Step(`^the perennial branches are "([^"]+)"$`, func(name string) error {
 		return state.fixture.DevRepo.Config.AddToPerennialBranches(name)
 	})
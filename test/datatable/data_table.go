@@ -76,7 +76,8 @@ func (table *DataTable) EqualGherkin(other *messages.PickleStepArgument_PickleTa
 }
 
 // Expand returns a new DataTable instance with the placeholders in this datatable replaced with the given values.
-func (table *DataTable) Expand(localRepo runner, remoteRepo runner) (DataTable, error) {
+// initialSHAs contains the SHAs of the commits that the scenario created before running Git Town, by commit message.
+func (table *DataTable) Expand(localRepo runner, remoteRepo runner, initialSHAs map[string]string) (DataTable, error) {
 	var templateRE *regexp.Regexp
 	var templateOnce sync.Once
 	result := DataTable{}
@@ -95,6 +96,13 @@ func (table *DataTable) Expand(localRepo runner, remoteRepo runner) (DataTable,
 						return DataTable{}, fmt.Errorf("cannot determine SHA: %w", err)
 					}
 					cell = strings.Replace(cell, match, sha, 1)
+				case strings.HasPrefix(match, "{{ sha-before-run "):
+					commitName := match[19 : len(match)-4]
+					sha, has := initialSHAs[commitName]
+					if !has {
+						return DataTable{}, fmt.Errorf("cannot determine the initial SHA of commit %q", commitName)
+					}
+					cell = strings.Replace(cell, match, sha, 1)
 				case strings.HasPrefix(match, "{{ sha-in-origin "):
 					commitName := match[18 : len(match)-4]
 					sha, err := remoteRepo.ShaForCommit(commitName)
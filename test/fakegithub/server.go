@@ -0,0 +1,159 @@
+// Package fakegithub provides a fake GitHub API for end-to-end tests.
+package fakegithub
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PullRequest is a pull request stored by the fake GitHub API.
+type PullRequest struct {
+	Number int
+	Head   string
+	Base   string
+	Title  string
+	Body   string
+	State  string // "open" or "closed"
+}
+
+// Server is a fake GitHub Enterprise API that serves the pull requests of a single repository over HTTPS.
+// It supports the API calls that Git Town makes to find and move pull requests.
+type Server struct {
+	*httptest.Server
+	mutex        sync.Mutex
+	pullRequests []PullRequest
+}
+
+// Start starts a fake GitHub API that contains the given pull requests.
+func Start(pullRequests []PullRequest) *Server {
+	server := Server{Server: nil, mutex: sync.Mutex{}, pullRequests: pullRequests}
+	server.Server = httptest.NewTLSServer(http.HandlerFunc(server.handle))
+	return &server
+}
+
+// Host provides the hostname and port of this server.
+func (s *Server) Host() string {
+	return strings.TrimPrefix(s.URL, "https://")
+}
+
+// PullRequests provides the pull requests that this server contains.
+func (s *Server) PullRequests() []PullRequest {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]PullRequest{}, s.pullRequests...)
+}
+
+// WriteCertificate writes the TLS certificate of this server into the given file
+// so that clients can trust it via the SSL_CERT_FILE environment variable.
+func (s *Server) WriteCertificate(path string) error {
+	content := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw})
+	return os.WriteFile(path, content, 0o600)
+}
+
+func (s *Server) handle(writer http.ResponseWriter, request *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	path := regexp.MustCompile(`^/api/v3/repos/[^/]+/[^/]+/`).ReplaceAllString(request.URL.Path, "")
+	pullRequestPath := regexp.MustCompile(`^pulls/(\d+)$`)
+	switch {
+	case request.Method == http.MethodGet && path == "pulls":
+		s.listPullRequests(writer, request)
+	case request.Method == http.MethodPost && path == "pulls":
+		s.createPullRequest(writer, request)
+	case request.Method == http.MethodPatch && pullRequestPath.MatchString(path):
+		number, _ := strconv.Atoi(pullRequestPath.FindStringSubmatch(path)[1])
+		s.editPullRequest(writer, request, number)
+	default:
+		respond(writer, http.StatusNotFound, map[string]any{"message": "Not Found"})
+	}
+}
+
+func (s *Server) listPullRequests(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	_, head, _ := strings.Cut(query.Get("head"), ":")
+	state := query.Get("state")
+	result := []any{}
+	for _, pullRequest := range s.pullRequests {
+		if (head == "" || pullRequest.Head == head) &&
+			(query.Get("base") == "" || pullRequest.Base == query.Get("base")) &&
+			(state == "all" || pullRequest.State == state) {
+			result = append(result, toJSON(pullRequest))
+		}
+	}
+	respond(writer, http.StatusOK, result)
+}
+
+func (s *Server) createPullRequest(writer http.ResponseWriter, request *http.Request) {
+	var data struct {
+		Title string `json:"title"`
+		Head  string `json:"head"`
+		Base  string `json:"base"`
+		Body  string `json:"body"`
+	}
+	err := json.NewDecoder(request.Body).Decode(&data)
+	if err != nil {
+		respond(writer, http.StatusBadRequest, map[string]any{"message": err.Error()})
+		return
+	}
+	pullRequest := PullRequest{
+		Number: len(s.pullRequests) + 1,
+		Head:   data.Head,
+		Base:   data.Base,
+		Title:  data.Title,
+		Body:   data.Body,
+		State:  "open",
+	}
+	s.pullRequests = append(s.pullRequests, pullRequest)
+	respond(writer, http.StatusCreated, toJSON(pullRequest))
+}
+
+func (s *Server) editPullRequest(writer http.ResponseWriter, request *http.Request, number int) {
+	var data struct {
+		State *string `json:"state"`
+		Base  *string `json:"base"`
+	}
+	err := json.NewDecoder(request.Body).Decode(&data)
+	if err != nil {
+		respond(writer, http.StatusBadRequest, map[string]any{"message": err.Error()})
+		return
+	}
+	for p := range s.pullRequests {
+		if s.pullRequests[p].Number != number {
+			continue
+		}
+		if data.State != nil {
+			s.pullRequests[p].State = *data.State
+		}
+		if data.Base != nil {
+			s.pullRequests[p].Base = *data.Base
+		}
+		respond(writer, http.StatusOK, toJSON(s.pullRequests[p]))
+		return
+	}
+	respond(writer, http.StatusNotFound, map[string]any{"message": fmt.Sprintf("pull request #%d not found", number)})
+}
+
+func respond(writer http.ResponseWriter, status int, data any) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	_ = json.NewEncoder(writer).Encode(data)
+}
+
+func toJSON(pullRequest PullRequest) map[string]any {
+	return map[string]any{
+		"number": pullRequest.Number,
+		"title":  pullRequest.Title,
+		"body":   pullRequest.Body,
+		"state":  pullRequest.State,
+		"head":   map[string]any{"ref": pullRequest.Head, "sha": pullRequest.Head},
+		"base":   map[string]any{"ref": pullRequest.Base},
+	}
+}
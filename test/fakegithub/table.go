@@ -0,0 +1,40 @@
+package fakegithub
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cucumber/messages-go/v10"
+	"github.com/git-town/git-town/v8/test/datatable"
+)
+
+// FromGherkin provides the pull requests described by the given Gherkin table
+// with the columns NUMBER, HEAD, BASE, TITLE, and STATE.
+func FromGherkin(table *messages.PickleStepArgument_PickleTable) ([]PullRequest, error) {
+	result := []PullRequest{}
+	for _, row := range datatable.FromGherkin(table).Cells[1:] {
+		number, err := strconv.Atoi(row[0])
+		if err != nil {
+			return result, fmt.Errorf("invalid pull request number %q: %w", row[0], err)
+		}
+		result = append(result, PullRequest{
+			Number: number,
+			Head:   row[1],
+			Base:   row[2],
+			Title:  row[3],
+			Body:   "",
+			State:  row[4],
+		})
+	}
+	return result, nil
+}
+
+// Table provides the given pull requests in the format of FromGherkin.
+func Table(pullRequests []PullRequest) datatable.DataTable {
+	result := datatable.DataTable{}
+	result.AddRow("NUMBER", "HEAD", "BASE", "TITLE", "STATE")
+	for _, pullRequest := range pullRequests {
+		result.AddRow(strconv.Itoa(pullRequest.Number), pullRequest.Head, pullRequest.Base, pullRequest.Title, pullRequest.State)
+	}
+	return result
+}
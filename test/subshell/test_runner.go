@@ -35,6 +35,9 @@ type TestRunner struct {
 	// the directory that contains the global Git configuration
 	HomeDir string
 
+	// optional additional environment variables for the commands that this runner executes
+	testEnv map[string]string `exhaustruct:"optional"`
+
 	// optional content of the GIT_TOWN_REMOTE environment variable
 	testOrigin string `exhaustruct:"optional"`
 
@@ -150,6 +153,13 @@ func (r *TestRunner) Query(name string, arguments ...string) (string, error) {
 	return r.QueryWith(&Options{}, name, arguments...)
 }
 
+// QueryWithInput runs the given command with the given input on STDIN
+// and the given additional environment variables.
+// Overrides will be used and removed when done.
+func (r *TestRunner) QueryWithInput(input string, env []string, name string, arguments ...string) (string, error) {
+	return r.QueryWith(&Options{Env: append(os.Environ(), env...), Input: []string{input}}, name, arguments...)
+}
+
 // QueryString runs the given command (including possible arguments).
 // Overrides will be used and removed when done.
 func (r *TestRunner) QueryString(fullCmd string) (string, error) {
@@ -188,6 +198,10 @@ func (r *TestRunner) QueryWithCode(opts *Options, cmd string, args ...string) (s
 	if r.testOrigin != "" {
 		opts.Env = envvars.Replace(opts.Env, "GIT_TOWN_REMOTE", r.testOrigin)
 	}
+	// add the custom environment variables
+	for name, value := range r.testEnv {
+		opts.Env = envvars.Replace(opts.Env, name, value)
+	}
 	// add the custom bin dir to the PATH
 	if r.usesBinDir {
 		opts.Env = envvars.PrependPath(opts.Env, r.BinDir)
@@ -275,6 +289,14 @@ func (r *TestRunner) RunMany(commands [][]string) error {
 	return nil
 }
 
+// SetTestEnv adds the given environment variable to subsequent runs of commands.
+func (r *TestRunner) SetTestEnv(name, value string) {
+	if r.testEnv == nil {
+		r.testEnv = map[string]string{}
+	}
+	r.testEnv[name] = value
+}
+
 // SetTestOrigin adds the given environment variable to subsequent runs of commands.
 func (r *TestRunner) SetTestOrigin(content string) {
 	r.testOrigin = content
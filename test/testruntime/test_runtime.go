@@ -59,17 +59,21 @@ func New(workingDir, homeDir, binDir string) TestRuntime {
 		HomeDir:    homeDir,
 		BinDir:     binDir,
 	}
+	backendRunner := prodshell.BackendRunner{Dir: &workingDir, Verbose: false, Stats: &execute.NoStatistics{}}
 	config := git.RepoConfig{
-		GitTown:            config.NewGitTown(&mockingRunner),
-		CurrentBranchCache: &cache.String{},
-		DryRun:             false,
-		IsRepoCache:        &cache.Bool{},
-		RemoteBranchCache:  &cache.Strings{},
-		RemotesCache:       &cache.Strings{},
-		RootDirCache:       &cache.String{},
+		GitTown:               config.NewGitTown(&mockingRunner),
+		BranchesSnapshotCache: nil, // tests change branches behind the back of BackendCommands
+		CurrentBranchCache:    &cache.String{},
+		DryRun:                false,
+		GitVersionCache:       &cache.String{},
+		IsOfflineCache:        &cache.Bool{},
+		IsRepoCache:           &cache.Bool{},
+		ProbeRunner:           backendRunner,
+		RemotesCache:          &cache.Strings{},
+		RootDirCache:          &cache.String{},
 	}
 	backendCommands := git.BackendCommands{
-		BackendRunner: prodshell.BackendRunner{Dir: &workingDir, Verbose: false, Stats: &execute.NoStatistics{}},
+		BackendRunner: backendRunner,
 		Config:        &config,
 	}
 	testCommands := commands.TestCommands{